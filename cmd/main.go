@@ -1,12 +1,15 @@
 package main
 
 import (
-	"log"
+	"context"
+	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	// Working hours use IANA time zones; embed them for images without tzdata.
+	_ "time/tzdata"
 
-	"pr-reviwer-assigner/internal/config"
-	"pr-reviwer-assigner/internal/di"
-	"pr-reviwer-assigner/internal/server"
+	"pr-reviwer-assigner/internal/cli"
 )
 
 func main() {
@@ -15,17 +18,12 @@ func main() {
 		cfgPath = "config/config.json"
 	}
 
-	cfg, err := config.Load(cfgPath)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	container := di.NewContainer(cfg)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	err := cli.New(cfgPath, os.Stdout, os.Stderr).Run(ctx, os.Args[1:])
+	stop()
 
-	app, err := server.New(cfg, container)
-	if err != nil {
-		log.Fatal(err)
+	if err != nil && err.Error() != "" {
+		fmt.Fprintln(os.Stderr, err)
 	}
-
-	app.Run()
+	os.Exit(cli.ExitCode(err))
 }
@@ -0,0 +1,200 @@
+// Package cli implements the prservice command line: serve, the default,
+// migrate and the admin commands, which call the services layer directly
+// against the configured database.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"pr-reviwer-assigner/internal/config"
+	"pr-reviwer-assigner/internal/di"
+	"pr-reviwer-assigner/internal/domain/services"
+)
+
+const usage = `usage: prservice [command]
+
+commands:
+  serve                                  run the HTTP service (default)
+  migrate up|down|status|create <name>   manage the schema
+  team add|get|deactivate                manage teams
+  user set-active|reviews                manage users
+  pr create|merge|reassign|show          manage pull requests
+
+Admin commands print a table, or the HTTP API's JSON with -o json.
+Run "prservice <command> <subcommand> -h" for the flags of a subcommand.
+The config is read from CONFIG_PATH, config/config.json by default.`
+
+// UsageError is returned for unknown commands and bad arguments. Msg is empty
+// when the flag package has already reported the error.
+type UsageError struct {
+	Msg string
+}
+
+func (e *UsageError) Error() string {
+	return e.Msg
+}
+
+func usageErrorf(format string, args ...any) error {
+	return &UsageError{Msg: fmt.Sprintf(format, args...)}
+}
+
+// ExitCode is the process exit code for the error Run returned: 0 on
+// success, 2 for usage errors and 1 otherwise.
+func ExitCode(err error) int {
+	var usageErr *UsageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &usageErr):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// Services are what the admin commands call.
+type Services struct {
+	Teams services.TeamService
+	Users services.UserService
+	PRs   services.PRService
+}
+
+type CLI struct {
+	ConfigPath string
+	Stdout     io.Writer
+	Stderr     io.Writer
+	// Connect builds the services of the admin commands; it is called once
+	// the arguments are valid.
+	Connect func() (*Services, error)
+}
+
+func New(cfgPath string, stdout, stderr io.Writer) *CLI {
+	c := &CLI{
+		ConfigPath: cfgPath,
+		Stdout:     stdout,
+		Stderr:     stderr,
+	}
+	c.Connect = c.connect
+	return c
+}
+
+// Run runs the command named by args, without the program name.
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	err := c.run(ctx, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
+func (c *CLI) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return c.serve()
+	}
+
+	switch args[0] {
+	case "serve":
+		if len(args) > 1 {
+			return usageErrorf("usage: prservice serve")
+		}
+		return c.serve()
+	case "migrate":
+		return c.migrate(ctx, args[1:])
+	case "team":
+		return c.team(ctx, args[1:])
+	case "user":
+		return c.user(ctx, args[1:])
+	case "pr":
+		return c.pr(ctx, args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Fprintln(c.Stdout, usage)
+		return nil
+	default:
+		return usageErrorf("unknown command %q\n\n%s", args[0], usage)
+	}
+}
+
+// connect wires the services like the HTTP service does. Admin commands never
+// migrate: a schema the running service doesn't have yet would break it.
+func (c *CLI) connect() (*Services, error) {
+	cfg, err := config.Load(c.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	cfg.AutoMigrate = false
+
+	container := di.NewContainer(cfg)
+
+	return &Services{
+		Teams: container.GetTeamService(),
+		Users: container.GetUserService(),
+		PRs:   container.GetPRService(),
+	}, nil
+}
+
+// flags returns a flag set for the subcommand with the -o flag every admin
+// command has.
+func (c *CLI) flags(name, args string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("prservice "+name, flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: prservice %s [flags] %s\n", name, args)
+		fs.PrintDefaults()
+	}
+	output := fs.String("o", formatTable, "output format: table or json")
+	return fs, output
+}
+
+// parse parses args and checks the output format.
+func parse(fs *flag.FlagSet, output *string, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &UsageError{}
+	}
+	if *output != formatTable && *output != formatJSON {
+		return usageErrorf("unknown output format %q: want table or json", *output)
+	}
+	return nil
+}
+
+// badArgs prints the usage of the subcommand for wrong positional arguments.
+func badArgs(fs *flag.FlagSet) error {
+	fs.Usage()
+	return &UsageError{}
+}
+
+// subcommand splits args into the subcommand and its arguments.
+func subcommand(command string, subcommands []string, args []string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, usageErrorf("usage: prservice %s %s", command, strings.Join(subcommands, "|"))
+	}
+	for _, s := range subcommands {
+		if args[0] == s {
+			return s, args[1:], nil
+		}
+	}
+	return "", nil, usageErrorf("unknown %s subcommand %q: want %s", command, args[0], strings.Join(subcommands, "|"))
+}
+
+// listFlag collects a flag that may be repeated or hold comma-separated values.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
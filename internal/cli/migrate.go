@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"pr-reviwer-assigner/internal/config"
+	"pr-reviwer-assigner/internal/infrastructure/database"
+	"pr-reviwer-assigner/internal/migrate"
+	"pr-reviwer-assigner/migrations"
+)
+
+// migrationsDir is where "migrate create" writes new files, relative to the
+// repository root.
+const migrationsDir = "migrations"
+
+// migrate implements "prservice migrate". Every subcommand but create works on
+// the configured database.
+func (c *CLI) migrate(ctx context.Context, args []string) error {
+	cmd, args, err := subcommand("migrate", []string{"up", "down", "status", "create"}, args)
+	if err != nil {
+		return err
+	}
+
+	if cmd == "create" {
+		if len(args) != 1 {
+			return usageErrorf("usage: prservice migrate create <name>")
+		}
+		up, down, err := migrate.Create(migrationsDir, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.Stdout, "created %s\ncreated %s\n", up, down)
+		return nil
+	}
+	if len(args) != 0 {
+		return usageErrorf("usage: prservice migrate %s", cmd)
+	}
+
+	cfg, err := config.Load(c.ConfigPath)
+	if err != nil {
+		return err
+	}
+	db, err := database.New(cfg.DB)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migrate.New(db, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Fprintf(c.Stdout, "applied %s\n", m)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(c.Stdout, "no pending migrations")
+		}
+	case "down":
+		m, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			fmt.Fprintln(c.Stdout, "no applied migrations")
+			return nil
+		}
+		fmt.Fprintf(c.Stdout, "rolled back %s\n", m)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(c.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "MIGRATION\tAPPLIED AT")
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.UTC().Format(time.RFC3339)
+			}
+			if s.Unknown {
+				applied += " (no files in this build)"
+			}
+			fmt.Fprintf(w, "%s\t%s\n", s.Migration, applied)
+		}
+		return w.Flush()
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+)
+
+// Output formats of the admin commands.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// print writes v as indented JSON, or the table written by table.
+func (c *CLI) print(format string, v any, table func(w io.Writer)) error {
+	if format == formatJSON {
+		enc := json.NewEncoder(c.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(c.Stdout, 0, 4, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
+
+func writeMembers(w io.Writer, members []dto.TeamMember) {
+	fmt.Fprintln(w, "USER_ID\tUSERNAME\tACTIVE")
+	for _, m := range members {
+		fmt.Fprintf(w, "%s\t%s\t%t\n", m.ID, m.Name, m.IsActive)
+	}
+}
+
+func writeUser(w io.Writer, u *dto.User) {
+	fmt.Fprintln(w, "USER_ID\tUSERNAME\tTEAM\tACTIVE")
+	fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", u.ID, u.Name, u.Team, u.IsActive)
+}
+
+func writePRShorts(w io.Writer, prs []dto.PRShort) {
+	fmt.Fprintln(w, "PULL_REQUEST_ID\tNAME\tAUTHOR\tSTATUS")
+	for _, pr := range prs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", pr.ID, pr.Name, pr.AuthorID, pr.Status)
+	}
+}
+
+// writePR writes the pull request's fields followed by its reviewers.
+func writePR(w io.Writer, pr *dto.PR) {
+	reviewers := fmt.Sprintf("%d of %d requested", len(pr.Reviewers), pr.RequestedReviewers)
+	if pr.Understaffed {
+		reviewers += ", understaffed"
+	}
+
+	fmt.Fprintf(w, "PULL_REQUEST_ID\t%s\n", pr.ID)
+	fmt.Fprintf(w, "NAME\t%s\n", pr.Name)
+	fmt.Fprintf(w, "AUTHOR\t%s\n", pr.AuthorID)
+	fmt.Fprintf(w, "STATUS\t%s\n", pr.Status)
+	fmt.Fprintf(w, "CREATED_AT\t%s\n", formatTime(&pr.CreatedAt))
+	if pr.MergedAt != nil {
+		fmt.Fprintf(w, "MERGED_AT\t%s\n", formatTime(pr.MergedAt))
+	}
+	if pr.ClosedAt != nil {
+		fmt.Fprintf(w, "CLOSED_AT\t%s\n", formatTime(pr.ClosedAt))
+	}
+	fmt.Fprintf(w, "REVIEWERS\t%s\n", reviewers)
+
+	if len(pr.Assignments) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "REVIEWER\tTEAM\tPOLICY\tVERDICT\tASSIGNED_AT\tSLA_BREACHED_AT")
+	for _, a := range pr.Assignments {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			a.UserID,
+			a.TeamName,
+			orDash(a.Policy),
+			orDash(a.Verdict),
+			formatTime(&a.AssignedAt),
+			formatTime(a.SLABreachedAt),
+		)
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func orDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+)
+
+func (c *CLI) pr(ctx context.Context, args []string) error {
+	cmd, args, err := subcommand("pr", []string{"create", "merge", "reassign", "show"}, args)
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "create":
+		return c.prCreate(ctx, args)
+	case "merge":
+		return c.prMerge(ctx, args)
+	case "reassign":
+		return c.prReassign(ctx, args)
+	default:
+		return c.prShow(ctx, args)
+	}
+}
+
+// prCreate mirrors POST /pullRequest/create; the service normalizes and
+// checks the request.
+func (c *CLI) prCreate(ctx context.Context, args []string) error {
+	fs, output := c.flags("pr create", "<pull_request_id>")
+	var files, skills, required, excluded listFlag
+	name := fs.String("name", "", "pull request name (required)")
+	author := fs.String("author", "", "author user_id (required)")
+	draft := fs.Bool("draft", false, "create the pull request as a draft, without reviewers")
+	fs.Var(&files, "file", "changed file for CODEOWNERS; repeat or separate with commas")
+	fs.Var(&skills, "skill", "required skill; repeat or separate with commas")
+	fs.Var(&required, "require", "required reviewer; repeat or separate with commas")
+	fs.Var(&excluded, "exclude", "excluded reviewer; repeat or separate with commas")
+	var count *int
+	fs.Func("reviewers", "number of reviewers (default: the team's)", func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return fmt.Errorf("want a non-negative number, got %q", v)
+		}
+		count = &n
+		return nil
+	})
+	if err := parse(fs, output, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return badArgs(fs)
+	}
+
+	if *name == "" || *author == "" {
+		return usageErrorf("-name and -author are required")
+	}
+	req := dto.PRRequest{
+		ID:                fs.Arg(0),
+		Name:              *name,
+		AuthorID:          *author,
+		ReviewersCount:    count,
+		Draft:             *draft,
+		ChangedFiles:      files,
+		RequiredSkills:    skills,
+		RequiredReviewers: required,
+		ExcludedReviewers: excluded,
+	}
+
+	svc, err := c.Connect()
+	if err != nil {
+		return err
+	}
+	pr, err := svc.PRs.Create(ctx, req)
+	if err != nil {
+		return fmt.Errorf("pr create: %w", err)
+	}
+
+	return c.printPR(*output, &pr)
+}
+
+// prMerge mirrors POST /pullRequest/merge.
+func (c *CLI) prMerge(ctx context.Context, args []string) error {
+	fs, output := c.flags("pr merge", "<pull_request_id>")
+	if err := parse(fs, output, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return badArgs(fs)
+	}
+
+	svc, err := c.Connect()
+	if err != nil {
+		return err
+	}
+	pr, err := svc.PRs.Merge(ctx, dto.MergeRequest{PullRequestID: fs.Arg(0)})
+	if err != nil {
+		return fmt.Errorf("pr merge: %w", err)
+	}
+
+	return c.printPR(*output, pr)
+}
+
+// prReassign mirrors POST /pullRequest/reassign.
+func (c *CLI) prReassign(ctx context.Context, args []string) error {
+	fs, output := c.flags("pr reassign", "<pull_request_id> <old_user_id>")
+	to := fs.String("to", "", "replacement user_id (default: picked by the team's strategy)")
+	if err := parse(fs, output, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return badArgs(fs)
+	}
+
+	svc, err := c.Connect()
+	if err != nil {
+		return err
+	}
+	pr, replacedBy, err := svc.PRs.Reassign(ctx, dto.ReassignRequest{
+		PullRequestID: fs.Arg(0),
+		OldUserID:     fs.Arg(1),
+		NewUserID:     strings.TrimSpace(*to),
+	})
+	if err != nil {
+		return fmt.Errorf("pr reassign: %w", err)
+	}
+
+	return c.print(*output, dto.ReassignResponse{PR: *pr, ReplacedBy: replacedBy}, func(w io.Writer) {
+		fmt.Fprintf(w, "REPLACED_BY\t%s\n", replacedBy)
+		writePR(w, pr)
+	})
+}
+
+// prShow prints the pull request with its reviewers.
+func (c *CLI) prShow(ctx context.Context, args []string) error {
+	fs, output := c.flags("pr show", "<pull_request_id>")
+	if err := parse(fs, output, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return badArgs(fs)
+	}
+
+	svc, err := c.Connect()
+	if err != nil {
+		return err
+	}
+	pr, err := svc.PRs.Get(ctx, fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("pr show: %w", err)
+	}
+
+	return c.printPR(*output, pr)
+}
+
+func (c *CLI) printPR(format string, pr *dto.PR) error {
+	return c.print(format, dto.PRResponse{PR: *pr}, func(w io.Writer) {
+		writePR(w, pr)
+	})
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"pr-reviwer-assigner/internal/config"
+	"pr-reviwer-assigner/internal/di"
+	"pr-reviwer-assigner/internal/server"
+)
+
+// serve runs the HTTP service until it gets SIGINT or SIGTERM.
+func (c *CLI) serve() error {
+	cfg, err := config.Load(c.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	container := di.NewContainer(cfg)
+
+	app, err := server.New(cfg, container)
+	if err != nil {
+		return err
+	}
+
+	app.Run()
+	return nil
+}
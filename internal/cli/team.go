@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/selector"
+	"pr-reviwer-assigner/internal/domain/services"
+)
+
+func (c *CLI) team(ctx context.Context, args []string) error {
+	cmd, args, err := subcommand("team", []string{"add", "get", "deactivate"}, args)
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "add":
+		return c.teamAdd(ctx, args)
+	case "get":
+		return c.teamGet(args)
+	default:
+		return c.teamDeactivate(ctx, args)
+	}
+}
+
+// teamAdd mirrors POST /team/add; the service fills in the same defaults and
+// checks the team.
+func (c *CLI) teamAdd(ctx context.Context, args []string) error {
+	fs, output := c.flags("team add", "<team_name>")
+	var members, fallbacks listFlag
+	fs.Var(&members, "member", "member as user_id:username; repeat or separate with commas")
+	fs.Var(&fallbacks, "fallback", "fallback team; repeat or separate with commas")
+	strategy := fs.String("strategy", "", fmt.Sprintf("reviewer strategy (default %s)", selector.DefaultStrategy))
+	reviewers := fs.Int("reviewers", 0, fmt.Sprintf("default number of reviewers (default %d)", services.DefaultReviewersCount))
+	minReviewers := fs.Int("min-reviewers", 0, "minimum reviewers_count of a pull request (default min(1, -reviewers))")
+	maxReviewers := fs.Int("max-reviewers", 0, "maximum reviewers_count of a pull request (default max(5, -reviewers))")
+	minApprovals := fs.Int("min-approvals", 0, "approvals needed to merge")
+	if err := parse(fs, output, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || len(members) == 0 {
+		return badArgs(fs)
+	}
+
+	team := dto.Team{
+		Name:             fs.Arg(0),
+		ReviewerStrategy: *strategy,
+		ReviewersCount:   *reviewers,
+		MinReviewers:     *minReviewers,
+		MaxReviewers:     *maxReviewers,
+		FallbackTeams:    fallbacks,
+		MinApprovals:     *minApprovals,
+	}
+	for _, m := range members {
+		id, name, ok := strings.Cut(m, ":")
+		if !ok {
+			return usageErrorf("member %q: want user_id:username", m)
+		}
+		team.Members = append(team.Members, dto.TeamMember{ID: id, Name: name, IsActive: true})
+	}
+
+	svc, err := c.Connect()
+	if err != nil {
+		return err
+	}
+	created, err := svc.Teams.Add(ctx, team)
+	if err != nil {
+		return fmt.Errorf("team add: %w", err)
+	}
+
+	return c.print(*output, dto.TeamResponse{Team: *created}, func(w io.Writer) {
+		writeMembers(w, created.Members)
+	})
+}
+
+// teamGet mirrors GET /team/get.
+func (c *CLI) teamGet(args []string) error {
+	fs, output := c.flags("team get", "<team_name>")
+	if err := parse(fs, output, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return badArgs(fs)
+	}
+
+	svc, err := c.Connect()
+	if err != nil {
+		return err
+	}
+	members, err := svc.Teams.Get(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("team get: %w", err)
+	}
+
+	team := dto.Team{Name: fs.Arg(0), Members: members}
+	return c.print(*output, team, func(w io.Writer) {
+		writeMembers(w, members)
+	})
+}
+
+// teamDeactivate mirrors POST /team/deactivateMembers: the users are
+// deactivated and their open reviews reassigned; the ones that couldn't be
+// are listed.
+func (c *CLI) teamDeactivate(ctx context.Context, args []string) error {
+	fs, output := c.flags("team deactivate", "<team_name> <user_id>...")
+	if err := parse(fs, output, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return badArgs(fs)
+	}
+
+	req := dto.TeamDeactivateRequest{TeamName: strings.TrimSpace(fs.Arg(0))}
+	seen := make(map[string]bool)
+	for _, id := range fs.Args()[1:] {
+		if id = strings.TrimSpace(id); id != "" && !seen[id] {
+			seen[id] = true
+			req.UserIDs = append(req.UserIDs, id)
+		}
+	}
+	if req.TeamName == "" || len(req.UserIDs) == 0 {
+		return badArgs(fs)
+	}
+
+	svc, err := c.Connect()
+	if err != nil {
+		return err
+	}
+	resp, err := svc.Teams.DeactivateMembers(ctx, req)
+	if err != nil {
+		return fmt.Errorf("team deactivate: %w", err)
+	}
+
+	return c.print(*output, resp, func(w io.Writer) {
+		fmt.Fprintln(w, "TEAM\tDEACTIVATED_USER_ID")
+		for _, id := range resp.Deactivated {
+			fmt.Fprintf(w, "%s\t%s\n", resp.TeamName, id)
+		}
+		if len(resp.NotReassigned) > 0 {
+			fmt.Fprintln(w, "\nNOT_REASSIGNED\tUSER_ID\tREASON")
+			for _, f := range resp.NotReassigned {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", f.PullRequestID, f.UserID, f.Reason)
+			}
+		}
+	})
+}
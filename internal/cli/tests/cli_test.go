@@ -0,0 +1,216 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/cli"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+type teamServiceMock struct {
+	services.TeamService
+	added       *dto.Team
+	deactivated *dto.TeamDeactivateRequest
+}
+
+func (m *teamServiceMock) Add(ctx context.Context, team dto.Team) (*dto.Team, error) {
+	m.added = &team
+	created := team
+	created.ReviewerStrategy = "least_loaded"
+	return &created, nil
+}
+
+func (m *teamServiceMock) Get(teamName string) ([]dto.TeamMember, error) {
+	if teamName != "backend" {
+		return nil, errors2.ErrNotFound
+	}
+	return []dto.TeamMember{
+		{ID: "u1", Name: "Alice", IsActive: true},
+		{ID: "u2", Name: "Bob", IsActive: false},
+	}, nil
+}
+
+func (m *teamServiceMock) DeactivateMembers(ctx context.Context, req dto.TeamDeactivateRequest) (*dto.TeamDeactivateResponse, error) {
+	m.deactivated = &req
+	return &dto.TeamDeactivateResponse{TeamName: req.TeamName, Deactivated: req.UserIDs}, nil
+}
+
+type prServiceMock struct {
+	services.PRService
+	created *dto.PRRequest
+}
+
+func (m *prServiceMock) Create(ctx context.Context, req dto.PRRequest) (dto.PR, error) {
+	m.created = &req
+	return dto.PR{ID: req.ID, Name: req.Name, AuthorID: req.AuthorID, Status: dto.PRStatusOpen}, nil
+}
+
+func (m *prServiceMock) Get(ctx context.Context, prID string) (*dto.PR, error) {
+	return &dto.PR{
+		ID:                 prID,
+		Name:               "Add search",
+		AuthorID:           "u1",
+		Status:             dto.PRStatusOpen,
+		Reviewers:          []string{"u2"},
+		RequestedReviewers: 2,
+		Understaffed:       true,
+		Assignments:        []dto.ReviewerAssignment{{UserID: "u2", TeamName: "backend", Policy: "working_hours"}},
+	}, nil
+}
+
+func (m *prServiceMock) Reassign(ctx context.Context, req dto.ReassignRequest) (*dto.PR, string, error) {
+	return &dto.PR{ID: req.PullRequestID, Status: dto.PRStatusOpen, Reviewers: []string{req.NewUserID}}, req.NewUserID, nil
+}
+
+type fixture struct {
+	cli    *cli.CLI
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	teams  *teamServiceMock
+	prs    *prServiceMock
+	// connected is set once a command asked for the services.
+	connected bool
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		stdout: &bytes.Buffer{},
+		stderr: &bytes.Buffer{},
+		teams:  &teamServiceMock{},
+		prs:    &prServiceMock{},
+	}
+	f.cli = cli.New("unused.json", f.stdout, f.stderr)
+	f.cli.Connect = func() (*cli.Services, error) {
+		f.connected = true
+		return &cli.Services{Teams: f.teams, PRs: f.prs}, nil
+	}
+	return f
+}
+
+func (f *fixture) run(args ...string) error {
+	return f.cli.Run(context.Background(), args)
+}
+
+func TestTeamGet_Table(t *testing.T) {
+	f := newFixture()
+
+	require.NoError(t, f.run("team", "get", "backend"))
+	require.Equal(t, "USER_ID  USERNAME  ACTIVE\nu1       Alice     true\nu2       Bob       false\n", f.stdout.String())
+}
+
+func TestTeamGet_NotFound(t *testing.T) {
+	f := newFixture()
+
+	err := f.run("team", "get", "nope")
+	require.ErrorIs(t, err, errors2.ErrNotFound)
+	require.Equal(t, 1, cli.ExitCode(err))
+}
+
+func TestTeamAdd_LeavesDefaultsToService(t *testing.T) {
+	f := newFixture()
+
+	require.NoError(t, f.run("team", "add", "-member", "u1:Alice,u2:Bob", "-member", "u3:Carol", "-o", "json", "backend"))
+	require.NotNil(t, f.teams.added)
+	require.Equal(t, "backend", f.teams.added.Name)
+	require.Empty(t, f.teams.added.ReviewerStrategy)
+	require.Zero(t, f.teams.added.ReviewersCount)
+	require.Len(t, f.teams.added.Members, 3)
+
+	// The created team, with the service's defaults, is printed.
+	var resp dto.TeamResponse
+	require.NoError(t, json.Unmarshal(f.stdout.Bytes(), &resp))
+	require.Equal(t, "backend", resp.Team.Name)
+	require.Equal(t, "least_loaded", resp.Team.ReviewerStrategy)
+}
+
+func TestTeamAdd_RejectsBadMember(t *testing.T) {
+	f := newFixture()
+
+	err := f.run("team", "add", "-member", "u1", "backend")
+	require.Equal(t, 2, cli.ExitCode(err))
+	require.False(t, f.connected)
+}
+
+func TestTeamDeactivate_JSON(t *testing.T) {
+	f := newFixture()
+
+	require.NoError(t, f.run("team", "deactivate", "-o", "json", "backend", "u1", "u2", "u1"))
+	require.Equal(t, []string{"u1", "u2"}, f.teams.deactivated.UserIDs)
+	require.JSONEq(t, `{"team_name":"backend","deactivated_user_ids":["u1","u2"]}`, f.stdout.String())
+}
+
+func TestPRCreate_MapsFlags(t *testing.T) {
+	f := newFixture()
+
+	require.NoError(t, f.run("pr", "create",
+		"-name", "Add search", "-author", "u1", "-reviewers", "3",
+		"-file", "cmd/main.go", "-skill", "Go,postgres", "-require", "u2", "-exclude", "u3,u3",
+		"pr-1"))
+
+	req := f.prs.created
+	require.Equal(t, "pr-1", req.ID)
+	require.Equal(t, 3, *req.ReviewersCount)
+	require.Equal(t, []string{"cmd/main.go"}, []string(req.ChangedFiles))
+	require.Equal(t, []string{"Go", "postgres"}, req.RequiredSkills)
+	require.Equal(t, []string{"u2"}, req.RequiredReviewers)
+	require.Equal(t, []string{"u3", "u3"}, req.ExcludedReviewers)
+}
+
+func TestPRCreate_RequiresNameAndAuthor(t *testing.T) {
+	f := newFixture()
+
+	err := f.run("pr", "create", "pr-1")
+	require.Equal(t, 2, cli.ExitCode(err))
+	require.False(t, f.connected)
+}
+
+func TestPRShow_Table(t *testing.T) {
+	f := newFixture()
+
+	require.NoError(t, f.run("pr", "show", "pr-1"))
+	out := f.stdout.String()
+	require.Contains(t, out, "STATUS           OPEN\n")
+	require.Contains(t, out, "REVIEWERS        1 of 2 requested, understaffed\n")
+	require.Contains(t, out, "u2        backend  working_hours  -")
+}
+
+func TestPRReassign_JSON(t *testing.T) {
+	f := newFixture()
+
+	require.NoError(t, f.run("pr", "reassign", "-to", "u5", "-o", "json", "pr-1", "u2"))
+
+	var resp dto.ReassignResponse
+	require.NoError(t, json.Unmarshal(f.stdout.Bytes(), &resp))
+	require.Equal(t, "u5", resp.ReplacedBy)
+	require.Equal(t, []string{"u5"}, resp.PR.Reviewers)
+}
+
+func TestRun_UsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"nope"},
+		{"team"},
+		{"team", "rename", "backend"},
+		{"user", "set-active", "u1", "maybe"},
+		{"pr", "show", "-o", "yaml", "pr-1"},
+		{"pr", "merge"},
+	} {
+		f := newFixture()
+		err := f.run(args...)
+		require.Equal(t, 2, cli.ExitCode(err), args)
+		require.False(t, f.connected, args)
+	}
+}
+
+func TestRun_Help(t *testing.T) {
+	f := newFixture()
+
+	require.NoError(t, f.run("pr", "create", "-h"))
+	require.Contains(t, f.stderr.String(), "-author")
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+)
+
+func (c *CLI) user(ctx context.Context, args []string) error {
+	cmd, args, err := subcommand("user", []string{"set-active", "reviews"}, args)
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "set-active":
+		return c.userSetActive(args)
+	default:
+		return c.userReviews(args)
+	}
+}
+
+// userSetActive mirrors POST /users/setIsActive.
+func (c *CLI) userSetActive(args []string) error {
+	fs, output := c.flags("user set-active", "<user_id> true|false")
+	if err := parse(fs, output, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return badArgs(fs)
+	}
+	active, err := strconv.ParseBool(fs.Arg(1))
+	if err != nil {
+		return usageErrorf("is_active must be true or false, got %q", fs.Arg(1))
+	}
+
+	svc, err := c.Connect()
+	if err != nil {
+		return err
+	}
+	user, err := svc.Users.SetIsActive(dto.SIARequest{ID: strings.TrimSpace(fs.Arg(0)), IsActive: active})
+	if err != nil {
+		return fmt.Errorf("user set-active: %w", err)
+	}
+
+	return c.print(*output, dto.UserResponse{User: *user}, func(w io.Writer) {
+		writeUser(w, user)
+	})
+}
+
+// userReviews mirrors GET /users/getReview.
+func (c *CLI) userReviews(args []string) error {
+	fs, output := c.flags("user reviews", "<user_id>")
+	if err := parse(fs, output, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return badArgs(fs)
+	}
+
+	svc, err := c.Connect()
+	if err != nil {
+		return err
+	}
+	prs, err := svc.Users.GetReview(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("user reviews: %w", err)
+	}
+	if prs == nil {
+		prs = []dto.PRShort{}
+	}
+
+	return c.print(*output, dto.UserPR{ID: fs.Arg(0), PRs: prs}, func(w io.Writer) {
+		writePRShorts(w, prs)
+	})
+}
@@ -0,0 +1,205 @@
+// Package codeowners parses CODEOWNERS files and matches changed paths against
+// their rules. Patterns follow GitHub: the last matching rule wins, a pattern
+// without a slash matches at any depth, a leading slash anchors it to the
+// repository root, a trailing slash matches everything under a directory and
+// "dir/*" matches direct children only. GitLab section headers are skipped.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+type OwnerKind string
+
+const (
+	// OwnerUser is written as @user_id.
+	OwnerUser OwnerKind = "user"
+	// OwnerTeam is written as @org/team_name; the org is kept for display only.
+	OwnerTeam OwnerKind = "team"
+	// OwnerEmail is a user's email address.
+	OwnerEmail OwnerKind = "email"
+)
+
+type Owner struct {
+	Kind OwnerKind
+	Name string
+	Org  string
+}
+
+func (o Owner) String() string {
+	switch o.Kind {
+	case OwnerUser:
+		return "@" + o.Name
+	case OwnerTeam:
+		return "@" + o.Org + "/" + o.Name
+	default:
+		return o.Name
+	}
+}
+
+type Rule struct {
+	// Line is the 1-based line of the rule in the file.
+	Line    int
+	Pattern string
+	// Owners may be empty: such a rule takes the ownership of matching paths away.
+	Owners []Owner
+	re     *regexp.Regexp
+}
+
+type Rules []Rule
+
+// ParseError points at the line of the file that could not be parsed.
+type ParseError struct {
+	Line int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+func Parse(r io.Reader) (Rules, error) {
+	var rules Rules
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") || isSection(line) {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		rule := Rule{Line: n, Pattern: fields[0]}
+
+		re, err := compile(rule.Pattern)
+		if err != nil {
+			return nil, &ParseError{Line: n, Msg: err.Error()}
+		}
+		rule.re = re
+
+		for _, field := range fields[1:] {
+			owner, err := parseOwner(field)
+			if err != nil {
+				return nil, &ParseError{Line: n, Msg: err.Error()}
+			}
+			rule.Owners = append(rule.Owners, owner)
+		}
+
+		rules = append(rules, rule)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Match returns the last rule matching the path, or nil.
+func (rs Rules) Match(path string) *Rule {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(rs) - 1; i >= 0; i-- {
+		if rs[i].re.MatchString(path) {
+			return &rs[i]
+		}
+	}
+	return nil
+}
+
+// Owning returns the rules that own at least one of the paths, each once and in
+// the order of the first path they own. Rules without owners are left out.
+func (rs Rules) Owning(paths []string) []Rule {
+	seen := make(map[int]bool)
+
+	var out []Rule
+	for _, path := range paths {
+		rule := rs.Match(path)
+		if rule == nil || len(rule.Owners) == 0 || seen[rule.Line] {
+			continue
+		}
+		seen[rule.Line] = true
+		out = append(out, *rule)
+	}
+	return out
+}
+
+// isSection reports whether the line is a GitLab section header such as
+// "[Database]" or "^[Docs][2] @docs-team".
+func isSection(line string) bool {
+	return strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[")
+}
+
+func parseOwner(field string) (Owner, error) {
+	if name, ok := strings.CutPrefix(field, "@"); ok {
+		org, team, isTeam := strings.Cut(name, "/")
+		switch {
+		case !isTeam && name != "":
+			return Owner{Kind: OwnerUser, Name: name}, nil
+		case isTeam && org != "" && team != "" && !strings.Contains(team, "/"):
+			return Owner{Kind: OwnerTeam, Name: team, Org: org}, nil
+		}
+		return Owner{}, fmt.Errorf("invalid owner %q", field)
+	}
+
+	if local, domain, ok := strings.Cut(field, "@"); ok && local != "" && strings.Contains(domain, ".") {
+		return Owner{Kind: OwnerEmail, Name: strings.ToLower(field)}, nil
+	}
+
+	return Owner{}, fmt.Errorf("invalid owner %q: want @user, @org/team or an email", field)
+}
+
+// compile turns a pattern into a regexp over slash-separated paths relative to
+// the repository root.
+func compile(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, fmt.Errorf("negated pattern %q is not supported", pattern)
+	}
+
+	p := pattern
+	anchored := strings.HasPrefix(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern %q", pattern)
+	}
+	// A slash in the middle anchors the pattern just like a leading one.
+	anchored = anchored || strings.Contains(p, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+
+	// A pattern naming a directory owns everything below it, except for "dir/*",
+	// which only owns the files directly inside.
+	if !strings.HasSuffix(p, "/*") {
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,93 @@
+package codeowners_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/codeowners"
+)
+
+func parseFile(t *testing.T) codeowners.Rules {
+	t.Helper()
+
+	f, err := os.Open("testdata/CODEOWNERS")
+	require.NoError(t, err)
+	defer f.Close()
+
+	rules, err := codeowners.Parse(f)
+	require.NoError(t, err)
+	return rules
+}
+
+func TestParse_ReadsRulesAndOwners(t *testing.T) {
+	rules := parseFile(t)
+	require.Len(t, rules, 7)
+
+	require.Equal(t, "/migrations/", rules[3].Pattern)
+	require.Equal(t, 9, rules[3].Line)
+	require.Equal(t, []codeowners.Owner{
+		{Kind: codeowners.OwnerUser, Name: "dbadmin"},
+		{Kind: codeowners.OwnerEmail, Name: "dba@example.com"},
+	}, rules[3].Owners)
+	require.Equal(t, []codeowners.Owner{{Kind: codeowners.OwnerTeam, Name: "backend", Org: "acme"}}, rules[0].Owners)
+	require.Equal(t, "@acme/backend", rules[0].Owners[0].String())
+	require.Empty(t, rules[6].Owners)
+}
+
+func TestRules_MatchLastRuleWins(t *testing.T) {
+	rules := parseFile(t)
+
+	cases := map[string]string{
+		"cmd/main.go":                                "*",
+		"web/src/App.tsx":                            "/web/",
+		"components/Button.tsx":                      "*.tsx",
+		"migrations/init.sql":                        "/migrations/",
+		"internal/infra/repository/pr_repo.go":       "**/repository/*.go",
+		"repository/user_repo.go":                    "**/repository/*.go",
+		"internal/infra/repository/tests/pr_test.go": "*",
+		"docs/openapi.yml":                           "docs/*",
+		"docs/api/openapi.yml":                       "*",
+		"vendor/github.com/lib/pq/conn.go":           "/vendor/",
+		"/migrations/002.sql":                        "/migrations/",
+	}
+	for path, pattern := range cases {
+		rule := rules.Match(path)
+		require.NotNil(t, rule, path)
+		require.Equal(t, pattern, rule.Pattern, path)
+	}
+}
+
+func TestRules_OwningSkipsUnownedAndDuplicates(t *testing.T) {
+	rules := parseFile(t)
+
+	owning := rules.Owning([]string{
+		"migrations/001.sql",
+		"vendor/x.go",
+		"migrations/002.sql",
+		"cmd/main.go",
+	})
+
+	var patterns []string
+	for _, r := range owning {
+		patterns = append(patterns, r.Pattern)
+	}
+	require.Equal(t, []string{"/migrations/", "*"}, patterns)
+}
+
+func TestParse_RejectsInvalidLines(t *testing.T) {
+	for _, content := range []string{
+		"*.go @",
+		"*.go @acme/",
+		"*.go not-an-owner",
+		"!*.go @alice",
+	} {
+		_, err := codeowners.Parse(strings.NewReader("# header\n" + content + "\n"))
+
+		var perr *codeowners.ParseError
+		require.ErrorAs(t, err, &perr, content)
+		require.Equal(t, 2, perr.Line, content)
+	}
+}
@@ -9,6 +9,17 @@ import (
 type Config struct {
 	HTTPAddr string   `json:"http_addr"`
 	DB       DBConfig `json:"db"`
+	// AutoMigrate applies pending schema migrations when the service starts.
+	AutoMigrate bool `json:"auto_migrate"`
+
+	Selection SelectionConfig `json:"selection"`
+	Webhooks  WebhooksConfig  `json:"webhooks"`
+	Outbox    OutboxConfig    `json:"outbox"`
+	Chat      ChatConfig      `json:"chat"`
+	Email     EmailConfig     `json:"email"`
+
+	Availability AvailabilityConfig `json:"availability"`
+	SLA          SLAConfig          `json:"sla"`
 }
 
 type DBConfig struct {
@@ -20,6 +31,87 @@ type DBConfig struct {
 	SSLMode  string `json:"sslmode"`
 }
 
+type SelectionConfig struct {
+	RandomSeed int64 `json:"random_seed"`
+}
+
+// WebhooksConfig holds the secrets incoming VCS webhooks are verified with.
+// A provider with an empty secret or token rejects every delivery.
+type WebhooksConfig struct {
+	GitHub GitHubWebhookConfig `json:"github"`
+	GitLab GitLabWebhookConfig `json:"gitlab"`
+
+	// Delivery configures outgoing webhooks to team subscriptions.
+	Delivery WebhookDeliveryConfig `json:"delivery"`
+}
+
+type GitHubWebhookConfig struct {
+	Secret string `json:"secret"`
+}
+
+type GitLabWebhookConfig struct {
+	Token string `json:"token"`
+}
+
+// WebhookDeliveryConfig tunes outgoing webhook retries. A failed delivery is
+// retried after base_backoff_ms, doubling up to max_backoff_ms, and is
+// dead-lettered after max_attempts. Zero values fall back to the defaults
+// in services.
+type WebhookDeliveryConfig struct {
+	MaxAttempts    int `json:"max_attempts"`
+	BaseBackoffMs  int `json:"base_backoff_ms"`
+	MaxBackoffMs   int `json:"max_backoff_ms"`
+	TimeoutMs      int `json:"timeout_ms"`
+	PollIntervalMs int `json:"poll_interval_ms"`
+	BatchSize      int `json:"batch_size"`
+}
+
+// OutboxConfig tunes the domain event dispatcher. An event still failing
+// after max_attempts is dead-lettered. Zero values fall back to one second,
+// 100 events and 20 attempts.
+type OutboxConfig struct {
+	PollIntervalMs int `json:"poll_interval_ms"`
+	BatchSize      int `json:"batch_size"`
+	MaxAttempts    int `json:"max_attempts"`
+}
+
+// ChatConfig tunes chat notifications. DigestTime is the UTC time of day
+// ("09:00") the daily open-review digest is posted; empty disables it.
+type ChatConfig struct {
+	TimeoutMs  int    `json:"timeout_ms"`
+	DigestTime string `json:"digest_time"`
+}
+
+// EmailConfig configures the SMTP relay notification emails go through; an
+// empty Host disables email. Templates found in TemplatesDir override the
+// built-in ones file by file.
+type EmailConfig struct {
+	Host         string `json:"host"`
+	Port         int    `json:"port"`
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+	From         string `json:"from"`
+	TemplatesDir string `json:"templates_dir"`
+	TimeoutMs    int    `json:"timeout_ms"`
+}
+
+// AvailabilityConfig tunes how often started unavailability windows are
+// checked for reviews to hand over. Zero values fall back to one minute
+// and 50 windows. ICSDir is the only directory calendar files are imported
+// from by path; empty disables path imports.
+type AvailabilityConfig struct {
+	PollIntervalMs int    `json:"poll_interval_ms"`
+	BatchSize      int    `json:"batch_size"`
+	ICSDir         string `json:"ics_dir"`
+}
+
+// SLAConfig tunes how often open reviews are checked against the review SLA
+// of their team. Zero values fall back to five minutes and 100 reviews.
+type SLAConfig struct {
+	PollIntervalMs int `json:"poll_interval_ms"`
+	BatchSize      int `json:"batch_size"`
+}
+
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -1,11 +1,19 @@
 package di
 
 import (
+	"context"
 	"log"
 	"pr-reviwer-assigner/internal/config"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/selector"
 	"pr-reviwer-assigner/internal/domain/services"
 	"pr-reviwer-assigner/internal/infrastructure/database"
 	repo2 "pr-reviwer-assigner/internal/infrastructure/database/repository"
+	"pr-reviwer-assigner/internal/mail"
+	"pr-reviwer-assigner/internal/migrate"
+	"pr-reviwer-assigner/internal/vcs"
+	"pr-reviwer-assigner/migrations"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -14,6 +22,19 @@ type Container struct {
 	prService   services.PRService
 	teamService services.TeamService
 	userService services.UserService
+	vcsApplier  vcs.Applier
+	dispatcher  *services.EventDispatcher
+	deliverer   *services.WebhookDeliverer
+	chatDigest  *services.ChatDigest
+	watcher     *services.AvailabilityWatcher
+	slaWatcher  *services.SLAWatcher
+
+	webhookService      services.WebhookService
+	availabilityService services.AvailabilityService
+	codeownersService   services.CodeownersService
+	statsService        services.StatsService
+
+	webhooks config.WebhooksConfig
 
 	logger *zap.Logger
 }
@@ -26,19 +47,115 @@ func NewContainer(cfg *config.Config) *Container {
 
 	zapLogger, _ := zap.NewProduction()
 
-	prrepo := repo2.NewPRRepository(db)
+	if cfg.AutoMigrate {
+		migrator, err := migrate.New(db, migrations.FS)
+		if err != nil {
+			log.Fatalf("load migrations: %v", err)
+		}
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		for _, m := range applied {
+			zapLogger.Named("migrate").Sugar().Infow("migration applied", "migration", m.String())
+		}
+	}
+
+	selectors := selector.NewRegistry(cfg.Selection.RandomSeed)
+
+	prrepo := repo2.NewPRRepository(db, selectors)
 	teamrepo := repo2.NewTeamRepository(db)
 	userrepo := repo2.NewUserRepository(db)
+	outboxrepo := repo2.NewOutboxRepository(db)
+	webhookrepo := repo2.NewWebhookRepository(db)
+	notificationrepo := repo2.NewNotificationRepository(db)
+	availabilityrepo := repo2.NewAvailabilityRepository(db)
+	codeownersrepo := repo2.NewCodeownersRepository(db)
+	statsrepo := repo2.NewStatsRepository(db)
+	slarepo := repo2.NewReviewSLARepository(db)
 
 	prservice := services.NewPRService(prrepo)
 	teamservice := services.NewTeamService(teamrepo, prrepo)
 	userservice := services.NewUserService(userrepo)
 
+	dispatcher := services.NewEventDispatcher(
+		outboxrepo,
+		time.Duration(cfg.Outbox.PollIntervalMs)*time.Millisecond,
+		cfg.Outbox.BatchSize,
+		cfg.Outbox.MaxAttempts,
+		zapLogger.Named("eventDispatcher").Sugar(),
+	)
+	dispatcher.Register(events.NewLogSink(zapLogger.Named("events").Sugar()))
+	dispatcher.Register(services.NewWebhookSink(webhookrepo))
+
+	chatPoster := services.NewChatPoster(time.Duration(cfg.Chat.TimeoutMs) * time.Millisecond)
+	dispatcher.Register(services.NewChatSink(notificationrepo, chatPoster, zapLogger.Named("chat").Sugar()))
+
+	if cfg.Email.Host != "" {
+		templates, err := mail.LoadTemplates(cfg.Email.TemplatesDir)
+		if err != nil {
+			log.Fatalf("load email templates: %v", err)
+		}
+		sender := mail.NewSMTPSender(mail.SMTPOptions{
+			Host:     cfg.Email.Host,
+			Port:     cfg.Email.Port,
+			Username: cfg.Email.Username,
+			Password: cfg.Email.Password,
+			From:     cfg.Email.From,
+			Timeout:  time.Duration(cfg.Email.TimeoutMs) * time.Millisecond,
+		})
+		dispatcher.Register(services.NewEmailSink(notificationrepo, sender, templates, zapLogger.Named("email").Sugar()))
+	}
+
+	var chatDigest *services.ChatDigest
+	if cfg.Chat.DigestTime != "" {
+		at, err := time.Parse("15:04", cfg.Chat.DigestTime)
+		if err != nil {
+			log.Fatalf("invalid chat.digest_time %q: %v", cfg.Chat.DigestTime, err)
+		}
+		offset := time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute
+		chatDigest = services.NewChatDigest(notificationrepo, prrepo, chatPoster, offset, zapLogger.Named("chatDigest").Sugar())
+	}
+
+	delivery := cfg.Webhooks.Delivery
+	deliverer := services.NewWebhookDeliverer(webhookrepo, services.WebhookDeliveryOptions{
+		MaxAttempts:  delivery.MaxAttempts,
+		BaseBackoff:  time.Duration(delivery.BaseBackoffMs) * time.Millisecond,
+		MaxBackoff:   time.Duration(delivery.MaxBackoffMs) * time.Millisecond,
+		Timeout:      time.Duration(delivery.TimeoutMs) * time.Millisecond,
+		PollInterval: time.Duration(delivery.PollIntervalMs) * time.Millisecond,
+		BatchSize:    delivery.BatchSize,
+	}, zapLogger.Named("webhookDeliverer").Sugar())
+
 	return &Container{
 		prService:   prservice,
 		teamService: teamservice,
 		userService: userservice,
-		logger:      zapLogger,
+		vcsApplier:  vcs.NewApplier(prservice, userservice),
+		dispatcher:  dispatcher,
+		deliverer:   deliverer,
+		chatDigest:  chatDigest,
+		watcher: services.NewAvailabilityWatcher(
+			availabilityrepo,
+			prrepo,
+			time.Duration(cfg.Availability.PollIntervalMs)*time.Millisecond,
+			cfg.Availability.BatchSize,
+			zapLogger.Named("availabilityWatcher").Sugar(),
+		),
+		slaWatcher: services.NewSLAWatcher(
+			slarepo,
+			prrepo,
+			time.Duration(cfg.SLA.PollIntervalMs)*time.Millisecond,
+			cfg.SLA.BatchSize,
+			zapLogger.Named("slaWatcher").Sugar(),
+		),
+
+		webhookService:      services.NewWebhookService(webhookrepo),
+		availabilityService: services.NewAvailabilityService(availabilityrepo, cfg.Availability.ICSDir),
+		codeownersService:   services.NewCodeownersService(codeownersrepo),
+		statsService:        services.NewStatsService(statsrepo),
+		webhooks:            cfg.Webhooks,
+		logger:              zapLogger,
 	}
 }
 
@@ -54,6 +171,51 @@ func (c *Container) GetUserService() services.UserService {
 	return c.userService
 }
 
+func (c *Container) GetVCSApplier() vcs.Applier {
+	return c.vcsApplier
+}
+
+func (c *Container) GetEventDispatcher() *services.EventDispatcher {
+	return c.dispatcher
+}
+
+func (c *Container) GetWebhookDeliverer() *services.WebhookDeliverer {
+	return c.deliverer
+}
+
+// GetChatDigest returns nil when the digest is disabled.
+func (c *Container) GetChatDigest() *services.ChatDigest {
+	return c.chatDigest
+}
+
+func (c *Container) GetAvailabilityWatcher() *services.AvailabilityWatcher {
+	return c.watcher
+}
+
+func (c *Container) GetSLAWatcher() *services.SLAWatcher {
+	return c.slaWatcher
+}
+
+func (c *Container) GetAvailabilityService() services.AvailabilityService {
+	return c.availabilityService
+}
+
+func (c *Container) GetCodeownersService() services.CodeownersService {
+	return c.codeownersService
+}
+
+func (c *Container) GetStatsService() services.StatsService {
+	return c.statsService
+}
+
+func (c *Container) GetWebhookService() services.WebhookService {
+	return c.webhookService
+}
+
+func (c *Container) GetWebhooksConfig() config.WebhooksConfig {
+	return c.webhooks
+}
+
 func (c *Container) GetNamedLogger(name string) *zap.SugaredLogger {
 	return c.logger.Named(name).Sugar()
 }
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// Unavailability is a period during which a user gets no new reviews. Once it
+// starts, the user's open reviews are handed over to teammates.
+type Unavailability struct {
+	ID       int64     `json:"id"`
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason,omitempty"`
+	// ExternalID is the UID of the calendar event the window was imported from.
+	ExternalID string    `json:"external_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	// ReassignAttempts counts the hand-overs of the window's reviews that
+	// failed, and ReassignError tells why the last one did.
+	ReassignAttempts int    `json:"reassign_attempts,omitempty"`
+	ReassignError    string `json:"reassign_error,omitempty"`
+}
+
+type UnavailabilityResponse struct {
+	Window Unavailability `json:"window"`
+}
+
+type UnavailabilitiesResponse struct {
+	UserID  string           `json:"user_id"`
+	Windows []Unavailability `json:"windows"`
+}
+
+type UnavailabilityDeleteRequest struct {
+	ID int64 `json:"id"`
+}
+
+// ICSImportRequest points at a calendar file under the configured import
+// directory. Without UserID, events are matched to users by the organizer
+// and attendee emails.
+type ICSImportRequest struct {
+	UserID string `json:"user_id"`
+	Path   string `json:"path"`
+}
+
+type ICSSkippedEvent struct {
+	UID    string `json:"uid"`
+	Reason string `json:"reason"`
+}
+
+type ICSImportResponse struct {
+	Imported int               `json:"imported"`
+	Removed  int               `json:"removed"`
+	Skipped  []ICSSkippedEvent `json:"skipped"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type CodeownersRule struct {
+	Line    int    `json:"line"`
+	Pattern string `json:"pattern"`
+	// Owners are written as in the file: @user_id, @org/team_name or an email.
+	Owners []string `json:"owners"`
+}
+
+type CodeownersResponse struct {
+	TeamName string           `json:"team_name"`
+	Rules    []CodeownersRule `json:"rules"`
+}
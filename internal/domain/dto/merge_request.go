@@ -3,3 +3,8 @@ package dto
 type MergeRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 }
+
+// PRStateRequest is the body of the close, reopen and markReady endpoints.
+type PRStateRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
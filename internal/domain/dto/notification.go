@@ -0,0 +1,28 @@
+package dto
+
+// Recipient is a user a notification is addressed to.
+type Recipient struct {
+	UserID     string
+	Username   string
+	ChatHandle string
+	Email      string
+}
+
+// AssignmentNotice is what notifiers need to tell a reviewer about a PR.
+// Team fields belong to the PR author's team.
+type AssignmentNotice struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	AuthorName      string
+	TeamName        string
+	ChatWebhookURL  string
+	Reviewer        Recipient
+}
+
+// ChatTeam is a team with a chat webhook and its active members.
+type ChatTeam struct {
+	TeamName       string
+	ChatWebhookURL string
+	Members        []Recipient
+}
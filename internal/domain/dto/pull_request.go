@@ -1,19 +1,85 @@
 package dto
 
+import "time"
+
+// Pull request statuses, as stored in the pull_request_status enum.
+const (
+	PRStatusOpen   = "OPEN"
+	PRStatusMerged = "MERGED"
+	PRStatusClosed = "CLOSED"
+	PRStatusDraft  = "DRAFT"
+)
+
+// Policies of reviewers that were not picked by the selector.
+const (
+	// PolicyRequired is the policy of reviewers assigned because the PR
+	// requires them, see PRRequest.RequiredReviewers.
+	PolicyRequired = "required"
+	// PolicyManual is the policy of reviewers named by the caller of
+	// addReviewer or reassign.
+	PolicyManual = "manual"
+)
+
 type PRRequest struct {
 	ID       string `json:"pull_request_id"`
 	Name     string `json:"pull_request_name"`
 	AuthorID string `json:"author_id"`
+	// ReviewersCount overrides the team's default number of reviewers.
+	ReviewersCount *int `json:"reviewers_count,omitempty"`
+	// Draft creates the PR in DRAFT status, without reviewers.
+	Draft bool `json:"draft,omitempty"`
+	// ChangedFiles are repository-relative paths; owners of the matching
+	// CODEOWNERS rules of the author's team are assigned first.
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	// RequiredSkills are skill tags; reviewers covering more of them are preferred.
+	RequiredSkills []string `json:"required_skills,omitempty"`
+	// RequiredReviewers are always assigned while they are active. They take
+	// the first seats and are all assigned even when there are fewer seats.
+	RequiredReviewers []string `json:"required_reviewers,omitempty"`
+	// ExcludedReviewers are never picked for the PR, neither on creation nor on
+	// a later reassign.
+	ExcludedReviewers []string `json:"excluded_reviewers,omitempty"`
 }
 
 type PR struct {
-	ID        string   `json:"pull_request_id"`
-	Name      string   `json:"pull_request_name"`
-	AuthorID  string   `json:"author_id"`
-	Status    string   `json:"status"`
-	Reviewers []string `json:"assigned_reviewers"`
-	CreatedAt string   `json:"createdAt,omitempty"`
-	MergedAt  string   `json:"mergedAt,omitempty"`
+	ID                 string   `json:"pull_request_id"`
+	Name               string   `json:"pull_request_name"`
+	AuthorID           string   `json:"author_id"`
+	Status             string   `json:"status"`
+	Reviewers          []string `json:"assigned_reviewers"`
+	RequestedReviewers int      `json:"requested_reviewers"`
+	// Assignments describes each reviewer in Reviewers, in the same order.
+	Assignments []ReviewerAssignment `json:"reviewer_assignments,omitempty"`
+	// Understaffed is set when fewer reviewers were found than requested.
+	Understaffed bool       `json:"understaffed"`
+	CreatedAt    time.Time  `json:"createdAt,omitzero"`
+	MergedAt     *time.Time `json:"mergedAt,omitempty"`
+	ClosedAt     *time.Time `json:"closedAt,omitempty"`
+	// UpdatedAt is the time of the last status change.
+	UpdatedAt time.Time `json:"updatedAt,omitzero"`
+}
+
+type ReviewerAssignment struct {
+	UserID string `json:"user_id"`
+	// TeamName is the team the reviewer was picked from; it differs from the
+	// author's team when the reviewer came from a fallback team.
+	TeamName string `json:"team_name"`
+	// Verdict is the reviewer's latest verdict, empty until they submit one.
+	Verdict   string     `json:"verdict,omitempty"`
+	VerdictAt *time.Time `json:"verdict_at,omitempty"`
+	// Policy tells why the reviewer was picked: working_hours, lead_time or
+	// off_hours, see the selector package, or required or manual.
+	Policy string `json:"policy,omitempty"`
+	// OwnerPattern is the CODEOWNERS pattern the reviewer was picked as an owner of.
+	OwnerPattern string `json:"owner_pattern,omitempty"`
+	// MatchScore is the share of the PR's required skills the reviewer had when
+	// picked, from 0 to 1; nil when the PR requires none.
+	MatchScore *float64 `json:"match_score,omitempty"`
+	// AssignedAt starts the review SLA clock; reopening the PR or marking it
+	// ready restarts it for reviewers without a verdict.
+	AssignedAt time.Time `json:"assigned_at,omitzero"`
+	// SLABreachedAt is set once the reviewer missed the team's review SLA.
+	SLABreachedAt *time.Time `json:"sla_breached_at,omitempty"`
 }
 
 type PRShort struct {
@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+// Sort keys of /pullRequest/list.
+const (
+	PRSortCreatedAt = "created_at"
+	PRSortUpdatedAt = "updated_at"
+)
+
+// PRListFilter selects a page of pull requests. Empty fields don't filter.
+type PRListFilter struct {
+	Statuses   []string
+	AuthorID   string
+	ReviewerID string
+	// TeamName is the author's team.
+	TeamName string
+	// CreatedFrom is inclusive, CreatedTo exclusive.
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// SortBy is PRSortCreatedAt or PRSortUpdatedAt; ties are broken by
+	// pull_request_id in the same direction.
+	SortBy string
+	Desc   bool
+	// After is the last pull request of the previous page, nil for the first.
+	After *PRListCursor
+	Limit int
+}
+
+// PRListCursor is the keyset position of a pull request in a sort order.
+type PRListCursor struct {
+	SortBy string    `json:"s"`
+	Desc   bool      `json:"d"`
+	Time   time.Time `json:"t"`
+	ID     string    `json:"id"`
+}
+
+// PRListRequest is a PRListFilter with the cursor as the client sent it.
+type PRListRequest struct {
+	PRListFilter
+	Cursor string
+}
+
+type PRListResponse struct {
+	PullRequests []PR `json:"pull_requests"`
+	// NextCursor fetches the next page with the same filters and sort; it is
+	// empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -3,6 +3,14 @@ package dto
 type ReassignRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_user_id"`
+	// NewUserID names the replacement; when empty it is picked automatically.
+	NewUserID string `json:"new_user_id,omitempty"`
+}
+
+// ReviewerRequest names a reviewer to add to or remove from a pull request.
+type ReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
 }
 
 type ReassignResponse struct {
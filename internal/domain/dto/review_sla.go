@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+// PendingReview is a reviewer of an OPEN pull request who has not submitted a
+// verdict yet and whose SLA may have run out.
+type PendingReview struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	ReviewerID      string
+	// TeamName is the author's team, whose SLA applies.
+	TeamName   string
+	AssignedAt time.Time
+	// SLAHours and AutoReassign are the team's review_sla_hours and
+	// sla_auto_reassign settings.
+	SLAHours     int
+	AutoReassign bool
+	// Required is set for an active required reviewer, who is escalated but
+	// never handed over.
+	Required bool
+	// WorkingHours are the reviewer's; the SLA only runs inside them. Nil
+	// when the reviewer has none, and then the SLA runs around the clock.
+	WorkingHours *WorkingHours
+}
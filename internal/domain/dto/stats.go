@@ -0,0 +1,11 @@
+package dto
+
+type PairingStats struct {
+	TeamName string `json:"team_name"`
+	// PairingWindow is the team's pairing_window setting.
+	PairingWindow int `json:"pairing_window"`
+	// Pairings maps every member of the team to the reviewers of their pull
+	// requests and how many of them each reviewer was assigned to. Members
+	// without reviewed pull requests map to an empty object.
+	Pairings map[string]map[string]int `json:"pairings"`
+}
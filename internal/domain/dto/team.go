@@ -4,11 +4,19 @@ type TeamMember struct {
 	ID       string `json:"user_id"`
 	Name     string `json:"username"`
 	IsActive bool   `json:"is_active"`
+	// Skills replace the member's skill tags on /team/add when set.
+	Skills []string `json:"skills,omitempty"`
 }
 
 type Team struct {
-	Name    string       `json:"team_name"`
-	Members []TeamMember `json:"members"`
+	Name             string       `json:"team_name"`
+	ReviewerStrategy string       `json:"reviewer_strategy,omitempty"`
+	ReviewersCount   int          `json:"reviewers_count,omitempty"`
+	MinReviewers     int          `json:"min_reviewers,omitempty"`
+	MaxReviewers     int          `json:"max_reviewers,omitempty"`
+	FallbackTeams    []string     `json:"fallback_teams,omitempty"`
+	MinApprovals     int          `json:"min_approvals,omitempty"`
+	Members          []TeamMember `json:"members"`
 }
 
 type TeamResponse struct {
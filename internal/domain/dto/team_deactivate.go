@@ -8,4 +8,14 @@ type TeamDeactivateRequest struct {
 type TeamDeactivateResponse struct {
 	TeamName    string   `json:"team_name"`
 	Deactivated []string `json:"deactivated_user_ids"`
+	// NotReassigned lists the open reviews that are still assigned to a
+	// deactivated user because no replacement could take them.
+	NotReassigned []ReassignFailure `json:"not_reassigned,omitempty"`
+}
+
+// ReassignFailure is an open review that could not be handed over.
+type ReassignFailure struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	Reason        string `json:"reason"`
 }
@@ -0,0 +1,49 @@
+package dto
+
+type TeamSettings struct {
+	TeamName         string `json:"team_name"`
+	ReviewerStrategy string `json:"reviewer_strategy"`
+	ReviewersCount   int    `json:"reviewers_count"`
+	MinReviewers     int    `json:"min_reviewers"`
+	MaxReviewers     int    `json:"max_reviewers"`
+	// FallbackTeams are asked for reviewers, in order, when the team runs out.
+	FallbackTeams []string `json:"fallback_teams"`
+	// MinApprovals is how many APPROVED verdicts a PR needs to be merged; 0 disables the check.
+	MinApprovals int `json:"min_approvals"`
+	// ChatWebhookURL is a Slack-compatible incoming webhook that receives
+	// assignment notifications and the daily digest; empty disables them.
+	ChatWebhookURL string `json:"chat_webhook_url"`
+	// WorkingHoursLeadMinutes: reviewers whose working hours start within this
+	// many minutes are preferred over reviewers that are off hours.
+	WorkingHoursLeadMinutes int `json:"working_hours_lead_minutes"`
+	// PairingWindow is how many of the author's latest pull requests the
+	// pairing_aware strategy counts past pairings over.
+	PairingWindow int `json:"pairing_window"`
+	// ReviewSLAHours is how many of their working hours a reviewer has for the
+	// first verdict; 0 disables the SLA.
+	ReviewSLAHours int `json:"review_sla_hours"`
+	// SLAAutoReassign hands a review over to someone else once its SLA is breached.
+	SLAAutoReassign bool `json:"sla_auto_reassign"`
+}
+
+// TeamSettingsRequest updates only the fields that are set.
+type TeamSettingsRequest struct {
+	TeamName         string  `json:"team_name"`
+	ReviewerStrategy *string `json:"reviewer_strategy,omitempty"`
+	ReviewersCount   *int    `json:"reviewers_count,omitempty"`
+	MinReviewers     *int    `json:"min_reviewers,omitempty"`
+	MaxReviewers     *int    `json:"max_reviewers,omitempty"`
+	// FallbackTeams replaces the whole list when set; an empty list clears it.
+	FallbackTeams *[]string `json:"fallback_teams,omitempty"`
+	MinApprovals  *int      `json:"min_approvals,omitempty"`
+	// ChatWebhookURL is cleared by an empty string.
+	ChatWebhookURL          *string `json:"chat_webhook_url,omitempty"`
+	WorkingHoursLeadMinutes *int    `json:"working_hours_lead_minutes,omitempty"`
+	PairingWindow           *int    `json:"pairing_window,omitempty"`
+	ReviewSLAHours          *int    `json:"review_sla_hours,omitempty"`
+	SLAAutoReassign         *bool   `json:"sla_auto_reassign,omitempty"`
+}
+
+type TeamSettingsResponse struct {
+	Settings TeamSettings `json:"settings"`
+}
@@ -5,6 +5,29 @@ type User struct {
 	Name     string `json:"username"`
 	Team     string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	// ChatHandle is the chat member ID reviewers are mentioned by, e.g. U024BE7LH.
+	ChatHandle string `json:"chat_handle,omitempty"`
+	Email      string `json:"email,omitempty"`
+	// WorkingHours decide whether the user is preferred as a reviewer right now.
+	WorkingHours *WorkingHours `json:"working_hours,omitempty"`
+}
+
+// WorkingHours is a weekly working-hours window in the user's time zone.
+type WorkingHours struct {
+	// TimeZone is an IANA name, e.g. Asia/Singapore.
+	TimeZone string `json:"time_zone,omitempty"`
+	// Start and End are local "15:04" times; an End that is not after Start
+	// means the working hours run past midnight.
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+	// Days are ISO weekdays the working hours start on, 1 = Monday.
+	Days []int `json:"days,omitempty"`
+}
+
+// UserSkills is both the request and the response of /users/skills.
+type UserSkills struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
 }
 
 type UserPR struct {
@@ -20,3 +43,12 @@ type SIARequest struct {
 	ID       string `json:"user_id"`
 	IsActive bool   `json:"is_active"`
 }
+
+// ProfileRequest updates only the fields that are set.
+type ProfileRequest struct {
+	ID         string  `json:"user_id"`
+	ChatHandle *string `json:"chat_handle,omitempty"`
+	Email      *string `json:"email,omitempty"`
+	// WorkingHours updates only its non-empty fields.
+	WorkingHours *WorkingHours `json:"working_hours,omitempty"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// VCSIdentity links a login on an external VCS provider (github, gitlab) to a user.
+type VCSIdentity struct {
+	Provider string `json:"provider"`
+	Login    string `json:"login"`
+	UserID   string `json:"user_id"`
+}
+
+type VCSIdentityResponse struct {
+	Identity VCSIdentity `json:"identity"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+// WebhookResponse is returned to the VCS provider for every accepted delivery.
+type WebhookResponse struct {
+	Status        string `json:"status"`
+	Action        string `json:"action,omitempty"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	PR            *PR    `json:"pr,omitempty"`
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"pr-reviwer-assigner/internal/domain/events"
+	"time"
+)
+
+// Webhook delivery statuses. DEAD deliveries ran out of attempts.
+const (
+	DeliveryPending   = "PENDING"
+	DeliveryDelivered = "DELIVERED"
+	DeliveryDead      = "DEAD"
+)
+
+// WebhookSubscription is an outgoing webhook registered by a team. An empty
+// EventTypes list subscribes to every event. Secret is only returned on creation.
+type WebhookSubscription struct {
+	ID         int64     `json:"id"`
+	TeamName   string    `json:"team_name"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"secret,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WebhookSubscriptionResponse struct {
+	Subscription WebhookSubscription `json:"subscription"`
+}
+
+type WebhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscription `json:"subscriptions"`
+}
+
+// WebhookIDRequest is the body of the subscription delete and delivery retry endpoints.
+type WebhookIDRequest struct {
+	ID int64 `json:"id"`
+}
+
+type WebhookDelivery struct {
+	ID             int64      `json:"id"`
+	SubscriptionID int64      `json:"subscription_id"`
+	EventID        int64      `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// WebhookJob is a claimed delivery with everything needed to send it.
+type WebhookJob struct {
+	DeliveryID int64
+	Attempts   int
+	URL        string
+	Secret     string
+	Event      events.Event
+}
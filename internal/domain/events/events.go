@@ -0,0 +1,115 @@
+// Package events defines the domain events written to the outbox and the
+// sinks the dispatcher delivers them to.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Event types.
+const (
+	PRCreated        = "pr.created"
+	PRMerged         = "pr.merged"
+	ReviewerAssigned = "reviewer.assigned"
+	ReviewerReplaced = "reviewer.replaced"
+	ReviewerRemoved  = "reviewer.removed"
+	UserDeactivated  = "user.deactivated"
+	// ReviewSLABreached escalates a reviewer that gave no verdict within the
+	// SLA of the author's team.
+	ReviewSLABreached = "review.sla_breached"
+)
+
+// Types lists every event type, in the order they are documented.
+var Types = []string{PRCreated, PRMerged, ReviewerAssigned, ReviewerReplaced, ReviewerRemoved, ReviewSLABreached, UserDeactivated}
+
+func IsKnown(eventType string) bool {
+	for _, t := range Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is one outbox row. AggregateID is the pull_request_id, or the user_id
+// for user events.
+type Event struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"created_at"`
+	// DeliveredTo names the sinks that accepted the event on an earlier
+	// attempt, and Attempts counts the attempts that failed.
+	DeliveredTo []string `json:"-"`
+	Attempts    int      `json:"-"`
+}
+
+// Decode unmarshals the payload into one of the payload types below.
+func (e Event) Decode(v any) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+type PRCreatedPayload struct {
+	PullRequestID string   `json:"pull_request_id"`
+	Name          string   `json:"pull_request_name"`
+	AuthorID      string   `json:"author_id"`
+	Status        string   `json:"status"`
+	Reviewers     []string `json:"assigned_reviewers"`
+}
+
+type PRMergedPayload struct {
+	PullRequestID string   `json:"pull_request_id"`
+	Name          string   `json:"pull_request_name"`
+	AuthorID      string   `json:"author_id"`
+	Reviewers     []string `json:"assigned_reviewers"`
+}
+
+type ReviewerAssignedPayload struct {
+	PullRequestID string `json:"pull_request_id"`
+	Name          string `json:"pull_request_name"`
+	AuthorID      string `json:"author_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	TeamName      string `json:"team_name"`
+}
+
+type ReviewerReplacedPayload struct {
+	PullRequestID string `json:"pull_request_id"`
+	Name          string `json:"pull_request_name"`
+	AuthorID      string `json:"author_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+type ReviewerRemovedPayload struct {
+	PullRequestID string `json:"pull_request_id"`
+	Name          string `json:"pull_request_name"`
+	AuthorID      string `json:"author_id"`
+	ReviewerID    string `json:"reviewer_id"`
+}
+
+type ReviewSLABreachedPayload struct {
+	PullRequestID string    `json:"pull_request_id"`
+	Name          string    `json:"pull_request_name"`
+	AuthorID      string    `json:"author_id"`
+	ReviewerID    string    `json:"reviewer_id"`
+	TeamName      string    `json:"team_name"`
+	AssignedAt    time.Time `json:"assigned_at"`
+	SLAHours      int       `json:"sla_hours"`
+}
+
+type UserDeactivatedPayload struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+}
+
+// Sink receives dispatched events. Delivery is at-least-once: an event is
+// retried on a sink until it accepts it, and may be repeated if recording the
+// success fails, so sinks should tolerate duplicates (Event.ID is stable).
+// Sink names must be unique; they key the per-sink delivery record.
+type Sink interface {
+	Name() string
+	Deliver(ctx context.Context, ev Event) error
+}
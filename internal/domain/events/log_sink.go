@@ -0,0 +1,32 @@
+package events
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type logSink struct {
+	logger *zap.SugaredLogger
+}
+
+// NewLogSink returns a sink that writes every event to the log.
+func NewLogSink(logger *zap.SugaredLogger) Sink {
+	return &logSink{
+		logger: logger,
+	}
+}
+
+func (s *logSink) Name() string {
+	return "log"
+}
+
+func (s *logSink) Deliver(ctx context.Context, ev Event) error {
+	s.logger.Infow("domain event",
+		"id", ev.ID,
+		"type", ev.Type,
+		"aggregate_id", ev.AggregateID,
+		"payload", string(ev.Payload),
+	)
+	return nil
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"time"
+)
+
+type AvailabilityRepository interface {
+	Add(ctx context.Context, window dto.Unavailability) (*dto.Unavailability, error)
+	List(ctx context.Context, userID string) ([]dto.Unavailability, error)
+	Update(ctx context.Context, window dto.Unavailability) (*dto.Unavailability, error)
+	Delete(ctx context.Context, id int64) error
+
+	// UpsertExternal creates or updates the window imported from the
+	// calendar event window.ExternalID.
+	UpsertExternal(ctx context.Context, window dto.Unavailability) error
+	// DeleteExternal removes the window imported from a calendar event and
+	// reports whether one existed.
+	DeleteExternal(ctx context.Context, userID, externalID string) (bool, error)
+	// UserIDsByEmail maps the given lower-cased emails to user IDs.
+	UserIDsByEmail(ctx context.Context, emails []string) (map[string]string, error)
+
+	// ListStarted returns windows in progress whose reviews were not handed over yet.
+	ListStarted(ctx context.Context, limit int) ([]dto.Unavailability, error)
+	// MarkReassigned marks the window handled, with the reviews that could
+	// not be handed over in failure, if any.
+	MarkReassigned(ctx context.Context, id int64, failure string) error
+	// MarkFailed records a failed hand-over: the window is retried at next,
+	// or given up on if dead.
+	MarkFailed(ctx context.Context, id int64, reason string, next time.Time, dead bool) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"pr-reviwer-assigner/internal/codeowners"
+)
+
+type CodeownersRepository interface {
+	// Get returns the team's CODEOWNERS file, empty when none was uploaded.
+	Get(ctx context.Context, teamName string) (string, error)
+	// Set replaces the team's CODEOWNERS file; empty content removes it.
+	Set(ctx context.Context, teamName, content string) error
+	// UnknownOwners returns the owners that match no user or team.
+	UnknownOwners(ctx context.Context, owners []codeowners.Owner) ([]codeowners.Owner, error)
+}
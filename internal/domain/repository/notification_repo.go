@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"pr-reviwer-assigner/internal/domain/dto"
+)
+
+type NotificationRepository interface {
+	AssignmentNotice(ctx context.Context, prID, reviewerID string) (*dto.AssignmentNotice, error)
+	// ChatTeams returns the teams that have a chat webhook configured.
+	ChatTeams(ctx context.Context) ([]dto.ChatTeam, error)
+	// Recipients returns the existing users among userIDs, in no particular order.
+	Recipients(ctx context.Context, userIDs []string) ([]dto.Recipient, error)
+	// Emailed returns the users already emailed about the event.
+	Emailed(ctx context.Context, eventID int64) ([]string, error)
+	MarkEmailed(ctx context.Context, eventID int64, userID string) error
+}
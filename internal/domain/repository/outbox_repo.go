@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"pr-reviwer-assigner/internal/domain/events"
+	"time"
+)
+
+type OutboxRepository interface {
+	// Claim leases up to limit pending events for the given duration so that
+	// concurrent dispatchers don't pick the same rows.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]events.Event, error)
+	// MarkDelivered records that sink accepted the event, so that a retry
+	// after another sink's failure doesn't deliver it there again.
+	MarkDelivered(ctx context.Context, id int64, sink string) error
+	MarkDispatched(ctx context.Context, id int64) error
+	// MarkFailed records a failed attempt; a dead event is not claimed again.
+	MarkFailed(ctx context.Context, id int64, reason string, dead bool) error
+}
@@ -6,8 +6,22 @@ import (
 )
 
 type PRRepository interface {
-	Create(ctx context.Context, req dto.PRRequest) ([]string, error)
+	Create(ctx context.Context, req dto.PRRequest) (*dto.PR, error)
+	// Get returns the pull request with its reviewers.
+	Get(ctx context.Context, prID string) (*dto.PR, error)
+	// List returns a page of pull requests with their reviewers, see
+	// dto.PRListFilter.
+	List(ctx context.Context, filter dto.PRListFilter) ([]dto.PR, error)
 	Merge(ctx context.Context, req dto.MergeRequest) (*dto.PR, error)
+	// RecordMerge marks a pull request merged on the VCS provider as merged,
+	// from any status and without checking min_approvals.
+	RecordMerge(ctx context.Context, prID string) (*dto.PR, error)
 	Reassign(ctx context.Context, req dto.ReassignRequest) (*dto.PR, string, error)
+	AddReviewer(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error)
+	RemoveReviewer(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error)
 	ListOpenAssignments(ctx context.Context, reviewerID string) ([]string, error)
+	Close(ctx context.Context, prID string) (*dto.PR, error)
+	Reopen(ctx context.Context, prID string) (*dto.PR, error)
+	MarkReady(ctx context.Context, prID string) (*dto.PR, error)
+	SubmitReview(ctx context.Context, req dto.ReviewRequest) (*dto.PR, error)
 }
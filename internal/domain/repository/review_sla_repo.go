@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"pr-reviwer-assigner/internal/domain/dto"
+)
+
+type ReviewSLARepository interface {
+	// ListPending returns up to limit reviews that have been waiting at least
+	// the team's SLA in wall-clock time and are not flagged yet, ordered by
+	// assigned_at, pull_request_id and reviewer_id and starting after the
+	// review given as after. A zero after starts from the beginning.
+	ListPending(ctx context.Context, after dto.PendingReview, limit int) ([]dto.PendingReview, error)
+	// MarkBreached flags the review and writes a review.sla_breached event. It
+	// reports false when the review got a verdict, was flagged or was handed
+	// over in the meantime.
+	MarkBreached(ctx context.Context, review dto.PendingReview) (bool, error)
+}
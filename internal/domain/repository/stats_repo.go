@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"pr-reviwer-assigner/internal/domain/dto"
+)
+
+type StatsRepository interface {
+	// Pairings returns the author×reviewer matrix of the team's members.
+	Pairings(ctx context.Context, teamName string) (*dto.PairingStats, error)
+}
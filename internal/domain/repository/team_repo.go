@@ -9,4 +9,6 @@ type TeamRepository interface {
 	Add(ctx context.Context, team dto.Team) error
 	Get(teamName string) ([]dto.TeamMember, error)
 	DeactivateMembers(ctx context.Context, teamName string, userIDs []string) error
+	GetSettings(ctx context.Context, teamName string) (*dto.TeamSettings, error)
+	UpdateSettings(ctx context.Context, req dto.TeamSettingsRequest) (*dto.TeamSettings, error)
 }
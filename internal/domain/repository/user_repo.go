@@ -1,8 +1,17 @@
 package repository
 
-import "pr-reviwer-assigner/internal/domain/dto"
+import (
+	"context"
+	"pr-reviwer-assigner/internal/domain/dto"
+)
 
 type UserRepository interface {
 	GetReview(userID string) ([]dto.PRShort, error)
 	SetIsActive(user dto.SIARequest) (*dto.User, error)
+	SetProfile(ctx context.Context, req dto.ProfileRequest) (*dto.User, error)
+	GetSkills(ctx context.Context, userID string) (*dto.UserSkills, error)
+	// SetSkills replaces all skill tags of the user.
+	SetSkills(ctx context.Context, req dto.UserSkills) (*dto.UserSkills, error)
+	SetVCSIdentity(ctx context.Context, identity dto.VCSIdentity) (*dto.VCSIdentity, error)
+	ResolveVCSLogin(ctx context.Context, provider, login string) (string, error)
 }
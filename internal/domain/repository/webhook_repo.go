@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	"time"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub dto.WebhookSubscription) (*dto.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, teamName string) ([]dto.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+
+	// Enqueue creates a pending delivery of the event for every matching
+	// subscription of the given teams and of the teams of the given users.
+	Enqueue(ctx context.Context, ev events.Event, userIDs, teams []string) (int, error)
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]dto.WebhookJob, error)
+	MarkDelivered(ctx context.Context, id int64, statusCode int) error
+	MarkFailed(ctx context.Context, id int64, statusCode int, reason string, next time.Time, dead bool) error
+	ListDeliveries(ctx context.Context, subscriptionID int64, status string, limit int) ([]dto.WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, id int64) error
+}
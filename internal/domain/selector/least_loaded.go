@@ -0,0 +1,29 @@
+package selector
+
+import "sort"
+
+type leastLoaded struct{}
+
+// NewLeastLoaded picks candidates with the fewest open reviews. Ties go to the
+// candidate with fewer reviews overall, then to the lower user_id, so the result
+// is deterministic and does not keep favouring the same people on equal load.
+func NewLeastLoaded() ReviewerSelector {
+	return &leastLoaded{}
+}
+
+func (s *leastLoaded) Select(req Request, candidates []Candidate) []string {
+	sorted := make([]Candidate, len(candidates))
+	copy(sorted, candidates)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].OpenReviews != sorted[j].OpenReviews {
+			return sorted[i].OpenReviews < sorted[j].OpenReviews
+		}
+		if sorted[i].TotalReviews != sorted[j].TotalReviews {
+			return sorted[i].TotalReviews < sorted[j].TotalReviews
+		}
+		return sorted[i].UserID < sorted[j].UserID
+	})
+
+	return ids(sorted, req.Count)
+}
@@ -0,0 +1,32 @@
+package selector
+
+import "sort"
+
+type pairingAware struct{}
+
+// NewPairingAware picks candidates that reviewed the fewest of the author's
+// recent pull requests, so knowledge spreads across the team instead of the
+// same author and reviewer pairing up again. Ties are broken like least_loaded.
+func NewPairingAware() ReviewerSelector {
+	return &pairingAware{}
+}
+
+func (s *pairingAware) Select(req Request, candidates []Candidate) []string {
+	sorted := make([]Candidate, len(candidates))
+	copy(sorted, candidates)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].RecentPairings != sorted[j].RecentPairings {
+			return sorted[i].RecentPairings < sorted[j].RecentPairings
+		}
+		if sorted[i].OpenReviews != sorted[j].OpenReviews {
+			return sorted[i].OpenReviews < sorted[j].OpenReviews
+		}
+		if sorted[i].TotalReviews != sorted[j].TotalReviews {
+			return sorted[i].TotalReviews < sorted[j].TotalReviews
+		}
+		return sorted[i].UserID < sorted[j].UserID
+	})
+
+	return ids(sorted, req.Count)
+}
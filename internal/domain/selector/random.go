@@ -0,0 +1,38 @@
+package selector
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"sort"
+)
+
+type random struct {
+	seed uint64
+}
+
+// NewRandom shuffles candidates with a generator seeded by seed and the PR id,
+// so the same PR always gets the same order for the same candidate set.
+func NewRandom(seed int64) ReviewerSelector {
+	return &random{
+		seed: uint64(seed),
+	}
+}
+
+func (s *random) Select(req Request, candidates []Candidate) []string {
+	sorted := make([]Candidate, len(candidates))
+	copy(sorted, candidates)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UserID < sorted[j].UserID
+	})
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(req.PullRequestID))
+
+	rng := rand.New(rand.NewPCG(s.seed, h.Sum64()))
+	rng.Shuffle(len(sorted), func(i, j int) {
+		sorted[i], sorted[j] = sorted[j], sorted[i]
+	})
+
+	return ids(sorted, req.Count)
+}
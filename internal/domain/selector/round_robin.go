@@ -0,0 +1,27 @@
+package selector
+
+import "sort"
+
+type roundRobin struct{}
+
+// NewRoundRobin walks the team in user_id order, starting right after req.Cursor.
+func NewRoundRobin() ReviewerSelector {
+	return &roundRobin{}
+}
+
+func (s *roundRobin) Select(req Request, candidates []Candidate) []string {
+	sorted := make([]Candidate, len(candidates))
+	copy(sorted, candidates)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UserID < sorted[j].UserID
+	})
+
+	start := sort.Search(len(sorted), func(i int) bool {
+		return sorted[i].UserID > req.Cursor
+	})
+
+	rotated := append(sorted[start:len(sorted):len(sorted)], sorted[:start]...)
+
+	return ids(rotated, req.Count)
+}
@@ -0,0 +1,92 @@
+package selector
+
+// Strategy names that can be stored in teams.reviewer_strategy.
+const (
+	LeastLoaded = "least_loaded"
+	RoundRobin  = "round_robin"
+	Random      = "random"
+	// PairingAware avoids reviewers that recently reviewed the same author.
+	PairingAware = "pairing_aware"
+)
+
+// DefaultStrategy is used when a team has no strategy or an unknown one.
+const DefaultStrategy = LeastLoaded
+
+// Candidate is an active teammate that can be assigned as a reviewer.
+type Candidate struct {
+	UserID string
+	// OpenReviews counts OPEN pull requests the candidate currently reviews.
+	OpenReviews int
+	// TotalReviews counts every review the candidate was ever assigned.
+	TotalReviews int
+	// Hours is the candidate's working-hours window, nil when unknown.
+	Hours *WorkingHours
+	// Skills are the candidate's skill tags, e.g. go or postgres.
+	Skills []string
+	// RecentPairings counts how many of the author's recent pull requests the
+	// candidate reviewed, see the team's pairing_window.
+	RecentPairings int
+}
+
+// Request describes one selection round for a pull request.
+type Request struct {
+	PullRequestID string
+	AuthorID      string
+	Count         int
+	// Cursor is the last reviewer picked in the team; round-robin continues after it.
+	Cursor string
+	// RequiredSkills are the skill tags the pull request needs.
+	RequiredSkills []string
+}
+
+// ReviewerSelector picks up to req.Count reviewers from candidates.
+type ReviewerSelector interface {
+	Select(req Request, candidates []Candidate) []string
+}
+
+type Registry struct {
+	selectors map[string]ReviewerSelector
+}
+
+func NewRegistry(seed int64) *Registry {
+	return &Registry{
+		selectors: map[string]ReviewerSelector{
+			LeastLoaded:  NewLeastLoaded(),
+			RoundRobin:   NewRoundRobin(),
+			Random:       NewRandom(seed),
+			PairingAware: NewPairingAware(),
+		},
+	}
+}
+
+// Get returns the selector for the strategy, falling back to DefaultStrategy.
+func (r *Registry) Get(strategy string) ReviewerSelector {
+	if s, ok := r.selectors[strategy]; ok {
+		return s
+	}
+	return r.selectors[DefaultStrategy]
+}
+
+func IsKnown(strategy string) bool {
+	switch strategy {
+	case LeastLoaded, RoundRobin, Random, PairingAware:
+		return true
+	default:
+		return false
+	}
+}
+
+func ids(candidates []Candidate, n int) []string {
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+	if n <= 0 {
+		return nil
+	}
+
+	out := make([]string, 0, n)
+	for _, c := range candidates[:n] {
+		out = append(out, c.UserID)
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package selector
+
+import (
+	"sort"
+	"time"
+)
+
+// MatchScore is the share of the required skills the candidate has, from 0 to 1.
+// It is 1 when nothing is required.
+func (c Candidate) MatchScore(required []string) float64 {
+	if len(required) == 0 {
+		return 1
+	}
+
+	has := make(map[string]bool, len(c.Skills))
+	for _, s := range c.Skills {
+		has[s] = true
+	}
+
+	matched := 0
+	for _, s := range required {
+		if has[s] {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(required))
+}
+
+// Choose picks up to req.Count candidates. Candidates covering more of
+// req.RequiredSkills go first; among equally matching ones, SelectByPolicy
+// decides by working hours and the strategy s.
+func Choose(s ReviewerSelector, req Request, candidates []Candidate, now time.Time, lead time.Duration) []Pick {
+	byScore := make(map[float64][]Candidate)
+	var scores []float64
+	for _, c := range candidates {
+		score := c.MatchScore(req.RequiredSkills)
+		if _, ok := byScore[score]; !ok {
+			scores = append(scores, score)
+		}
+		byScore[score] = append(byScore[score], c)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(scores)))
+
+	var picks []Pick
+	for _, score := range scores {
+		if len(picks) >= req.Count {
+			break
+		}
+
+		round := req
+		round.Count = req.Count - len(picks)
+		if len(picks) > 0 {
+			round.Cursor = picks[len(picks)-1].UserID
+		}
+		for _, p := range SelectByPolicy(s, round, byScore[score], now, lead) {
+			p.MatchScore = score
+			picks = append(picks, p)
+		}
+	}
+
+	return picks
+}
@@ -0,0 +1,97 @@
+package selector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/selector"
+)
+
+func candidates() []selector.Candidate {
+	return []selector.Candidate{
+		{UserID: "u4", OpenReviews: 0},
+		{UserID: "u2", OpenReviews: 3},
+		{UserID: "u3", OpenReviews: 1},
+		{UserID: "u5", OpenReviews: 0},
+	}
+}
+
+func TestLeastLoaded_PicksLowestLoadFirst(t *testing.T) {
+	s := selector.NewLeastLoaded()
+
+	picked := s.Select(selector.Request{PullRequestID: "pr-1", Count: 3}, candidates())
+	require.Equal(t, []string{"u4", "u5", "u3"}, picked)
+}
+
+func TestRoundRobin_ContinuesAfterCursor(t *testing.T) {
+	s := selector.NewRoundRobin()
+
+	picked := s.Select(selector.Request{PullRequestID: "pr-1", Count: 2, Cursor: "u4"}, candidates())
+	require.Equal(t, []string{"u5", "u2"}, picked)
+
+	picked = s.Select(selector.Request{PullRequestID: "pr-2", Count: 2}, candidates())
+	require.Equal(t, []string{"u2", "u3"}, picked)
+}
+
+func TestRandom_IsDeterministicForSeedAndPR(t *testing.T) {
+	s := selector.NewRandom(42)
+
+	first := s.Select(selector.Request{PullRequestID: "pr-1", Count: 2}, candidates())
+	second := s.Select(selector.Request{PullRequestID: "pr-1", Count: 2}, candidates())
+	require.Len(t, first, 2)
+	require.Equal(t, first, second)
+}
+
+func TestSelectors_FewerCandidatesThanRequested(t *testing.T) {
+	registry := selector.NewRegistry(1)
+
+	for _, name := range []string{selector.LeastLoaded, selector.RoundRobin, selector.Random, selector.PairingAware} {
+		picked := registry.Get(name).Select(selector.Request{PullRequestID: "pr-1", Count: 2}, candidates()[:1])
+		require.Equal(t, []string{"u4"}, picked, name)
+
+		picked = registry.Get(name).Select(selector.Request{PullRequestID: "pr-1", Count: 2}, nil)
+		require.Empty(t, picked, name)
+	}
+}
+
+func TestRegistry_UnknownStrategyFallsBackToDefault(t *testing.T) {
+	registry := selector.NewRegistry(1)
+
+	require.False(t, selector.IsKnown("loudest"))
+	require.IsType(t, registry.Get(selector.DefaultStrategy), registry.Get("loudest"))
+}
+
+func TestLeastLoaded_BreaksTiesByTotalThenUserID(t *testing.T) {
+	s := selector.NewLeastLoaded()
+
+	picked := s.Select(selector.Request{PullRequestID: "pr-1", Count: 3}, []selector.Candidate{
+		{UserID: "u1", OpenReviews: 2, TotalReviews: 30},
+		{UserID: "u2", OpenReviews: 2, TotalReviews: 5},
+		{UserID: "u3", OpenReviews: 2, TotalReviews: 5},
+		{UserID: "u4", OpenReviews: 15, TotalReviews: 40},
+	})
+	require.Equal(t, []string{"u2", "u3", "u1"}, picked)
+}
+
+func TestLeastLoaded_ReassignSkipsBusiestReviewer(t *testing.T) {
+	registry := selector.NewRegistry(1)
+
+	picked := registry.Get(selector.DefaultStrategy).Select(selector.Request{PullRequestID: "pr-1", Count: 1}, []selector.Candidate{
+		{UserID: "senior", OpenReviews: 15, TotalReviews: 200},
+		{UserID: "newbie", OpenReviews: 0, TotalReviews: 0},
+	})
+	require.Equal(t, []string{"newbie"}, picked)
+}
+
+func TestPairingAware_PrefersReviewersNewToTheAuthor(t *testing.T) {
+	s := selector.NewPairingAware()
+
+	picked := s.Select(selector.Request{PullRequestID: "pr-1", AuthorID: "author", Count: 2}, []selector.Candidate{
+		{UserID: "u1", OpenReviews: 0, RecentPairings: 3},
+		{UserID: "u2", OpenReviews: 2, RecentPairings: 0},
+		{UserID: "u3", OpenReviews: 1, RecentPairings: 0},
+		{UserID: "u4", OpenReviews: 0, RecentPairings: 1},
+	})
+	require.Equal(t, []string{"u3", "u2"}, picked)
+}
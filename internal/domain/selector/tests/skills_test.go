@@ -0,0 +1,53 @@
+package selector_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/selector"
+)
+
+func TestCandidate_MatchScore(t *testing.T) {
+	c := selector.Candidate{UserID: "u1", Skills: []string{"go", "postgres"}}
+
+	require.Equal(t, 1.0, c.MatchScore(nil))
+	require.Equal(t, 1.0, c.MatchScore([]string{"go"}))
+	require.Equal(t, 0.5, c.MatchScore([]string{"go", "react"}))
+	require.Zero(t, c.MatchScore([]string{"react"}))
+}
+
+func TestChoose_PrefersBetterMatch(t *testing.T) {
+	candidates := []selector.Candidate{
+		{UserID: "idle", OpenReviews: 0},
+		{UserID: "partial", OpenReviews: 3, Skills: []string{"go"}},
+		{UserID: "full", OpenReviews: 5, Skills: []string{"go", "postgres"}},
+		{UserID: "also-partial", OpenReviews: 1, Skills: []string{"postgres"}},
+	}
+	req := selector.Request{
+		PullRequestID:  "pr-1",
+		AuthorID:       "author-1",
+		Count:          3,
+		RequiredSkills: []string{"go", "postgres"},
+	}
+
+	picks := selector.Choose(selector.NewLeastLoaded(), req, candidates, time.Now(), time.Hour)
+	require.Equal(t, []selector.Pick{
+		{UserID: "full", Policy: selector.PolicyWorkingHours, MatchScore: 1},
+		{UserID: "also-partial", Policy: selector.PolicyWorkingHours, MatchScore: 0.5},
+		{UserID: "partial", Policy: selector.PolicyWorkingHours, MatchScore: 0.5},
+	}, picks)
+}
+
+func TestChoose_NoRequiredSkillsKeepsStrategyOrder(t *testing.T) {
+	candidates := []selector.Candidate{
+		{UserID: "busy", OpenReviews: 4, Skills: []string{"go"}},
+		{UserID: "idle", OpenReviews: 0},
+	}
+	req := selector.Request{PullRequestID: "pr-1", AuthorID: "author-1", Count: 1}
+
+	picks := selector.Choose(selector.NewLeastLoaded(), req, candidates, time.Now(), time.Hour)
+	require.Len(t, picks, 1)
+	require.Equal(t, "idle", picks[0].UserID)
+}
@@ -0,0 +1,103 @@
+package selector_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/selector"
+)
+
+func weekdays(t *testing.T, timeZone, start, end string) *selector.WorkingHours {
+	t.Helper()
+
+	hours, err := selector.NewWorkingHours(timeZone, start, end, []int{1, 2, 3, 4, 5})
+	require.NoError(t, err)
+	return hours
+}
+
+func TestWorkingHours_Until(t *testing.T) {
+	tokyo := weekdays(t, "Asia/Tokyo", "09:00", "18:00")
+
+	// Wednesday 2025-01-15 02:00 UTC is 11:00 in Tokyo.
+	d, ok := tokyo.Until(time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	require.Zero(t, d)
+
+	// 22:30 UTC is 07:30 on Thursday in Tokyo.
+	d, ok = tokyo.Until(time.Date(2025, 1, 15, 22, 30, 0, 0, time.UTC))
+	require.True(t, ok)
+	require.Equal(t, 90*time.Minute, d)
+
+	// Friday 18:00 in Tokyo waits for Monday 09:00.
+	d, ok = tokyo.Until(time.Date(2025, 1, 17, 9, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	require.Equal(t, 63*time.Hour, d)
+}
+
+func TestWorkingHours_OvernightWindow(t *testing.T) {
+	night := weekdays(t, "UTC", "22:00", "06:00")
+
+	// Saturday 03:00 still belongs to the shift that started on Friday.
+	d, ok := night.Until(time.Date(2025, 1, 18, 3, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	require.Zero(t, d)
+
+	d, ok = night.Until(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	require.Equal(t, 10*time.Hour, d)
+}
+
+func TestNewWorkingHours_RejectsInvalidInput(t *testing.T) {
+	_, err := selector.NewWorkingHours("Mars/Olympus", "09:00", "18:00", []int{1})
+	require.Error(t, err)
+
+	_, err = selector.NewWorkingHours("UTC", "9am", "18:00", []int{1})
+	require.Error(t, err)
+
+	_, err = selector.NewWorkingHours("UTC", "09:00", "18:00", []int{0})
+	require.Error(t, err)
+}
+
+func TestByPolicy_GroupsByWorkingHoursAndLeadTime(t *testing.T) {
+	// Wednesday 2025-01-15 08:30 UTC: 09:30 in Berlin, 08:30 in London, 03:30 in New York.
+	now := time.Date(2025, 1, 15, 8, 30, 0, 0, time.UTC)
+
+	groups := selector.ByPolicy([]selector.Candidate{
+		{UserID: "berlin", Hours: weekdays(t, "Europe/Berlin", "09:00", "18:00")},
+		{UserID: "london", Hours: weekdays(t, "Europe/London", "09:00", "18:00")},
+		{UserID: "newyork", Hours: weekdays(t, "America/New_York", "09:00", "18:00")},
+		{UserID: "unknown"},
+	}, now, time.Hour)
+
+	ids := func(candidates []selector.Candidate) []string {
+		var out []string
+		for _, c := range candidates {
+			out = append(out, c.UserID)
+		}
+		return out
+	}
+
+	require.Equal(t, []string{"berlin", "unknown"}, ids(groups[selector.PolicyWorkingHours]))
+	require.Equal(t, []string{"london"}, ids(groups[selector.PolicyLeadTime]))
+	require.Equal(t, []string{"newyork"}, ids(groups[selector.PolicyOffHours]))
+}
+
+func TestWorkingHours_Between(t *testing.T) {
+	berlin := weekdays(t, "Europe/Berlin", "09:00", "17:00")
+
+	// Thursday 2025-01-16 15:00 to Monday 11:00 in Berlin: 2h + 8h + 2h.
+	from := time.Date(2025, 1, 16, 14, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 20, 10, 0, 0, 0, time.UTC)
+	require.Equal(t, 12*time.Hour, berlin.Between(from, to))
+
+	require.Zero(t, berlin.Between(to, from))
+
+	// An overnight window that opened before from still counts.
+	night := weekdays(t, "UTC", "22:00", "06:00")
+	require.Equal(t, 3*time.Hour, night.Between(
+		time.Date(2025, 1, 15, 3, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+	))
+}
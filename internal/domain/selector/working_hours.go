@@ -0,0 +1,219 @@
+package selector
+
+import (
+	"fmt"
+	"time"
+)
+
+// Selection policies, best first. They are stored on every assignment to explain
+// why the reviewer was picked: the team's strategy only chooses among candidates
+// of the best policy that still has someone left.
+const (
+	// PolicyWorkingHours: the reviewer was inside their working hours.
+	PolicyWorkingHours = "working_hours"
+	// PolicyLeadTime: the reviewer's working hours started within the team's lead time.
+	PolicyLeadTime = "lead_time"
+	// PolicyOffHours: nobody inside or close to their working hours was left.
+	PolicyOffHours = "off_hours"
+)
+
+// Policies lists the selection policies in the order candidates are tried.
+var Policies = []string{PolicyWorkingHours, PolicyLeadTime, PolicyOffHours}
+
+// WorkingHours is a weekly working-hours window in the user's time zone.
+type WorkingHours struct {
+	Location *time.Location
+	// Start and End are offsets from local midnight. An End that is not after
+	// Start means the window runs past midnight into the next day.
+	Start time.Duration
+	End   time.Duration
+	// Days are the weekdays the window starts on.
+	Days []time.Weekday
+}
+
+// ClockLayout is the layout of working-hours start and end times.
+const ClockLayout = "15:04"
+
+// NewWorkingHours builds a window from its stored form: an IANA time zone name,
+// "15:04" start and end times and ISO weekdays, 1 = Monday ... 7 = Sunday.
+func NewWorkingHours(timeZone, start, end string, isoDays []int) (*WorkingHours, error) {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("time zone %q: %w", timeZone, err)
+	}
+
+	w := &WorkingHours{Location: loc}
+	if w.Start, err = clock(start); err != nil {
+		return nil, err
+	}
+	if w.End, err = clock(end); err != nil {
+		return nil, err
+	}
+
+	for _, d := range isoDays {
+		if d < 1 || d > 7 {
+			return nil, fmt.Errorf("weekday %d is out of 1..7", d)
+		}
+		w.Days = append(w.Days, time.Weekday(d%7))
+	}
+
+	return w, nil
+}
+
+func clock(value string) (time.Duration, error) {
+	t, err := time.Parse(ClockLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("time %q: want HH:MM", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Until returns how long it takes from now until the user is inside their working
+// hours: 0 when they already are. ok is false when the window never opens.
+func (w WorkingHours) Until(now time.Time) (d time.Duration, ok bool) {
+	loc := w.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	y, m, day := local.Date()
+
+	// Yesterday's window may still be open when it runs past midnight, and a week
+	// ahead always reaches the next working day.
+	for offset := -1; offset <= 7; offset++ {
+		midnight := time.Date(y, m, day+offset, 0, 0, 0, 0, loc)
+		if !w.worksOn(midnight.Weekday()) {
+			continue
+		}
+
+		start := midnight.Add(w.Start)
+		end := midnight.Add(w.End)
+		if w.End <= w.Start {
+			end = time.Date(y, m, day+offset+1, 0, 0, 0, 0, loc).Add(w.End)
+		}
+
+		switch {
+		case !local.Before(start) && local.Before(end):
+			return 0, true
+		case start.After(local):
+			return start.Sub(local), true
+		}
+	}
+
+	return 0, false
+}
+
+// Between returns how much of the time from from to to falls inside the working
+// hours.
+func (w WorkingHours) Between(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+
+	loc := w.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, m, day := from.In(loc).Date()
+
+	var total time.Duration
+	// Start a day early for a window that runs past midnight into from.
+	for offset := -1; ; offset++ {
+		midnight := time.Date(y, m, day+offset, 0, 0, 0, 0, loc)
+		if !midnight.Before(to) {
+			break
+		}
+		if !w.worksOn(midnight.Weekday()) {
+			continue
+		}
+
+		start := midnight.Add(w.Start)
+		end := midnight.Add(w.End)
+		if w.End <= w.Start {
+			end = time.Date(y, m, day+offset+1, 0, 0, 0, 0, loc).Add(w.End)
+		}
+
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+
+	return total
+}
+
+func (w WorkingHours) worksOn(day time.Weekday) bool {
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy returns the selection policy a candidate qualifies for at now. Candidates
+// without working hours are always treated as working.
+func (c Candidate) Policy(now time.Time, lead time.Duration) string {
+	if c.Hours == nil {
+		return PolicyWorkingHours
+	}
+
+	d, ok := c.Hours.Until(now)
+	switch {
+	case !ok:
+		return PolicyOffHours
+	case d == 0:
+		return PolicyWorkingHours
+	case d <= lead:
+		return PolicyLeadTime
+	default:
+		return PolicyOffHours
+	}
+}
+
+// ByPolicy groups candidates by the policy they qualify for, keeping their order.
+func ByPolicy(candidates []Candidate, now time.Time, lead time.Duration) map[string][]Candidate {
+	groups := make(map[string][]Candidate, len(Policies))
+	for _, c := range candidates {
+		p := c.Policy(now, lead)
+		groups[p] = append(groups[p], c)
+	}
+	return groups
+}
+
+// Pick is a chosen reviewer with the policy it qualified for.
+type Pick struct {
+	UserID string
+	Policy string
+	// MatchScore is set by Choose, see Candidate.MatchScore.
+	MatchScore float64
+}
+
+// SelectByPolicy lets s choose up to req.Count candidates, exhausting the
+// candidates of one policy before moving on to the next, see Policies.
+func SelectByPolicy(s ReviewerSelector, req Request, candidates []Candidate, now time.Time, lead time.Duration) []Pick {
+	groups := ByPolicy(candidates, now, lead)
+
+	var picks []Pick
+	for _, policy := range Policies {
+		if len(picks) >= req.Count {
+			break
+		}
+
+		round := req
+		round.Count = req.Count - len(picks)
+		if len(picks) > 0 {
+			round.Cursor = picks[len(picks)-1].UserID
+		}
+		for _, id := range s.Select(round, groups[policy]) {
+			picks = append(picks, Pick{UserID: id, Policy: policy})
+		}
+	}
+
+	return picks
+}
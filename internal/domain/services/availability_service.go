@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"pr-reviwer-assigner/internal/ical"
+	"time"
+)
+
+type AvailabilityService interface {
+	Add(ctx context.Context, window dto.Unavailability) (*dto.Unavailability, error)
+	List(ctx context.Context, userID string) ([]dto.Unavailability, error)
+	Update(ctx context.Context, window dto.Unavailability) (*dto.Unavailability, error)
+	Delete(ctx context.Context, id int64) error
+
+	// ImportICS turns the out-of-office events of a calendar into windows.
+	// Windows are keyed by event UID, so importing the same calendar again
+	// updates them, and cancelled events remove them. An empty userID
+	// matches events to users by organizer and attendee email.
+	ImportICS(ctx context.Context, userID string, r io.Reader) (*dto.ICSImportResponse, error)
+	// ImportICSFile imports a calendar file from the import directory.
+	ImportICSFile(ctx context.Context, req dto.ICSImportRequest) (*dto.ICSImportResponse, error)
+}
+
+type availabilityService struct {
+	repo   repository.AvailabilityRepository
+	icsDir string
+}
+
+// NewAvailabilityService reads calendar files only from icsDir; an empty
+// icsDir disables ImportICSFile.
+func NewAvailabilityService(repo repository.AvailabilityRepository, icsDir string) AvailabilityService {
+	return &availabilityService{
+		repo:   repo,
+		icsDir: icsDir,
+	}
+}
+
+func (s *availabilityService) Add(ctx context.Context, window dto.Unavailability) (*dto.Unavailability, error) {
+	return s.repo.Add(ctx, window)
+}
+
+func (s *availabilityService) List(ctx context.Context, userID string) ([]dto.Unavailability, error) {
+	windows, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if windows == nil {
+		windows = []dto.Unavailability{}
+	}
+
+	return windows, nil
+}
+
+func (s *availabilityService) Update(ctx context.Context, window dto.Unavailability) (*dto.Unavailability, error) {
+	return s.repo.Update(ctx, window)
+}
+
+func (s *availabilityService) Delete(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *availabilityService) ImportICS(ctx context.Context, userID string, r io.Reader) (*dto.ICSImportResponse, error) {
+	calendar, err := ical.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors2.ErrInvalidCalendar, err)
+	}
+
+	owners, err := s.eventOwners(ctx, userID, calendar)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resp := &dto.ICSImportResponse{Skipped: []dto.ICSSkippedEvent{}}
+	skip := func(ev ical.Event, reason string) {
+		resp.Skipped = append(resp.Skipped, dto.ICSSkippedEvent{UID: ev.UID, Reason: reason})
+	}
+
+	for _, ev := range calendar {
+		switch {
+		case ev.UID == "":
+			skip(ev, "missing UID")
+			continue
+		case !ev.IsOutOfOffice():
+			skip(ev, "not an out-of-office event")
+			continue
+		}
+
+		users := owners(ev)
+		if len(users) == 0 {
+			skip(ev, "no user with the organizer or attendee email")
+			continue
+		}
+
+		if ev.Cancelled() {
+			for _, id := range users {
+				removed, err := s.repo.DeleteExternal(ctx, id, ev.UID)
+				if err != nil {
+					return nil, err
+				}
+				if removed {
+					resp.Removed++
+				}
+			}
+			continue
+		}
+
+		switch {
+		case !ev.End.After(ev.Start):
+			skip(ev, "empty time range")
+			continue
+		case !ev.End.After(now):
+			skip(ev, "already ended")
+			continue
+		}
+
+		for _, id := range users {
+			err := s.repo.UpsertExternal(ctx, dto.Unavailability{
+				UserID:     id,
+				StartsAt:   ev.Start.UTC(),
+				EndsAt:     ev.End.UTC(),
+				Reason:     ev.Summary,
+				ExternalID: ev.UID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			resp.Imported++
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *availabilityService) ImportICSFile(ctx context.Context, req dto.ICSImportRequest) (*dto.ICSImportResponse, error) {
+	if s.icsDir == "" {
+		return nil, fmt.Errorf("%w: calendar import directory is not configured", errors2.ErrBadRequest)
+	}
+	if !filepath.IsLocal(req.Path) {
+		return nil, fmt.Errorf("%w: path must be relative to the import directory", errors2.ErrBadRequest)
+	}
+
+	f, err := os.Open(filepath.Join(s.icsDir, req.Path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors2.ErrNotFound
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	return s.ImportICS(ctx, req.UserID, f)
+}
+
+// eventOwners returns a function giving the users an event belongs to:
+// always userID when set, otherwise the users matching its emails.
+func (s *availabilityService) eventOwners(ctx context.Context, userID string, calendar []ical.Event) (func(ical.Event) []string, error) {
+	if userID != "" {
+		return func(ical.Event) []string { return []string{userID} }, nil
+	}
+
+	var emails []string
+	for _, ev := range calendar {
+		emails = append(emails, ev.Emails...)
+	}
+
+	byEmail, err := s.repo.UserIDsByEmail(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ev ical.Event) []string {
+		var users []string
+		seen := make(map[string]bool)
+		for _, email := range ev.Emails {
+			if id, ok := byEmail[email]; ok && !seen[id] {
+				seen[id] = true
+				users = append(users, id)
+			}
+		}
+		return users
+	}, nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"pr-reviwer-assigner/internal/domain/repository"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Availability watcher defaults, used when the config leaves them at zero.
+const (
+	DefaultAvailabilityPollInterval = time.Minute
+	DefaultAvailabilityBatchSize    = 50
+)
+
+// AvailabilityMaxAttempts bounds the hand-overs tried per unavailability
+// window.
+const AvailabilityMaxAttempts = 8
+
+// AvailabilityWatcher hands over the open reviews of users whose
+// unavailability window has started, the same way DeactivateMembers does.
+type AvailabilityWatcher struct {
+	repo     repository.AvailabilityRepository
+	prRepo   repository.PRRepository
+	interval time.Duration
+	batch    int
+	logger   *zap.SugaredLogger
+}
+
+func NewAvailabilityWatcher(repo repository.AvailabilityRepository, prRepo repository.PRRepository, interval time.Duration, batch int, logger *zap.SugaredLogger) *AvailabilityWatcher {
+	if interval <= 0 {
+		interval = DefaultAvailabilityPollInterval
+	}
+	if batch <= 0 {
+		batch = DefaultAvailabilityBatchSize
+	}
+
+	return &AvailabilityWatcher{
+		repo:     repo,
+		prRepo:   prRepo,
+		interval: interval,
+		batch:    batch,
+		logger:   logger,
+	}
+}
+
+// Run watches until ctx is cancelled.
+func (w *AvailabilityWatcher) Run(ctx context.Context) {
+	poll(ctx, w.interval, w.batch, w.ReassignOnce, func(err error) {
+		w.logger.Error("reassign unavailable reviewers: ", err)
+	})
+}
+
+// ReassignOnce handles one batch of started windows and returns how many it
+// looked at. A window whose reviews couldn't all be handed over is retried
+// with backoff, picking up only the reviews still left, and given up on after
+// AvailabilityMaxAttempts. Active required reviewers are never handed over,
+// so they alone don't hold the window back.
+func (w *AvailabilityWatcher) ReassignOnce(ctx context.Context) (int, error) {
+	windows, err := w.repo.ListStarted(ctx, w.batch)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, window := range windows {
+		failed, err := reassignOpenReviews(ctx, w.prRepo, window.UserID)
+		if err != nil {
+			failed = []reassignFailure{{err: err}}
+		}
+
+		var reasons []string
+		retry := false
+		for _, f := range failed {
+			reasons = append(reasons, f.String())
+			if !errors.Is(f.err, errors2.ErrInvalidReviewers) {
+				retry = true
+			}
+		}
+		reason := strings.Join(reasons, "; ")
+
+		if !retry {
+			if err := w.repo.MarkReassigned(ctx, window.ID, reason); err != nil {
+				return len(windows), err
+			}
+			continue
+		}
+
+		attempt := window.ReassignAttempts + 1
+		dead := attempt >= AvailabilityMaxAttempts
+		if dead {
+			w.logger.Error("reassign reviews of unavailable user ", window.UserID, ": giving up after ", attempt, " attempts: ", reason)
+		} else {
+			w.logger.Error("reassign reviews of unavailable user ", window.UserID, ": ", reason)
+		}
+		if err := w.repo.MarkFailed(ctx, window.ID, reason, time.Now().Add(w.backoff(attempt)), dead); err != nil {
+			return len(windows), err
+		}
+	}
+
+	return len(windows), nil
+}
+
+// backoff is the wait after the given failed attempt: the poll interval
+// doubled per attempt, capped at an hour.
+func (w *AvailabilityWatcher) backoff(attempt int) time.Duration {
+	wait := w.interval
+	for i := 1; i < attempt && wait < time.Hour; i++ {
+		wait *= 2
+	}
+	return min(wait, time.Hour)
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"time"
+)
+
+// DefaultChatTimeout bounds one post to a chat webhook.
+const DefaultChatTimeout = 5 * time.Second
+
+// ChatMessage is the Slack incoming-webhook payload. Text uses Slack's
+// mrkdwn, so mentions are written as <@MEMBER_ID>.
+type ChatMessage struct {
+	Text string `json:"text"`
+}
+
+// ChatPoster posts messages to Slack-compatible incoming webhooks.
+type ChatPoster struct {
+	client *http.Client
+}
+
+func NewChatPoster(timeout time.Duration) *ChatPoster {
+	if timeout <= 0 {
+		timeout = DefaultChatTimeout
+	}
+
+	return &ChatPoster{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Post sends msg to url and returns the response status. A non-2xx status
+// is returned together with an error.
+func (p *ChatPoster) Post(ctx context.Context, url string, msg ChatMessage) (int, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+
+	return resp.StatusCode, nil
+}
+
+// ChatMention mentions the user by chat handle, or names them when no
+// handle is stored.
+func ChatMention(r dto.Recipient) string {
+	if r.ChatHandle != "" {
+		return "<@" + r.ChatHandle + ">"
+	}
+	if r.Username != "" {
+		return r.Username
+	}
+	return r.UserID
+}
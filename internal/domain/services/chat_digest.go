@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/repository"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ChatDigest posts a daily summary of each member's open reviews to every
+// team with a chat webhook.
+type ChatDigest struct {
+	repo   repository.NotificationRepository
+	prRepo repository.PRRepository
+	poster *ChatPoster
+	at     time.Duration
+	logger *zap.SugaredLogger
+}
+
+// NewChatDigest sends the digest every day at the given offset from UTC midnight.
+func NewChatDigest(repo repository.NotificationRepository, prRepo repository.PRRepository, poster *ChatPoster, at time.Duration, logger *zap.SugaredLogger) *ChatDigest {
+	return &ChatDigest{
+		repo:   repo,
+		prRepo: prRepo,
+		poster: poster,
+		at:     at,
+		logger: logger,
+	}
+}
+
+// Run sends the digest daily until ctx is cancelled.
+func (d *ChatDigest) Run(ctx context.Context) {
+	for {
+		timer := time.NewTimer(time.Until(d.NextRun(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if _, err := d.SendOnce(ctx); err != nil {
+			d.logger.Error("send chat digest: ", err)
+		}
+	}
+}
+
+// NextRun is the first digest time strictly after now.
+func (d *ChatDigest) NextRun(now time.Time) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Add(d.at)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// SendOnce posts the digest to every team and returns how many teams got it.
+// A team whose post fails is skipped; the last error is returned.
+func (d *ChatDigest) SendOnce(ctx context.Context) (int, error) {
+	teams, err := d.repo.ChatTeams(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var lastErr error
+	for _, team := range teams {
+		var b strings.Builder
+		fmt.Fprintf(&b, "Open reviews in *%s*:", team.TeamName)
+
+		for _, member := range team.Members {
+			prIDs, err := d.prRepo.ListOpenAssignments(ctx, member.UserID)
+			if err != nil {
+				return sent, err
+			}
+
+			if len(prIDs) == 0 {
+				fmt.Fprintf(&b, "\n• %s: none", ChatMention(member))
+				continue
+			}
+			fmt.Fprintf(&b, "\n• %s: %d (`%s`)", ChatMention(member), len(prIDs), strings.Join(prIDs, "`, `"))
+		}
+
+		if _, err := d.poster.Post(ctx, team.ChatWebhookURL, ChatMessage{Text: b.String()}); err != nil {
+			lastErr = fmt.Errorf("%s: %w", team.TeamName, err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, lastErr
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/repository"
+	errors2 "pr-reviwer-assigner/internal/errors"
+
+	"go.uber.org/zap"
+)
+
+type chatSink struct {
+	repo   repository.NotificationRepository
+	poster *ChatPoster
+	logger *zap.SugaredLogger
+}
+
+// NewChatSink returns a sink that posts reviewer.assigned and
+// review.sla_breached events to the chat webhook of the PR author's team. It
+// runs after the change is committed, so a failed post never affects it.
+func NewChatSink(repo repository.NotificationRepository, poster *ChatPoster, logger *zap.SugaredLogger) events.Sink {
+	return &chatSink{
+		repo:   repo,
+		poster: poster,
+		logger: logger,
+	}
+}
+
+func (s *chatSink) Name() string {
+	return "chat"
+}
+
+func (s *chatSink) Deliver(ctx context.Context, ev events.Event) error {
+	switch ev.Type {
+	case events.ReviewerAssigned:
+		var payload events.ReviewerAssignedPayload
+		if err := ev.Decode(&payload); err != nil {
+			return err
+		}
+		return s.notify(ctx, payload.PullRequestID, payload.ReviewerID, func(n *dto.AssignmentNotice) string {
+			return fmt.Sprintf("%s you were assigned to review *%s* (`%s`) by %s",
+				ChatMention(n.Reviewer),
+				n.PullRequestName,
+				n.PullRequestID,
+				n.AuthorName,
+			)
+		})
+	case events.ReviewSLABreached:
+		var payload events.ReviewSLABreachedPayload
+		if err := ev.Decode(&payload); err != nil {
+			return err
+		}
+		return s.notify(ctx, payload.PullRequestID, payload.ReviewerID, func(n *dto.AssignmentNotice) string {
+			return fmt.Sprintf(":warning: %s has not reviewed *%s* (`%s`) by %s within %d working hours",
+				ChatMention(n.Reviewer),
+				n.PullRequestName,
+				n.PullRequestID,
+				n.AuthorName,
+				payload.SLAHours,
+			)
+		})
+	default:
+		return nil
+	}
+}
+
+// notify posts the text built from the notice of the PR and reviewer.
+func (s *chatSink) notify(ctx context.Context, prID, reviewerID string, text func(n *dto.AssignmentNotice) string) error {
+	notice, err := s.repo.AssignmentNotice(ctx, prID, reviewerID)
+	if err != nil {
+		if errors.Is(err, errors2.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if notice.ChatWebhookURL == "" {
+		return nil
+	}
+
+	code, err := s.poster.Post(ctx, notice.ChatWebhookURL, ChatMessage{Text: text(notice)})
+	if err != nil {
+		// A 4xx means the webhook is misconfigured; retrying won't help.
+		if code >= 400 && code < 500 {
+			s.logger.Error("chat notification for team ", notice.TeamName, " rejected: ", err)
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
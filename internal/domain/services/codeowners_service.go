@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"pr-reviwer-assigner/internal/codeowners"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"strings"
+)
+
+// maxCodeownersSize bounds an uploaded CODEOWNERS file; GitHub rejects files
+// over 3 MB as well.
+const maxCodeownersSize = 3 << 20
+
+type CodeownersService interface {
+	Get(ctx context.Context, teamName string) (*dto.CodeownersResponse, error)
+	// Upload validates the file and replaces the team's rules with it. Every
+	// owner must be an existing user (@user_id or email) or team (@org/team_name).
+	// An empty file removes the rules.
+	Upload(ctx context.Context, teamName string, r io.Reader) (*dto.CodeownersResponse, error)
+}
+
+type codeownersService struct {
+	repo repository.CodeownersRepository
+}
+
+func NewCodeownersService(repo repository.CodeownersRepository) CodeownersService {
+	return &codeownersService{
+		repo: repo,
+	}
+}
+
+func (s *codeownersService) Get(ctx context.Context, teamName string) (*dto.CodeownersResponse, error) {
+	content, err := s.repo.Get(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stored file was valid when uploaded.
+	rules, err := codeowners.Parse(strings.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	return codeownersResponse(teamName, rules), nil
+}
+
+func (s *codeownersService) Upload(ctx context.Context, teamName string, r io.Reader) (*dto.CodeownersResponse, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxCodeownersSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxCodeownersSize {
+		return nil, fmt.Errorf("%w: file is larger than %d bytes", errors2.ErrInvalidCodeowners, maxCodeownersSize)
+	}
+
+	rules, err := codeowners.Parse(strings.NewReader(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors2.ErrInvalidCodeowners, err)
+	}
+
+	var owners []codeowners.Owner
+	for _, rule := range rules {
+		owners = append(owners, rule.Owners...)
+	}
+	if len(owners) > 0 {
+		unknown, err := s.repo.UnknownOwners(ctx, owners)
+		if err != nil {
+			return nil, err
+		}
+		if len(unknown) > 0 {
+			names := make([]string, 0, len(unknown))
+			for _, o := range unknown {
+				names = append(names, o.String())
+			}
+			return nil, fmt.Errorf("%w: unknown owners: %s", errors2.ErrInvalidCodeowners, strings.Join(names, ", "))
+		}
+	}
+
+	// A file with nothing but comments removes the rules as well.
+	stored := string(content)
+	if len(rules) == 0 {
+		stored = ""
+	}
+	if err := s.repo.Set(ctx, teamName, stored); err != nil {
+		return nil, err
+	}
+
+	return codeownersResponse(teamName, rules), nil
+}
+
+func codeownersResponse(teamName string, rules codeowners.Rules) *dto.CodeownersResponse {
+	resp := &dto.CodeownersResponse{
+		TeamName: teamName,
+		Rules:    make([]dto.CodeownersRule, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		owners := make([]string, 0, len(rule.Owners))
+		for _, o := range rule.Owners {
+			owners = append(owners, o.String())
+		}
+		resp.Rules = append(resp.Rules, dto.CodeownersRule{
+			Line:    rule.Line,
+			Pattern: rule.Pattern,
+			Owners:  owners,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/repository"
+	"pr-reviwer-assigner/internal/mail"
+	"slices"
+
+	"go.uber.org/zap"
+)
+
+type emailSink struct {
+	repo      repository.NotificationRepository
+	sender    mail.Sender
+	templates *mail.Templates
+	logger    *zap.SugaredLogger
+}
+
+// NewEmailSink returns a sink that emails reviewers when they are assigned
+// or replaced, and the author and reviewers when a PR is merged. Users
+// without an email address are skipped.
+func NewEmailSink(repo repository.NotificationRepository, sender mail.Sender, templates *mail.Templates, logger *zap.SugaredLogger) events.Sink {
+	return &emailSink{
+		repo:      repo,
+		sender:    sender,
+		templates: templates,
+		logger:    logger,
+	}
+}
+
+func (s *emailSink) Name() string {
+	return "email"
+}
+
+func (s *emailSink) Deliver(ctx context.Context, ev events.Event) error {
+	switch ev.Type {
+	case events.ReviewerAssigned:
+		var p events.ReviewerAssignedPayload
+		if err := ev.Decode(&p); err != nil {
+			return err
+		}
+		return s.send(ctx, ev.ID, mail.TemplateAssigned, p.PullRequestID, p.Name, p.AuthorID, "", []string{p.ReviewerID})
+	case events.ReviewerReplaced:
+		var p events.ReviewerReplacedPayload
+		if err := ev.Decode(&p); err != nil {
+			return err
+		}
+		return s.send(ctx, ev.ID, mail.TemplateReplaced, p.PullRequestID, p.Name, p.AuthorID, p.NewReviewerID, []string{p.OldReviewerID})
+	case events.PRMerged:
+		var p events.PRMergedPayload
+		if err := ev.Decode(&p); err != nil {
+			return err
+		}
+		return s.send(ctx, ev.ID, mail.TemplateMerged, p.PullRequestID, p.Name, p.AuthorID, "", append([]string{p.AuthorID}, p.Reviewers...))
+	default:
+		return nil
+	}
+}
+
+// send renders one message per recipient so each is greeted by name. Every
+// recipient handled is recorded against the event, so a retry after a
+// transient error mails only the ones that are left.
+func (s *emailSink) send(ctx context.Context, eventID int64, template, prID, prName, authorID, reviewerID string, to []string) error {
+	users, err := s.repo.Recipients(ctx, append([]string{authorID, reviewerID}, to...))
+	if err != nil {
+		return err
+	}
+
+	emailed, err := s.repo.Emailed(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]dto.Recipient, len(users))
+	for _, u := range users {
+		byID[u.UserID] = u
+	}
+
+	data := mail.Data{
+		PullRequestID:   prID,
+		PullRequestName: prName,
+		AuthorName:      displayName(byID, authorID),
+		ReviewerName:    displayName(byID, reviewerID),
+	}
+
+	for _, id := range to {
+		rcpt, ok := byID[id]
+		if !ok || rcpt.Email == "" || slices.Contains(emailed, id) {
+			continue
+		}
+
+		data.RecipientName = displayName(byID, id)
+		msg, err := s.templates.Render(template, []string{rcpt.Email}, data)
+		if err != nil {
+			return err
+		}
+
+		if err := s.sender.Send(ctx, msg); err != nil {
+			if !mail.IsPermanent(err) {
+				return err
+			}
+			s.logger.Error("email to ", id, " rejected: ", err)
+		}
+
+		if err := s.repo.MarkEmailed(ctx, eventID, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func displayName(users map[string]dto.Recipient, id string) string {
+	if u, ok := users[id]; ok && u.Username != "" {
+		return u.Username
+	}
+	return id
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/repository"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Outbox polling defaults, used when the config leaves them at zero.
+const (
+	DefaultOutboxPollInterval = time.Second
+	DefaultOutboxBatchSize    = 100
+	DefaultOutboxMaxAttempts  = 20
+)
+
+// EventDispatcher polls the outbox and hands every event to the registered
+// sinks. An event is marked dispatched once all sinks have accepted it; a sink
+// that accepted it is not handed it again when another one fails. An event
+// still failing after maxAttempts is dead-lettered.
+type EventDispatcher struct {
+	repo        repository.OutboxRepository
+	interval    time.Duration
+	batch       int
+	maxAttempts int
+	logger      *zap.SugaredLogger
+
+	mu    sync.RWMutex
+	sinks []events.Sink
+}
+
+func NewEventDispatcher(repo repository.OutboxRepository, interval time.Duration, batch, maxAttempts int, logger *zap.SugaredLogger) *EventDispatcher {
+	if interval <= 0 {
+		interval = DefaultOutboxPollInterval
+	}
+	if batch <= 0 {
+		batch = DefaultOutboxBatchSize
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultOutboxMaxAttempts
+	}
+
+	return &EventDispatcher{
+		repo:        repo,
+		interval:    interval,
+		batch:       batch,
+		maxAttempts: maxAttempts,
+		logger:      logger,
+	}
+}
+
+func (d *EventDispatcher) Register(sink events.Sink) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sinks = append(d.sinks, sink)
+}
+
+// Run dispatches until ctx is cancelled.
+func (d *EventDispatcher) Run(ctx context.Context) {
+	poll(ctx, d.interval, d.batch, d.DispatchOnce, func(err error) {
+		d.logger.Error("dispatch outbox: ", err)
+	})
+}
+
+// poll calls once every interval until ctx is cancelled. A full batch means
+// there is probably more waiting, so it is called again right away.
+func poll(ctx context.Context, interval time.Duration, batch int, once func(context.Context) (int, error), onErr func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := once(ctx)
+			if err != nil {
+				onErr(err)
+				break
+			}
+			if n < batch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce claims one batch of events and delivers it. It returns the
+// number of events claimed.
+func (d *EventDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	// The lease outlives a slow batch so that another instance doesn't
+	// deliver the same events concurrently.
+	claimed, err := d.repo.Claim(ctx, d.batch, 10*d.interval+time.Minute)
+	if err != nil {
+		return 0, err
+	}
+
+	d.mu.RLock()
+	sinks := d.sinks
+	d.mu.RUnlock()
+
+	for _, ev := range claimed {
+		if err := d.deliver(ctx, sinks, ev); err != nil {
+			attempt := ev.Attempts + 1
+			dead := attempt >= d.maxAttempts
+			if dead {
+				d.logger.Error("deliver event ", ev.ID, " (", ev.Type, "): dead after ", attempt, " attempts: ", err)
+			} else {
+				d.logger.Error("deliver event ", ev.ID, " (", ev.Type, "): ", err)
+			}
+			if err := d.repo.MarkFailed(ctx, ev.ID, err.Error(), dead); err != nil {
+				return len(claimed), err
+			}
+			continue
+		}
+
+		if err := d.repo.MarkDispatched(ctx, ev.ID); err != nil {
+			return len(claimed), err
+		}
+	}
+
+	return len(claimed), nil
+}
+
+// deliver hands ev to every sink that hasn't accepted it yet and records each
+// success, so a retry goes only to the sinks that failed.
+func (d *EventDispatcher) deliver(ctx context.Context, sinks []events.Sink, ev events.Event) error {
+	var failed []string
+	for _, sink := range sinks {
+		if slices.Contains(ev.DeliveredTo, sink.Name()) {
+			continue
+		}
+		if err := sink.Deliver(ctx, ev); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", sink.Name(), err))
+			continue
+		}
+		if err := d.repo.MarkDelivered(ctx, ev.ID, sink.Name()); err != nil {
+			return err
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
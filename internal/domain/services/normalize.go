@@ -0,0 +1,77 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"strings"
+	"unicode"
+)
+
+// maxSkillLength bounds a single skill tag.
+const maxSkillLength = 64
+
+// normalizeSkills lower-cases, trims and deduplicates skill tags, keeping their
+// order.
+func normalizeSkills(skills []string) ([]string, error) {
+	out := make([]string, 0, len(skills))
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		switch {
+		case skill == "":
+			return nil, fmt.Errorf("%w: skills can't be empty strings", errors2.ErrBadRequest)
+		case len(skill) > maxSkillLength:
+			return nil, fmt.Errorf("%w: skill is longer than %d bytes: %s", errors2.ErrBadRequest, maxSkillLength, skill)
+		case strings.ContainsFunc(skill, unicode.IsSpace):
+			return nil, fmt.Errorf("%w: skill can't contain spaces: %s", errors2.ErrBadRequest, skill)
+		}
+		if !seen[skill] {
+			seen[skill] = true
+			out = append(out, skill)
+		}
+	}
+	return out, nil
+}
+
+// normalizeFallbackTeams trims the fallback teams of teamName and rejects
+// empty, repeated and self references.
+func normalizeFallbackTeams(teamName string, fallbacks []string) ([]string, error) {
+	out := make([]string, 0, len(fallbacks))
+	seen := make(map[string]struct{})
+	for _, name := range fallbacks {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: fallback_teams can't contain empty values", errors2.ErrBadRequest)
+		}
+		if name == teamName {
+			return nil, fmt.Errorf("%w: team can't be its own fallback", errors2.ErrBadRequest)
+		}
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate fallback team: %s", errors2.ErrBadRequest, name)
+		}
+		seen[name] = struct{}{}
+		out = append(out, name)
+	}
+
+	return out, nil
+}
+
+// uniqueIDs trims the user IDs and drops empty and repeated ones.
+func uniqueIDs(ids []string) []string {
+	out := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// IsHTTPURL reports whether raw is an absolute http or https URL.
+func IsHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/dto"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+// Page sizes of /pullRequest/list.
+const (
+	DefaultPRListLimit = 50
+	MaxPRListLimit     = 200
+)
+
+// List returns a page of pull requests. The request's cursor must come from a
+// previous page with the same sort; it is opaque to clients.
+func (s *prService) List(ctx context.Context, req dto.PRListRequest) (*dto.PRListResponse, error) {
+	filter := req.PRListFilter
+	if filter.SortBy == "" {
+		filter.SortBy = dto.PRSortCreatedAt
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultPRListLimit
+	}
+	filter.Limit = min(filter.Limit, MaxPRListLimit)
+
+	if req.Cursor != "" {
+		after, err := decodePRCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if after.SortBy != filter.SortBy || after.Desc != filter.Desc {
+			return nil, fmt.Errorf("%w: cursor belongs to another sort order", errors2.ErrBadRequest)
+		}
+		filter.After = after
+	}
+
+	// One extra row tells whether there is a next page.
+	limit := filter.Limit
+	filter.Limit++
+	prs, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.PRListResponse{PullRequests: prs}
+	if len(prs) > limit {
+		resp.PullRequests = prs[:limit]
+		last := resp.PullRequests[limit-1]
+		cursor := dto.PRListCursor{SortBy: filter.SortBy, Desc: filter.Desc, Time: last.CreatedAt, ID: last.ID}
+		if filter.SortBy == dto.PRSortUpdatedAt {
+			cursor.Time = last.UpdatedAt
+		}
+		resp.NextCursor = encodePRCursor(cursor)
+	}
+
+	return resp, nil
+}
+
+func encodePRCursor(c dto.PRListCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePRCursor(s string) (*dto.PRListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", errors2.ErrBadRequest)
+	}
+
+	var c dto.PRListCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" || c.Time.IsZero() {
+		return nil, fmt.Errorf("%w: malformed cursor", errors2.ErrBadRequest)
+	}
+	return &c, nil
+}
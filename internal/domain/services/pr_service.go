@@ -2,14 +2,26 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"pr-reviwer-assigner/internal/domain/dto"
 	"pr-reviwer-assigner/internal/domain/repository"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"strings"
 )
 
 type PRService interface {
 	Create(ctx context.Context, req dto.PRRequest) (dto.PR, error)
+	Get(ctx context.Context, prID string) (*dto.PR, error)
+	List(ctx context.Context, req dto.PRListRequest) (*dto.PRListResponse, error)
 	Merge(ctx context.Context, req dto.MergeRequest) (*dto.PR, error)
+	RecordMerge(ctx context.Context, prID string) (*dto.PR, error)
 	Reassign(ctx context.Context, req dto.ReassignRequest) (*dto.PR, string, error)
+	AddReviewer(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error)
+	RemoveReviewer(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error)
+	Close(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error)
+	Reopen(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error)
+	MarkReady(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error)
+	SubmitReview(ctx context.Context, req dto.ReviewRequest) (*dto.PR, error)
 }
 
 type prService struct {
@@ -23,28 +35,89 @@ func NewPRService(repo repository.PRRepository) PRService {
 }
 
 func (s *prService) Create(ctx context.Context, req dto.PRRequest) (dto.PR, error) {
-	users, err := s.repo.Create(ctx, req)
+	req, err := normalizePRRequest(req)
 	if err != nil {
 		return dto.PR{}, err
 	}
 
-	var pr dto.PR
-	pr.ID = req.ID
-	pr.Name = req.Name
-	pr.AuthorID = req.AuthorID
-	pr.Status = "OPEN"
-	pr.Reviewers = make([]string, 0)
-	for _, user := range users {
-		pr.Reviewers = append(pr.Reviewers, user)
+	pr, err := s.repo.Create(ctx, req)
+	if err != nil {
+		return dto.PR{}, err
 	}
 
-	return pr, nil
+	return *pr, nil
+}
+
+func (s *prService) Get(ctx context.Context, prID string) (*dto.PR, error) {
+	return s.repo.Get(ctx, prID)
 }
 
 func (s *prService) Merge(ctx context.Context, req dto.MergeRequest) (*dto.PR, error) {
 	return s.repo.Merge(ctx, req)
 }
 
+func (s *prService) RecordMerge(ctx context.Context, prID string) (*dto.PR, error) {
+	return s.repo.RecordMerge(ctx, prID)
+}
+
 func (s *prService) Reassign(ctx context.Context, req dto.ReassignRequest) (*dto.PR, string, error) {
 	return s.repo.Reassign(ctx, req)
 }
+
+func (s *prService) AddReviewer(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error) {
+	return s.repo.AddReviewer(ctx, req)
+}
+
+func (s *prService) RemoveReviewer(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error) {
+	return s.repo.RemoveReviewer(ctx, req)
+}
+
+func (s *prService) Close(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error) {
+	return s.repo.Close(ctx, req.PullRequestID)
+}
+
+func (s *prService) Reopen(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error) {
+	return s.repo.Reopen(ctx, req.PullRequestID)
+}
+
+func (s *prService) MarkReady(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error) {
+	return s.repo.MarkReady(ctx, req.PullRequestID)
+}
+
+func (s *prService) SubmitReview(ctx context.Context, req dto.ReviewRequest) (*dto.PR, error) {
+	return s.repo.SubmitReview(ctx, req)
+}
+
+// normalizePRRequest trims the request, drops empty and repeated list values
+// and rejects what the repository can't store.
+func normalizePRRequest(req dto.PRRequest) (dto.PRRequest, error) {
+	req.ID = strings.TrimSpace(req.ID)
+	req.Name = strings.TrimSpace(req.Name)
+	req.AuthorID = strings.TrimSpace(req.AuthorID)
+	if req.ID == "" || req.Name == "" || req.AuthorID == "" {
+		return req, fmt.Errorf("%w: pull_request_id, pull_request_name and author_id are required", errors2.ErrBadRequest)
+	}
+
+	if req.ReviewersCount != nil && *req.ReviewersCount < 0 {
+		return req, fmt.Errorf("%w: reviewers_count can't be negative", errors2.ErrInvalidReviewersCount)
+	}
+
+	files := make([]string, 0, len(req.ChangedFiles))
+	for _, f := range req.ChangedFiles {
+		if f = strings.TrimSpace(f); f != "" {
+			files = append(files, f)
+		}
+	}
+	req.ChangedFiles = files
+
+	skills, err := normalizeSkills(req.RequiredSkills)
+	if err != nil {
+		return req, err
+	}
+	req.RequiredSkills = skills
+
+	req.RequiredReviewers = uniqueIDs(req.RequiredReviewers)
+	req.ExcludedReviewers = uniqueIDs(req.ExcludedReviewers)
+
+	return req, nil
+}
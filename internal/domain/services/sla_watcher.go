@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+	"pr-reviwer-assigner/internal/domain/selector"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SLA watcher defaults, used when the config leaves them at zero.
+const (
+	DefaultSLAPollInterval = 5 * time.Minute
+	DefaultSLABatchSize    = 100
+)
+
+// SLAWatcher flags reviewers that gave no verdict within the review SLA of the
+// author's team, counted in the reviewer's working hours. Every breach is
+// escalated with a review.sla_breached event and, when the team asks for it,
+// the review is handed over the same way Reassign does.
+type SLAWatcher struct {
+	repo     repository.ReviewSLARepository
+	prRepo   repository.PRRepository
+	interval time.Duration
+	batch    int
+	logger   *zap.SugaredLogger
+
+	// after is where the next CheckOnce continues; Run is its only caller.
+	after dto.PendingReview
+}
+
+func NewSLAWatcher(repo repository.ReviewSLARepository, prRepo repository.PRRepository, interval time.Duration, batch int, logger *zap.SugaredLogger) *SLAWatcher {
+	if interval <= 0 {
+		interval = DefaultSLAPollInterval
+	}
+	if batch <= 0 {
+		batch = DefaultSLABatchSize
+	}
+
+	return &SLAWatcher{
+		repo:     repo,
+		prRepo:   prRepo,
+		interval: interval,
+		batch:    batch,
+		logger:   logger,
+	}
+}
+
+// Run watches until ctx is cancelled.
+func (w *SLAWatcher) Run(ctx context.Context) {
+	poll(ctx, w.interval, w.batch, w.CheckOnce, func(err error) {
+		w.logger.Error("check review SLA: ", err)
+	})
+}
+
+// CheckOnce checks the next batch of pending reviews and returns how many it
+// looked at. Reviews still inside the SLA in working hours are skipped until a
+// later poll. When marking a breach fails, the next call starts again from that
+// review.
+//
+// A hand-over is tried once, right after the breach is marked, and a failed one
+// is only logged: a breached review is never listed again, so the reviewer
+// keeps it until someone reassigns it by hand after the escalation.
+func (w *SLAWatcher) CheckOnce(ctx context.Context) (int, error) {
+	pending, err := w.repo.ListPending(ctx, w.after, w.batch)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	for _, p := range pending {
+		if !breached(p, now) {
+			w.after = p
+			continue
+		}
+
+		marked, err := w.repo.MarkBreached(ctx, p)
+		if err != nil {
+			return 0, err
+		}
+		w.after = p
+		if !marked {
+			continue
+		}
+		w.logger.Info("review SLA breached: ", p.PullRequestID, " reviewer ", p.ReviewerID)
+
+		// Required reviewers can't be replaced; the escalation is all there is.
+		if !p.AutoReassign || p.Required {
+			continue
+		}
+		_, newID, err := w.prRepo.Reassign(ctx, dto.ReassignRequest{
+			PullRequestID: p.PullRequestID,
+			OldUserID:     p.ReviewerID,
+		})
+		if err != nil {
+			w.logger.Error("reassign overdue review of ", p.ReviewerID, " on ", p.PullRequestID, ", not retried: ", err)
+			continue
+		}
+		w.logger.Info("overdue review of ", p.ReviewerID, " on ", p.PullRequestID, " handed over to ", newID)
+	}
+	if len(pending) < w.batch {
+		w.after = dto.PendingReview{}
+	}
+
+	return len(pending), nil
+}
+
+// breached reports whether the reviewer spent the team's SLA in working hours
+// since the assignment. Reviewers without working hours, or with ones that
+// can't be parsed, count as always working, as they do for reviewer selection.
+func breached(p dto.PendingReview, now time.Time) bool {
+	sla := time.Duration(p.SLAHours) * time.Hour
+	if p.WorkingHours == nil {
+		return now.Sub(p.AssignedAt) >= sla
+	}
+
+	hours, err := selector.NewWorkingHours(p.WorkingHours.TimeZone, p.WorkingHours.Start, p.WorkingHours.End, p.WorkingHours.Days)
+	if err != nil {
+		return now.Sub(p.AssignedAt) >= sla
+	}
+	return hours.Between(p.AssignedAt, now) >= sla
+}
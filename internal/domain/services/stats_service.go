@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+)
+
+type StatsService interface {
+	Pairings(ctx context.Context, teamName string) (*dto.PairingStats, error)
+}
+
+type statsService struct {
+	repo repository.StatsRepository
+}
+
+func NewStatsService(repo repository.StatsRepository) StatsService {
+	return &statsService{
+		repo: repo,
+	}
+}
+
+func (s *statsService) Pairings(ctx context.Context, teamName string) (*dto.PairingStats, error) {
+	return s.repo.Pairings(ctx, teamName)
+}
@@ -2,14 +2,29 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"pr-reviwer-assigner/internal/domain/dto"
 	"pr-reviwer-assigner/internal/domain/repository"
+	"pr-reviwer-assigner/internal/domain/selector"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"strings"
+)
+
+// Reviewer count settings for teams created without explicit values.
+const (
+	DefaultReviewersCount = 2
+	DefaultMinReviewers   = 1
+	DefaultMaxReviewers   = 5
 )
 
 type TeamService interface {
-	Add(ctx context.Context, team dto.Team) error
+	// Add creates the team with its members. Unset reviewer settings get the
+	// defaults; the created team is returned with them.
+	Add(ctx context.Context, team dto.Team) (*dto.Team, error)
 	Get(teamName string) ([]dto.TeamMember, error)
 	DeactivateMembers(ctx context.Context, req dto.TeamDeactivateRequest) (*dto.TeamDeactivateResponse, error)
+	GetSettings(ctx context.Context, teamName string) (*dto.TeamSettings, error)
+	UpdateSettings(ctx context.Context, req dto.TeamSettingsRequest) (*dto.TeamSettings, error)
 }
 
 type teamService struct {
@@ -24,38 +39,222 @@ func NewTeamService(repo repository.TeamRepository, prRepo repository.PRReposito
 	}
 }
 
-func (s *teamService) Add(ctx context.Context, team dto.Team) error {
-	return s.repo.Add(ctx, team)
+func (s *teamService) Add(ctx context.Context, team dto.Team) (*dto.Team, error) {
+	team, err := normalizeTeam(team)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Add(ctx, team); err != nil {
+		return nil, err
+	}
+
+	return &team, nil
 }
 
 func (s *teamService) Get(teamName string) ([]dto.TeamMember, error) {
 	return s.repo.Get(teamName)
 }
 
+// DeactivateMembers deactivates the users first, so that they are no longer
+// active required reviewers, and then hands their open reviews over. Reviews
+// that can't be handed over stay assigned and are listed in the response.
 func (s *teamService) DeactivateMembers(ctx context.Context, req dto.TeamDeactivateRequest) (*dto.TeamDeactivateResponse, error) {
+	if err := s.repo.DeactivateMembers(ctx, req.TeamName, req.UserIDs); err != nil {
+		return nil, err
+	}
+
+	resp := &dto.TeamDeactivateResponse{
+		TeamName:    req.TeamName,
+		Deactivated: req.UserIDs,
+	}
 	for _, userID := range req.UserIDs {
-		prIDs, err := s.prRepo.ListOpenAssignments(ctx, userID)
+		failed, err := reassignOpenReviews(ctx, s.prRepo, userID)
 		if err != nil {
 			return nil, err
 		}
-
-		for _, prID := range prIDs {
-			_, _, err := s.prRepo.Reassign(ctx, dto.ReassignRequest{
-				PullRequestID: prID,
-				OldUserID:     userID,
+		for _, f := range failed {
+			resp.NotReassigned = append(resp.NotReassigned, dto.ReassignFailure{
+				PullRequestID: f.prID,
+				UserID:        userID,
+				Reason:        f.err.Error(),
 			})
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *teamService) GetSettings(ctx context.Context, teamName string) (*dto.TeamSettings, error) {
+	return s.repo.GetSettings(ctx, teamName)
+}
+
+func (s *teamService) UpdateSettings(ctx context.Context, req dto.TeamSettingsRequest) (*dto.TeamSettings, error) {
+	req, err := normalizeTeamSettings(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdateSettings(ctx, req)
+}
+
+// reassignFailure is an open review that could not be handed over.
+type reassignFailure struct {
+	prID string
+	err  error
+}
+
+func (f reassignFailure) String() string {
+	if f.prID == "" {
+		return f.err.Error()
+	}
+	return fmt.Sprintf("%s: %v", f.prID, f.err)
+}
+
+// reassignOpenReviews hands every open review of the user over to the
+// selection strategy of the reviewer's team. A PR that can't be handed over
+// doesn't stop the rest; it is returned among the failures. The error is set
+// only when the reviews couldn't be listed.
+func reassignOpenReviews(ctx context.Context, prRepo repository.PRRepository, userID string) ([]reassignFailure, error) {
+	prIDs, err := prRepo.ListOpenAssignments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var failed []reassignFailure
+	for _, prID := range prIDs {
+		_, _, err := prRepo.Reassign(ctx, dto.ReassignRequest{
+			PullRequestID: prID,
+			OldUserID:     userID,
+		})
+		if err != nil {
+			failed = append(failed, reassignFailure{prID: prID, err: err})
+		}
+	}
+
+	return failed, nil
+}
+
+// normalizeTeam trims the team, fills in the default reviewer settings and
+// rejects what the repository can't store.
+func normalizeTeam(team dto.Team) (dto.Team, error) {
+	team.Name = strings.TrimSpace(team.Name)
+	if team.Name == "" {
+		return team, fmt.Errorf("%w: team_name can't be empty", errors2.ErrBadRequest)
+	}
+
+	team.ReviewerStrategy = strings.TrimSpace(team.ReviewerStrategy)
+	if team.ReviewerStrategy == "" {
+		team.ReviewerStrategy = selector.DefaultStrategy
+	}
+	if !selector.IsKnown(team.ReviewerStrategy) {
+		return team, fmt.Errorf("%w: unknown reviewer_strategy: %s", errors2.ErrBadRequest, team.ReviewerStrategy)
+	}
+
+	if team.ReviewersCount == 0 {
+		team.ReviewersCount = DefaultReviewersCount
+	}
+	if team.MinReviewers == 0 {
+		team.MinReviewers = min(DefaultMinReviewers, team.ReviewersCount)
+	}
+	if team.MaxReviewers == 0 {
+		team.MaxReviewers = max(DefaultMaxReviewers, team.ReviewersCount)
+	}
+	if team.MinReviewers < 0 || team.MinReviewers > team.ReviewersCount || team.ReviewersCount > team.MaxReviewers {
+		return team, fmt.Errorf("%w: expected min_reviewers <= reviewers_count <= max_reviewers", errors2.ErrInvalidReviewersCount)
+	}
+
+	if team.MinApprovals < 0 {
+		return team, fmt.Errorf("%w: min_approvals can't be negative", errors2.ErrBadRequest)
+	}
+
+	fallbacks, err := normalizeFallbackTeams(team.Name, team.FallbackTeams)
+	if err != nil {
+		return team, err
+	}
+	team.FallbackTeams = fallbacks
+
+	if len(team.Members) == 0 {
+		return team, fmt.Errorf("%w: members can't be empty", errors2.ErrBadRequest)
+	}
+
+	members := make([]dto.TeamMember, len(team.Members))
+	seen := make(map[string]struct{}, len(team.Members))
+	for i, m := range team.Members {
+		m.ID = strings.TrimSpace(m.ID)
+		m.Name = strings.TrimSpace(m.Name)
+		if m.ID == "" || m.Name == "" {
+			return team, fmt.Errorf("%w: member[%d]: user_id and username can't be empty", errors2.ErrBadRequest, i)
+		}
+		if _, ok := seen[m.ID]; ok {
+			return team, fmt.Errorf("%w: duplicate user_id in members: %s", errors2.ErrBadRequest, m.ID)
+		}
+		seen[m.ID] = struct{}{}
+
+		if m.Skills != nil {
+			skills, err := normalizeSkills(m.Skills)
 			if err != nil {
-				return nil, err
+				return team, fmt.Errorf("member[%d]: %w", i, err)
 			}
+			m.Skills = skills
 		}
+
+		members[i] = m
 	}
+	team.Members = members
 
-	if err := s.repo.DeactivateMembers(ctx, req.TeamName, req.UserIDs); err != nil {
-		return nil, err
+	return team, nil
+}
+
+// normalizeTeamSettings trims the settings update and rejects values the
+// repository can't store. Bounds between the reviewer counts are checked
+// against the stored settings by the repository.
+func normalizeTeamSettings(req dto.TeamSettingsRequest) (dto.TeamSettingsRequest, error) {
+	req.TeamName = strings.TrimSpace(req.TeamName)
+	if req.TeamName == "" {
+		return req, fmt.Errorf("%w: team_name can't be empty", errors2.ErrBadRequest)
 	}
 
-	return &dto.TeamDeactivateResponse{
-		TeamName:    req.TeamName,
-		Deactivated: req.UserIDs,
-	}, nil
+	if req.ReviewerStrategy != nil {
+		strategy := strings.TrimSpace(*req.ReviewerStrategy)
+		if !selector.IsKnown(strategy) {
+			return req, fmt.Errorf("%w: unknown reviewer_strategy: %s", errors2.ErrBadRequest, strategy)
+		}
+		req.ReviewerStrategy = &strategy
+	}
+
+	for _, v := range []*int{req.ReviewersCount, req.MinReviewers, req.MaxReviewers} {
+		if v != nil && *v < 0 {
+			return req, fmt.Errorf("%w: reviewers counts can't be negative", errors2.ErrInvalidReviewersCount)
+		}
+	}
+
+	switch {
+	case req.MinApprovals != nil && *req.MinApprovals < 0:
+		return req, fmt.Errorf("%w: min_approvals can't be negative", errors2.ErrBadRequest)
+	case req.WorkingHoursLeadMinutes != nil && *req.WorkingHoursLeadMinutes < 0:
+		return req, fmt.Errorf("%w: working_hours_lead_minutes can't be negative", errors2.ErrBadRequest)
+	case req.PairingWindow != nil && *req.PairingWindow <= 0:
+		return req, fmt.Errorf("%w: pairing_window must be positive", errors2.ErrBadRequest)
+	case req.ReviewSLAHours != nil && *req.ReviewSLAHours < 0:
+		return req, fmt.Errorf("%w: review_sla_hours can't be negative", errors2.ErrBadRequest)
+	}
+
+	if req.ChatWebhookURL != nil {
+		chatURL := strings.TrimSpace(*req.ChatWebhookURL)
+		if chatURL != "" && !IsHTTPURL(chatURL) {
+			return req, fmt.Errorf("%w: chat_webhook_url must be an absolute http(s) URL", errors2.ErrBadRequest)
+		}
+		req.ChatWebhookURL = &chatURL
+	}
+
+	if req.FallbackTeams != nil {
+		fallbacks, err := normalizeFallbackTeams(req.TeamName, *req.FallbackTeams)
+		if err != nil {
+			return req, err
+		}
+		req.FallbackTeams = &fallbacks
+	}
+
+	return req, nil
 }
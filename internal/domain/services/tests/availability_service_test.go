@@ -0,0 +1,97 @@
+package services_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+type importRepoMock struct {
+	availabilityRepoMock
+	users    map[string]string
+	upserted []dto.Unavailability
+	deleted  []string
+}
+
+func (m *importRepoMock) UpsertExternal(ctx context.Context, window dto.Unavailability) error {
+	m.upserted = append(m.upserted, window)
+	return nil
+}
+
+func (m *importRepoMock) DeleteExternal(ctx context.Context, userID, externalID string) (bool, error) {
+	m.deleted = append(m.deleted, userID+"/"+externalID)
+	return true, nil
+}
+
+func (m *importRepoMock) UserIDsByEmail(ctx context.Context, emails []string) (map[string]string, error) {
+	return m.users, nil
+}
+
+func TestAvailabilityServiceImportICS_MatchesByEmail(t *testing.T) {
+	f, err := os.Open("testdata/vacations.ics")
+	require.NoError(t, err)
+	defer f.Close()
+
+	repo := &importRepoMock{users: map[string]string{"bob@example.com": "u2"}}
+	svc := services.NewAvailabilityService(repo, "")
+
+	resp, err := svc.ImportICS(context.Background(), "", f)
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.Imported)
+	require.Equal(t, 1, resp.Removed)
+
+	require.Equal(t, []dto.Unavailability{{
+		UserID:     "u2",
+		StartsAt:   time.Date(2099, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:     time.Date(2099, 7, 15, 0, 0, 0, 0, time.UTC),
+		Reason:     "Vacation",
+		ExternalID: "vac-1@hr.acme",
+	}}, repo.upserted)
+	require.Equal(t, []string{"u2/vac-2@hr.acme"}, repo.deleted)
+
+	require.Equal(t, []dto.ICSSkippedEvent{
+		{UID: "vac-3@hr.acme", Reason: "no user with the organizer or attendee email"},
+		{UID: "vac-4@hr.acme", Reason: "already ended"},
+		{UID: "mtg-5@hr.acme", Reason: "not an out-of-office event"},
+	}, resp.Skipped)
+}
+
+func TestAvailabilityServiceImportICS_InvalidCalendar(t *testing.T) {
+	svc := services.NewAvailabilityService(&importRepoMock{}, "")
+
+	_, err := svc.ImportICS(context.Background(), "u1", strings.NewReader("not a calendar"))
+	require.ErrorIs(t, err, errors2.ErrInvalidCalendar)
+}
+
+func TestAvailabilityServiceImportICSFile_StaysInsideDir(t *testing.T) {
+	dir := t.TempDir()
+	src, err := os.ReadFile("testdata/vacations.ics")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bob.ics"), src, 0o600))
+
+	repo := &importRepoMock{}
+	svc := services.NewAvailabilityService(repo, dir)
+
+	resp, err := svc.ImportICSFile(context.Background(), dto.ICSImportRequest{UserID: "u2", Path: "bob.ics"})
+	require.NoError(t, err)
+	// With an explicit user every out-of-office event belongs to them.
+	require.Equal(t, 2, resp.Imported)
+
+	_, err = svc.ImportICSFile(context.Background(), dto.ICSImportRequest{UserID: "u2", Path: "../bob.ics"})
+	require.ErrorIs(t, err, errors2.ErrBadRequest)
+
+	_, err = svc.ImportICSFile(context.Background(), dto.ICSImportRequest{UserID: "u2", Path: "missing.ics"})
+	require.ErrorIs(t, err, errors2.ErrNotFound)
+
+	_, err = services.NewAvailabilityService(repo, "").ImportICSFile(context.Background(), dto.ICSImportRequest{Path: "bob.ics"})
+	require.ErrorIs(t, err, errors2.ErrBadRequest)
+}
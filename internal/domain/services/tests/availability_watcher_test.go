@@ -0,0 +1,149 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+type availabilityRepoMock struct {
+	repository.AvailabilityRepository
+	started    []dto.Unavailability
+	reassigned []int64
+	failures   map[int64]string
+	retries    map[int64]time.Time
+	dead       []int64
+}
+
+func (m *availabilityRepoMock) ListStarted(ctx context.Context, limit int) ([]dto.Unavailability, error) {
+	return m.started, nil
+}
+
+func (m *availabilityRepoMock) MarkFailed(ctx context.Context, id int64, reason string, next time.Time, dead bool) error {
+	if m.failures == nil {
+		m.failures = make(map[int64]string)
+	}
+	if m.retries == nil {
+		m.retries = make(map[int64]time.Time)
+	}
+	m.failures[id] = reason
+	m.retries[id] = next
+	if dead {
+		m.dead = append(m.dead, id)
+	}
+	return nil
+}
+
+func (m *availabilityRepoMock) MarkReassigned(ctx context.Context, id int64, failure string) error {
+	m.reassigned = append(m.reassigned, id)
+	if failure != "" {
+		if m.failures == nil {
+			m.failures = make(map[int64]string)
+		}
+		m.failures[id] = failure
+	}
+	return nil
+}
+
+// reassignMock implements the PRRepository calls used to hand reviews over.
+type reassignMock struct {
+	repository.PRRepository
+	open    map[string][]string
+	failFor string
+	failPR  string
+	// requiredOn is a PR the old reviewer is an active required reviewer of.
+	requiredOn string
+	reassigns  []dto.ReassignRequest
+}
+
+func (m *reassignMock) ListOpenAssignments(ctx context.Context, reviewerID string) ([]string, error) {
+	return m.open[reviewerID], nil
+}
+
+func (m *reassignMock) Reassign(ctx context.Context, req dto.ReassignRequest) (*dto.PR, string, error) {
+	if req.PullRequestID == m.requiredOn {
+		return nil, "", errors2.ErrInvalidReviewers
+	}
+	if req.OldUserID == m.failFor || req.PullRequestID == m.failPR {
+		return nil, "", errors2.ErrNoCandidate
+	}
+	m.reassigns = append(m.reassigns, req)
+	return &dto.PR{ID: req.PullRequestID}, "", nil
+}
+
+func TestAvailabilityWatcher_ReassignsStartedWindows(t *testing.T) {
+	repo := &availabilityRepoMock{started: []dto.Unavailability{
+		{ID: 1, UserID: "u1"},
+		{ID: 2, UserID: "u2"},
+	}}
+	prs := &reassignMock{
+		open:    map[string][]string{"u1": {"pr-1", "pr-2"}, "u2": {"pr-3"}},
+		failFor: "u2",
+	}
+
+	w := services.NewAvailabilityWatcher(repo, prs, time.Minute, 10, zap.NewNop().Sugar())
+	n, err := w.ReassignOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	require.Equal(t, []dto.ReassignRequest{
+		{PullRequestID: "pr-1", OldUserID: "u1"},
+		{PullRequestID: "pr-2", OldUserID: "u1"},
+	}, prs.reassigns)
+	require.Equal(t, []int64{1}, repo.reassigned)
+	// u2 had no candidate: the window is held back and retried later rather
+	// than on every poll.
+	require.Contains(t, repo.failures[2], "pr-3")
+	require.True(t, repo.retries[2].After(time.Now()))
+	require.Empty(t, repo.dead)
+}
+
+func TestAvailabilityWatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	repo := &availabilityRepoMock{started: []dto.Unavailability{
+		{ID: 1, UserID: "u1", ReassignAttempts: services.AvailabilityMaxAttempts - 1},
+	}}
+	prs := &reassignMock{open: map[string][]string{"u1": {"pr-1"}}, failFor: "u1"}
+
+	w := services.NewAvailabilityWatcher(repo, prs, time.Minute, 10, zap.NewNop().Sugar())
+	_, err := w.ReassignOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, repo.dead)
+}
+
+func TestAvailabilityWatcher_RequiredReviewerDoesNotHoldWindow(t *testing.T) {
+	repo := &availabilityRepoMock{started: []dto.Unavailability{{ID: 1, UserID: "sec"}}}
+	prs := &reassignMock{open: map[string][]string{"sec": {"pr-1"}}, requiredOn: "pr-1"}
+
+	w := services.NewAvailabilityWatcher(repo, prs, time.Minute, 10, zap.NewNop().Sugar())
+	_, err := w.ReassignOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, repo.reassigned)
+	require.Contains(t, repo.failures[1], "pr-1")
+	require.Empty(t, repo.retries)
+}
+
+func TestAvailabilityWatcher_FailedPRDoesNotStopTheRest(t *testing.T) {
+	repo := &availabilityRepoMock{started: []dto.Unavailability{{ID: 1, UserID: "u1"}}}
+	prs := &reassignMock{
+		open:   map[string][]string{"u1": {"pr-1", "pr-2", "pr-3"}},
+		failPR: "pr-2",
+	}
+
+	w := services.NewAvailabilityWatcher(repo, prs, time.Minute, 10, zap.NewNop().Sugar())
+	_, err := w.ReassignOnce(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []dto.ReassignRequest{
+		{PullRequestID: "pr-1", OldUserID: "u1"},
+		{PullRequestID: "pr-3", OldUserID: "u1"},
+	}, prs.reassigns)
+	require.Equal(t, "pr-2: "+errors2.ErrNoCandidate.Error(), repo.failures[1])
+}
@@ -0,0 +1,178 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/repository"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+type notificationRepoMock struct {
+	notices map[string]dto.AssignmentNotice
+	teams   []dto.ChatTeam
+	users   []dto.Recipient
+	emailed map[int64][]string
+}
+
+func (m *notificationRepoMock) AssignmentNotice(ctx context.Context, prID, reviewerID string) (*dto.AssignmentNotice, error) {
+	n, ok := m.notices[prID+"/"+reviewerID]
+	if !ok {
+		return nil, errors2.ErrNotFound
+	}
+	return &n, nil
+}
+
+func (m *notificationRepoMock) ChatTeams(ctx context.Context) ([]dto.ChatTeam, error) {
+	return m.teams, nil
+}
+
+func (m *notificationRepoMock) Recipients(ctx context.Context, userIDs []string) ([]dto.Recipient, error) {
+	return m.users, nil
+}
+
+func (m *notificationRepoMock) Emailed(ctx context.Context, eventID int64) ([]string, error) {
+	return m.emailed[eventID], nil
+}
+
+func (m *notificationRepoMock) MarkEmailed(ctx context.Context, eventID int64, userID string) error {
+	if m.emailed == nil {
+		m.emailed = make(map[int64][]string)
+	}
+	m.emailed[eventID] = append(m.emailed[eventID], userID)
+	return nil
+}
+
+// openAssignmentsMock implements only ListOpenAssignments of PRRepository.
+type openAssignmentsMock struct {
+	repository.PRRepository
+	open map[string][]string
+}
+
+func (m *openAssignmentsMock) ListOpenAssignments(ctx context.Context, reviewerID string) ([]string, error) {
+	return m.open[reviewerID], nil
+}
+
+// chatStandIn records the messages posted to it and answers with status.
+func chatStandIn(t *testing.T, status int) (*httptest.Server, *[]services.ChatMessage) {
+	var received []services.ChatMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg services.ChatMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		received = append(received, msg)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &received
+}
+
+func assignedEvent(t *testing.T, prID, reviewerID string) events.Event {
+	payload, err := json.Marshal(events.ReviewerAssignedPayload{PullRequestID: prID, ReviewerID: reviewerID})
+	require.NoError(t, err)
+	return events.Event{ID: 1, Type: events.ReviewerAssigned, AggregateID: prID, Payload: payload}
+}
+
+func TestChatSink_MentionsReviewer(t *testing.T) {
+	srv, received := chatStandIn(t, http.StatusOK)
+	repo := &notificationRepoMock{notices: map[string]dto.AssignmentNotice{
+		"pr-1/u2": {
+			PullRequestID:   "pr-1",
+			PullRequestName: "Add search",
+			AuthorName:      "Alice",
+			TeamName:        "backend",
+			ChatWebhookURL:  srv.URL,
+			Reviewer:        dto.Recipient{UserID: "u2", Username: "Bob", ChatHandle: "U024BE7LH"},
+		},
+	}}
+
+	sink := services.NewChatSink(repo, services.NewChatPoster(time.Second), zap.NewNop().Sugar())
+	require.NoError(t, sink.Deliver(context.Background(), assignedEvent(t, "pr-1", "u2")))
+
+	require.Len(t, *received, 1)
+	require.Equal(t, "<@U024BE7LH> you were assigned to review *Add search* (`pr-1`) by Alice", (*received)[0].Text)
+}
+
+func TestChatSink_SkipsTeamsWithoutWebhook(t *testing.T) {
+	repo := &notificationRepoMock{notices: map[string]dto.AssignmentNotice{
+		"pr-1/u2": {PullRequestID: "pr-1", Reviewer: dto.Recipient{UserID: "u2"}},
+	}}
+
+	sink := services.NewChatSink(repo, services.NewChatPoster(time.Second), zap.NewNop().Sugar())
+	require.NoError(t, sink.Deliver(context.Background(), assignedEvent(t, "pr-1", "u2")))
+}
+
+func TestChatSink_ServerErrorIsRetried(t *testing.T) {
+	srv, _ := chatStandIn(t, http.StatusBadGateway)
+	rejected, _ := chatStandIn(t, http.StatusNotFound)
+	repo := &notificationRepoMock{notices: map[string]dto.AssignmentNotice{
+		"pr-1/u2": {PullRequestID: "pr-1", ChatWebhookURL: srv.URL, Reviewer: dto.Recipient{UserID: "u2"}},
+		"pr-2/u2": {PullRequestID: "pr-2", ChatWebhookURL: rejected.URL, Reviewer: dto.Recipient{UserID: "u2"}},
+	}}
+
+	sink := services.NewChatSink(repo, services.NewChatPoster(time.Second), zap.NewNop().Sugar())
+	require.Error(t, sink.Deliver(context.Background(), assignedEvent(t, "pr-1", "u2")))
+	require.NoError(t, sink.Deliver(context.Background(), assignedEvent(t, "pr-2", "u2")))
+}
+
+func TestChatSink_EscalatesBreachedSLA(t *testing.T) {
+	srv, received := chatStandIn(t, http.StatusOK)
+	repo := &notificationRepoMock{notices: map[string]dto.AssignmentNotice{
+		"pr-1/u2": {
+			PullRequestID:   "pr-1",
+			PullRequestName: "Add search",
+			AuthorName:      "Alice",
+			ChatWebhookURL:  srv.URL,
+			Reviewer:        dto.Recipient{UserID: "u2", Username: "Bob"},
+		},
+	}}
+
+	payload, err := json.Marshal(events.ReviewSLABreachedPayload{PullRequestID: "pr-1", ReviewerID: "u2", SLAHours: 24})
+	require.NoError(t, err)
+
+	sink := services.NewChatSink(repo, services.NewChatPoster(time.Second), zap.NewNop().Sugar())
+	require.NoError(t, sink.Deliver(context.Background(), events.Event{ID: 2, Type: events.ReviewSLABreached, AggregateID: "pr-1", Payload: payload}))
+
+	require.Len(t, *received, 1)
+	require.Equal(t, ":warning: Bob has not reviewed *Add search* (`pr-1`) by Alice within 24 working hours", (*received)[0].Text)
+}
+
+func TestChatDigest_ListsOpenReviews(t *testing.T) {
+	srv, received := chatStandIn(t, http.StatusOK)
+	repo := &notificationRepoMock{teams: []dto.ChatTeam{{
+		TeamName:       "backend",
+		ChatWebhookURL: srv.URL,
+		Members: []dto.Recipient{
+			{UserID: "u1", Username: "Alice", ChatHandle: "UALICE"},
+			{UserID: "u2", Username: "Bob"},
+		},
+	}}}
+	prs := &openAssignmentsMock{open: map[string][]string{"u1": {"pr-1", "pr-2"}}}
+
+	digest := services.NewChatDigest(repo, prs, services.NewChatPoster(time.Second), 9*time.Hour, zap.NewNop().Sugar())
+	sent, err := digest.SendOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	require.Len(t, *received, 1)
+	require.Equal(t, "Open reviews in *backend*:\n• <@UALICE>: 2 (`pr-1`, `pr-2`)\n• Bob: none", (*received)[0].Text)
+}
+
+func TestChatDigest_NextRun(t *testing.T) {
+	digest := services.NewChatDigest(&notificationRepoMock{}, &openAssignmentsMock{}, services.NewChatPoster(time.Second), 9*time.Hour, zap.NewNop().Sugar())
+
+	morning := time.Date(2025, 3, 10, 8, 30, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC), digest.NextRun(morning))
+
+	evening := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC), digest.NextRun(evening))
+}
@@ -0,0 +1,83 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/codeowners"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+type codeownersRepoMock struct {
+	content string
+	stored  bool
+	known   map[string]bool
+}
+
+func (m *codeownersRepoMock) Get(ctx context.Context, teamName string) (string, error) {
+	return m.content, nil
+}
+
+func (m *codeownersRepoMock) Set(ctx context.Context, teamName, content string) error {
+	m.content = content
+	m.stored = true
+	return nil
+}
+
+func (m *codeownersRepoMock) UnknownOwners(ctx context.Context, owners []codeowners.Owner) ([]codeowners.Owner, error) {
+	var unknown []codeowners.Owner
+	for _, o := range owners {
+		if !m.known[o.Name] {
+			unknown = append(unknown, o)
+		}
+	}
+	return unknown, nil
+}
+
+func TestCodeownersServiceUpload_StoresValidFile(t *testing.T) {
+	repo := &codeownersRepoMock{known: map[string]bool{"backend": true, "dbadmin": true}}
+	svc := services.NewCodeownersService(repo)
+
+	file := "# owners\n* @acme/backend\n/migrations/ @dbadmin\n"
+	resp, err := svc.Upload(context.Background(), "backend", strings.NewReader(file))
+	require.NoError(t, err)
+	require.Equal(t, file, repo.content)
+	require.Len(t, resp.Rules, 2)
+	require.Equal(t, 3, resp.Rules[1].Line)
+	require.Equal(t, []string{"@acme/backend"}, resp.Rules[0].Owners)
+}
+
+func TestCodeownersServiceUpload_RejectsUnknownOwners(t *testing.T) {
+	repo := &codeownersRepoMock{known: map[string]bool{"backend": true}}
+	svc := services.NewCodeownersService(repo)
+
+	_, err := svc.Upload(context.Background(), "backend", strings.NewReader("* @acme/backend ghost@example.com @nobody\n"))
+	require.ErrorIs(t, err, errors2.ErrInvalidCodeowners)
+	require.Contains(t, err.Error(), "ghost@example.com, @nobody")
+	require.False(t, repo.stored)
+}
+
+func TestCodeownersServiceUpload_RejectsInvalidSyntax(t *testing.T) {
+	repo := &codeownersRepoMock{}
+	svc := services.NewCodeownersService(repo)
+
+	_, err := svc.Upload(context.Background(), "backend", strings.NewReader("*.go @\n"))
+	require.ErrorIs(t, err, errors2.ErrInvalidCodeowners)
+	require.Contains(t, err.Error(), "line 1")
+	require.False(t, repo.stored)
+}
+
+func TestCodeownersServiceUpload_CommentsOnlyRemovesRules(t *testing.T) {
+	repo := &codeownersRepoMock{content: "* @alice\n"}
+	svc := services.NewCodeownersService(repo)
+
+	resp, err := svc.Upload(context.Background(), "backend", strings.NewReader("# nobody owns anything\n"))
+	require.NoError(t, err)
+	require.Empty(t, repo.content)
+	require.True(t, repo.stored)
+	require.Empty(t, resp.Rules)
+}
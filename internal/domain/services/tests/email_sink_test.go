@@ -0,0 +1,119 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/services"
+	"pr-reviwer-assigner/internal/mail"
+)
+
+type senderMock struct {
+	sent []mail.Message
+	err  error
+	// failTo fails messages to these addresses.
+	failTo map[string]error
+}
+
+func (m *senderMock) Send(ctx context.Context, msg mail.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	if err := m.failTo[msg.To[0]]; err != nil {
+		return err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func newEmailSink(t *testing.T, sender mail.Sender) events.Sink {
+	templates, err := mail.LoadTemplates("")
+	require.NoError(t, err)
+
+	repo := &notificationRepoMock{users: []dto.Recipient{
+		{UserID: "u1", Username: "Alice", Email: "alice@example.com"},
+		{UserID: "u2", Username: "Bob", Email: "bob@example.com"},
+		{UserID: "u3", Username: "Carol"},
+	}}
+	return services.NewEmailSink(repo, sender, templates, zap.NewNop().Sugar())
+}
+
+func event(t *testing.T, eventType string, payload any) events.Event {
+	raw, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return events.Event{ID: 1, Type: eventType, Payload: raw}
+}
+
+func TestEmailSink_Replaced(t *testing.T) {
+	sender := &senderMock{}
+	sink := newEmailSink(t, sender)
+
+	ev := event(t, events.ReviewerReplaced, events.ReviewerReplacedPayload{
+		PullRequestID: "pr-1",
+		Name:          "Add search",
+		AuthorID:      "u1",
+		OldReviewerID: "u2",
+		NewReviewerID: "u3",
+	})
+	require.NoError(t, sink.Deliver(context.Background(), ev))
+
+	require.Len(t, sender.sent, 1)
+	require.Equal(t, []string{"bob@example.com"}, sender.sent[0].To)
+	require.Equal(t, "You were replaced as reviewer: Add search", sender.sent[0].Subject)
+	require.Contains(t, sender.sent[0].Text, "Carol took over")
+}
+
+func TestEmailSink_MergedSkipsUsersWithoutEmail(t *testing.T) {
+	sender := &senderMock{}
+	sink := newEmailSink(t, sender)
+
+	ev := event(t, events.PRMerged, events.PRMergedPayload{
+		PullRequestID: "pr-1",
+		Name:          "Add search",
+		AuthorID:      "u1",
+		Reviewers:     []string{"u2", "u3"},
+	})
+	require.NoError(t, sink.Deliver(context.Background(), ev))
+
+	require.Len(t, sender.sent, 2)
+	require.Equal(t, []string{"alice@example.com"}, sender.sent[0].To)
+	require.Equal(t, []string{"bob@example.com"}, sender.sent[1].To)
+}
+
+func TestEmailSink_PermanentFailureIsDropped(t *testing.T) {
+	sink := newEmailSink(t, &senderMock{err: &textproto.Error{Code: 550, Msg: "mailbox unavailable"}})
+
+	ev := event(t, events.ReviewerAssigned, events.ReviewerAssignedPayload{PullRequestID: "pr-1", AuthorID: "u1", ReviewerID: "u2"})
+	require.NoError(t, sink.Deliver(context.Background(), ev))
+
+	sink = newEmailSink(t, &senderMock{err: &textproto.Error{Code: 421, Msg: "try again later"}})
+	require.Error(t, sink.Deliver(context.Background(), ev))
+}
+
+func TestEmailSink_RetryMailsOnlyRemainingRecipients(t *testing.T) {
+	sender := &senderMock{failTo: map[string]error{
+		"bob@example.com": &textproto.Error{Code: 421, Msg: "try again later"},
+	}}
+	sink := newEmailSink(t, sender)
+
+	ev := event(t, events.PRMerged, events.PRMergedPayload{
+		PullRequestID: "pr-1",
+		Name:          "Add search",
+		AuthorID:      "u1",
+		Reviewers:     []string{"u2"},
+	})
+	require.Error(t, sink.Deliver(context.Background(), ev))
+	require.Len(t, sender.sent, 1)
+
+	sender.failTo = nil
+	require.NoError(t, sink.Deliver(context.Background(), ev))
+	require.Len(t, sender.sent, 2)
+	require.Equal(t, []string{"bob@example.com"}, sender.sent[1].To)
+}
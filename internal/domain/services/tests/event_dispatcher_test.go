@@ -0,0 +1,148 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/services"
+)
+
+type outboxRepoMock struct {
+	pending    []events.Event
+	dispatched []int64
+	delivered  map[int64][]string
+	failed     map[int64]string
+	dead       []int64
+}
+
+func (m *outboxRepoMock) Claim(ctx context.Context, limit int, lease time.Duration) ([]events.Event, error) {
+	n := min(limit, len(m.pending))
+	claimed := m.pending[:n]
+	m.pending = m.pending[n:]
+	return claimed, nil
+}
+
+func (m *outboxRepoMock) MarkDelivered(ctx context.Context, id int64, sink string) error {
+	if m.delivered == nil {
+		m.delivered = make(map[int64][]string)
+	}
+	m.delivered[id] = append(m.delivered[id], sink)
+	return nil
+}
+
+func (m *outboxRepoMock) MarkDispatched(ctx context.Context, id int64) error {
+	m.dispatched = append(m.dispatched, id)
+	return nil
+}
+
+func (m *outboxRepoMock) MarkFailed(ctx context.Context, id int64, reason string, dead bool) error {
+	if m.failed == nil {
+		m.failed = make(map[int64]string)
+	}
+	m.failed[id] = reason
+	if dead {
+		m.dead = append(m.dead, id)
+	}
+	return nil
+}
+
+type sinkMock struct {
+	name     string
+	failOn   string
+	down     bool
+	received []events.Event
+}
+
+func (s *sinkMock) Name() string { return s.name }
+
+func (s *sinkMock) Deliver(ctx context.Context, ev events.Event) error {
+	if s.down || ev.Type == s.failOn {
+		return errors.New("unavailable")
+	}
+	s.received = append(s.received, ev)
+	return nil
+}
+
+func TestEventDispatcher_DeliversToAllSinks(t *testing.T) {
+	repo := &outboxRepoMock{pending: []events.Event{
+		{ID: 1, Type: events.PRCreated},
+		{ID: 2, Type: events.ReviewerAssigned},
+	}}
+	first, second := &sinkMock{name: "first"}, &sinkMock{name: "second"}
+
+	d := services.NewEventDispatcher(repo, time.Second, 10, 3, zap.NewNop().Sugar())
+	d.Register(first)
+	d.Register(second)
+
+	n, err := d.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, []int64{1, 2}, repo.dispatched)
+	require.Len(t, first.received, 2)
+	require.Len(t, second.received, 2)
+}
+
+func TestEventDispatcher_FailedSinkKeepsEventPending(t *testing.T) {
+	repo := &outboxRepoMock{pending: []events.Event{
+		{ID: 1, Type: events.PRCreated},
+		{ID: 2, Type: events.PRMerged},
+	}}
+	sink := &sinkMock{name: "chat", failOn: events.PRCreated}
+
+	d := services.NewEventDispatcher(repo, time.Second, 10, 3, zap.NewNop().Sugar())
+	d.Register(sink)
+
+	_, err := d.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, repo.dispatched)
+	require.Equal(t, "chat: unavailable", repo.failed[1])
+}
+
+func TestEventDispatcher_RetriesOnlyFailedSink(t *testing.T) {
+	ev := events.Event{ID: 1, Type: events.PRCreated}
+	repo := &outboxRepoMock{pending: []events.Event{ev}}
+	chat, email := &sinkMock{name: "chat"}, &sinkMock{name: "email", down: true}
+
+	d := services.NewEventDispatcher(repo, time.Second, 10, 3, zap.NewNop().Sugar())
+	d.Register(chat)
+	d.Register(email)
+
+	_, err := d.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, repo.dispatched)
+	require.Equal(t, "email: unavailable", repo.failed[1])
+	require.Equal(t, []string{"chat"}, repo.delivered[1])
+
+	// The retry carries the recorded deliveries and skips the chat sink.
+	email.down = false
+	ev.DeliveredTo = repo.delivered[1]
+	repo.pending = []events.Event{ev}
+
+	_, err = d.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, repo.dispatched)
+	require.Len(t, chat.received, 1)
+	require.Len(t, email.received, 1)
+}
+
+func TestEventDispatcher_DeadLettersAfterMaxAttempts(t *testing.T) {
+	repo := &outboxRepoMock{pending: []events.Event{
+		{ID: 1, Type: events.PRCreated, Attempts: 1},
+		{ID: 2, Type: events.PRCreated, Attempts: 2},
+	}}
+	sink := &sinkMock{name: "chat", down: true}
+
+	d := services.NewEventDispatcher(repo, time.Second, 10, 3, zap.NewNop().Sugar())
+	d.Register(sink)
+
+	_, err := d.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, repo.failed, 2)
+	require.Equal(t, []int64{2}, repo.dead)
+}
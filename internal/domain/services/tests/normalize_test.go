@@ -0,0 +1,185 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+type prCreateMock struct {
+	repository.PRRepository
+	created *dto.PRRequest
+}
+
+func (m *prCreateMock) Create(ctx context.Context, req dto.PRRequest) (*dto.PR, error) {
+	m.created = &req
+	return &dto.PR{ID: req.ID, AuthorID: req.AuthorID, Status: dto.PRStatusOpen}, nil
+}
+
+type teamAddMock struct {
+	repository.TeamRepository
+	added   *dto.Team
+	updated *dto.TeamSettingsRequest
+}
+
+func (m *teamAddMock) Add(ctx context.Context, team dto.Team) error {
+	m.added = &team
+	return nil
+}
+
+func (m *teamAddMock) UpdateSettings(ctx context.Context, req dto.TeamSettingsRequest) (*dto.TeamSettings, error) {
+	m.updated = &req
+	return &dto.TeamSettings{TeamName: req.TeamName}, nil
+}
+
+type userSkillsMock struct {
+	repository.UserRepository
+}
+
+func (m *userSkillsMock) SetSkills(ctx context.Context, req dto.UserSkills) (*dto.UserSkills, error) {
+	return &req, nil
+}
+
+func TestPRServiceCreate_NormalizesRequest(t *testing.T) {
+	repo := &prCreateMock{}
+	svc := services.NewPRService(repo)
+
+	_, err := svc.Create(context.Background(), dto.PRRequest{
+		ID:                " pr-1 ",
+		Name:              "Add search",
+		AuthorID:          "u1",
+		ChangedFiles:      []string{"cmd/main.go", " "},
+		RequiredSkills:    []string{" Go", "postgres", "go"},
+		RequiredReviewers: []string{"sec", "sec", ""},
+		ExcludedReviewers: []string{" u3 "},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pr-1", repo.created.ID)
+	require.Equal(t, []string{"cmd/main.go"}, repo.created.ChangedFiles)
+	require.Equal(t, []string{"go", "postgres"}, repo.created.RequiredSkills)
+	require.Equal(t, []string{"sec"}, repo.created.RequiredReviewers)
+	require.Equal(t, []string{"u3"}, repo.created.ExcludedReviewers)
+}
+
+func TestPRServiceCreate_RejectsInvalidRequests(t *testing.T) {
+	negative := -1
+	for _, tc := range []struct {
+		req dto.PRRequest
+		err error
+	}{
+		{
+			req: dto.PRRequest{ID: "pr-1", AuthorID: "u1"},
+			err: errors2.ErrBadRequest,
+		},
+		{
+			req: dto.PRRequest{ID: "pr-1", Name: "Add", AuthorID: "u1", ReviewersCount: &negative},
+			err: errors2.ErrInvalidReviewersCount,
+		},
+		{
+			req: dto.PRRequest{ID: "pr-1", Name: "Add", AuthorID: "u1", RequiredSkills: []string{"machine learning"}},
+			err: errors2.ErrBadRequest,
+		},
+	} {
+		repo := &prCreateMock{}
+		_, err := services.NewPRService(repo).Create(context.Background(), tc.req)
+		require.ErrorIs(t, err, tc.err)
+		require.Nil(t, repo.created, "repository must not be called")
+	}
+}
+
+func TestTeamServiceAdd_AppliesDefaults(t *testing.T) {
+	repo := &teamAddMock{}
+	svc := services.NewTeamService(repo, nil)
+
+	team, err := svc.Add(context.Background(), dto.Team{
+		Name:    " backend ",
+		Members: []dto.TeamMember{{ID: " u1", Name: "Alice ", Skills: []string{"Go", "go"}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, *repo.added, *team)
+	require.Equal(t, "backend", team.Name)
+	require.Equal(t, "least_loaded", team.ReviewerStrategy)
+	require.Equal(t, services.DefaultReviewersCount, team.ReviewersCount)
+	require.Equal(t, 1, team.MinReviewers)
+	require.Equal(t, 5, team.MaxReviewers)
+	require.Equal(t, dto.TeamMember{ID: "u1", Name: "Alice", Skills: []string{"go"}}, team.Members[0])
+}
+
+func TestTeamServiceAdd_RejectsInvalidTeams(t *testing.T) {
+	member := []dto.TeamMember{{ID: "u1", Name: "Alice"}}
+	for _, tc := range []struct {
+		team dto.Team
+		err  error
+	}{
+		{dto.Team{Members: member}, errors2.ErrBadRequest},
+		{dto.Team{Name: "backend", ReviewerStrategy: "loudest", Members: member}, errors2.ErrBadRequest},
+		{dto.Team{Name: "backend", ReviewersCount: 3, MaxReviewers: 2, Members: member}, errors2.ErrInvalidReviewersCount},
+		{dto.Team{Name: "backend", MinApprovals: -1, Members: member}, errors2.ErrBadRequest},
+		{dto.Team{Name: "backend", FallbackTeams: []string{"backend"}, Members: member}, errors2.ErrBadRequest},
+		{dto.Team{Name: "backend"}, errors2.ErrBadRequest},
+		{dto.Team{Name: "backend", Members: []dto.TeamMember{{ID: "u1"}}}, errors2.ErrBadRequest},
+		{dto.Team{Name: "backend", Members: append(member, member...)}, errors2.ErrBadRequest},
+	} {
+		repo := &teamAddMock{}
+		_, err := services.NewTeamService(repo, nil).Add(context.Background(), tc.team)
+		require.ErrorIs(t, err, tc.err, tc.team)
+		require.Nil(t, repo.added, "repository must not be called")
+	}
+}
+
+func TestTeamServiceUpdateSettings_NormalizesRequest(t *testing.T) {
+	repo := &teamAddMock{}
+	strategy := " round_robin "
+	chatURL := " https://chat.example.com/hook "
+
+	_, err := services.NewTeamService(repo, nil).UpdateSettings(context.Background(), dto.TeamSettingsRequest{
+		TeamName:         " backend ",
+		ReviewerStrategy: &strategy,
+		ChatWebhookURL:   &chatURL,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "backend", repo.updated.TeamName)
+	require.Equal(t, "round_robin", *repo.updated.ReviewerStrategy)
+	require.Equal(t, "https://chat.example.com/hook", *repo.updated.ChatWebhookURL)
+}
+
+func TestTeamServiceUpdateSettings_RejectsInvalidSettings(t *testing.T) {
+	negative, zero := -1, 0
+	strategy, chatURL := "loudest", "ftp://chat.example.com"
+	for _, tc := range []struct {
+		req dto.TeamSettingsRequest
+		err error
+	}{
+		{dto.TeamSettingsRequest{TeamName: " "}, errors2.ErrBadRequest},
+		{dto.TeamSettingsRequest{TeamName: "backend", ReviewerStrategy: &strategy}, errors2.ErrBadRequest},
+		{dto.TeamSettingsRequest{TeamName: "backend", MaxReviewers: &negative}, errors2.ErrInvalidReviewersCount},
+		{dto.TeamSettingsRequest{TeamName: "backend", MinApprovals: &negative}, errors2.ErrBadRequest},
+		{dto.TeamSettingsRequest{TeamName: "backend", WorkingHoursLeadMinutes: &negative}, errors2.ErrBadRequest},
+		{dto.TeamSettingsRequest{TeamName: "backend", PairingWindow: &zero}, errors2.ErrBadRequest},
+		{dto.TeamSettingsRequest{TeamName: "backend", ReviewSLAHours: &negative}, errors2.ErrBadRequest},
+		{dto.TeamSettingsRequest{TeamName: "backend", ChatWebhookURL: &chatURL}, errors2.ErrBadRequest},
+		{dto.TeamSettingsRequest{TeamName: "backend", FallbackTeams: &[]string{"backend"}}, errors2.ErrBadRequest},
+	} {
+		repo := &teamAddMock{}
+		_, err := services.NewTeamService(repo, nil).UpdateSettings(context.Background(), tc.req)
+		require.ErrorIs(t, err, tc.err, tc.req)
+		require.Nil(t, repo.updated, "repository must not be called")
+	}
+}
+
+func TestUserServiceSetSkills_NormalizesTags(t *testing.T) {
+	svc := services.NewUserService(&userSkillsMock{})
+
+	skills, err := svc.SetSkills(context.Background(), dto.UserSkills{UserID: "u1", Skills: []string{" Go", "postgres", "go"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "postgres"}, skills.Skills)
+
+	_, err = svc.SetSkills(context.Background(), dto.UserSkills{UserID: "u1", Skills: []string{""}})
+	require.ErrorIs(t, err, errors2.ErrBadRequest)
+}
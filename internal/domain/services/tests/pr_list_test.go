@@ -0,0 +1,105 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+// prListMock implements only List of PRRepository over a fixed set of pull
+// requests sorted by created_at descending.
+type prListMock struct {
+	repository.PRRepository
+	prs     []dto.PR
+	filters []dto.PRListFilter
+}
+
+func (m *prListMock) List(ctx context.Context, f dto.PRListFilter) ([]dto.PR, error) {
+	m.filters = append(m.filters, f)
+
+	var page []dto.PR
+	for _, pr := range m.prs {
+		if f.After != nil && (pr.CreatedAt.After(f.After.Time) || pr.CreatedAt.Equal(f.After.Time) && pr.ID >= f.After.ID) {
+			continue
+		}
+		if len(page) == f.Limit {
+			break
+		}
+		page = append(page, pr)
+	}
+	return page, nil
+}
+
+func listFixture(n int) []dto.PR {
+	base := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	prs := make([]dto.PR, 0, n)
+	for i := n; i > 0; i-- {
+		// Two pull requests per timestamp check the pull_request_id tie-break.
+		prs = append(prs, dto.PR{ID: fmt.Sprintf("pr-%02d", i), CreatedAt: base.Add(time.Duration(i/2) * time.Hour)})
+	}
+	return prs
+}
+
+func TestPRServiceList_PagesThroughEverything(t *testing.T) {
+	repo := &prListMock{prs: listFixture(7)}
+	svc := services.NewPRService(repo)
+
+	var ids []string
+	req := dto.PRListRequest{PRListFilter: dto.PRListFilter{Desc: true, Limit: 3}}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5)
+
+		resp, err := svc.List(context.Background(), req)
+		require.NoError(t, err)
+		for _, pr := range resp.PullRequests {
+			ids = append(ids, pr.ID)
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		req.Cursor = resp.NextCursor
+	}
+
+	require.Equal(t, []string{"pr-07", "pr-06", "pr-05", "pr-04", "pr-03", "pr-02", "pr-01"}, ids)
+	require.Equal(t, 4, repo.filters[0].Limit, "one extra row detects the next page")
+	require.Equal(t, dto.PRSortCreatedAt, repo.filters[0].SortBy)
+}
+
+func TestPRServiceList_DefaultAndMaxLimit(t *testing.T) {
+	repo := &prListMock{}
+	svc := services.NewPRService(repo)
+
+	_, err := svc.List(context.Background(), dto.PRListRequest{})
+	require.NoError(t, err)
+	_, err = svc.List(context.Background(), dto.PRListRequest{PRListFilter: dto.PRListFilter{Limit: 10_000}})
+	require.NoError(t, err)
+
+	require.Equal(t, services.DefaultPRListLimit+1, repo.filters[0].Limit)
+	require.Equal(t, services.MaxPRListLimit+1, repo.filters[1].Limit)
+}
+
+func TestPRServiceList_RejectsForeignCursor(t *testing.T) {
+	repo := &prListMock{prs: listFixture(3)}
+	svc := services.NewPRService(repo)
+
+	resp, err := svc.List(context.Background(), dto.PRListRequest{PRListFilter: dto.PRListFilter{Desc: true, Limit: 1}})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.NextCursor)
+
+	_, err = svc.List(context.Background(), dto.PRListRequest{
+		PRListFilter: dto.PRListFilter{SortBy: dto.PRSortUpdatedAt, Desc: true},
+		Cursor:       resp.NextCursor,
+	})
+	require.ErrorIs(t, err, errors2.ErrBadRequest)
+
+	_, err = svc.List(context.Background(), dto.PRListRequest{Cursor: "not a cursor"})
+	require.ErrorIs(t, err, errors2.ErrBadRequest)
+}
@@ -0,0 +1,114 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/services"
+)
+
+type reviewSLARepoMock struct {
+	pending []dto.PendingReview
+	afters  []dto.PendingReview
+	flagged []string
+	// failOn makes MarkBreached fail for this pull request.
+	failOn string
+}
+
+func (m *reviewSLARepoMock) ListPending(ctx context.Context, after dto.PendingReview, limit int) ([]dto.PendingReview, error) {
+	m.afters = append(m.afters, after)
+	return m.pending, nil
+}
+
+func (m *reviewSLARepoMock) MarkBreached(ctx context.Context, review dto.PendingReview) (bool, error) {
+	if review.PullRequestID == m.failOn {
+		return false, errors.New("connection reset")
+	}
+	m.flagged = append(m.flagged, review.PullRequestID+"/"+review.ReviewerID)
+	return true, nil
+}
+
+func TestSLAWatcher_EscalatesAndReassignsBreachedReviews(t *testing.T) {
+	assigned := time.Now().Add(-30 * time.Hour)
+	allDay := &dto.WorkingHours{TimeZone: "UTC", Start: "00:00", End: "00:00", Days: []int{1, 2, 3, 4, 5, 6, 7}}
+	office := &dto.WorkingHours{TimeZone: "Europe/Berlin", Start: "09:00", End: "17:00", Days: []int{1, 2, 3, 4, 5}}
+
+	repo := &reviewSLARepoMock{pending: []dto.PendingReview{
+		{PullRequestID: "pr-1", ReviewerID: "u1", AssignedAt: assigned, SLAHours: 24, AutoReassign: true, WorkingHours: allDay},
+		// 30 hours hold at most 16 working hours of an 8-hour day.
+		{PullRequestID: "pr-2", ReviewerID: "u2", AssignedAt: assigned, SLAHours: 24, AutoReassign: true, WorkingHours: office},
+		{PullRequestID: "pr-3", ReviewerID: "u3", AssignedAt: assigned, SLAHours: 24, WorkingHours: allDay},
+		// A user without working hours counts as always working.
+		{PullRequestID: "pr-4", ReviewerID: "u4", AssignedAt: assigned, SLAHours: 24, AutoReassign: true},
+	}}
+	prs := &reassignMock{failFor: "u4"}
+
+	w := services.NewSLAWatcher(repo, prs, time.Minute, 10, zap.NewNop().Sugar())
+	n, err := w.CheckOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+
+	require.Equal(t, []string{"pr-1/u1", "pr-3/u3", "pr-4/u4"}, repo.flagged)
+	// u4 had no candidate: the breach is escalated all the same.
+	require.Equal(t, []dto.ReassignRequest{{PullRequestID: "pr-1", OldUserID: "u1"}}, prs.reassigns)
+}
+
+func TestSLAWatcher_EscalatesRequiredReviewerWithoutReassigning(t *testing.T) {
+	repo := &reviewSLARepoMock{pending: []dto.PendingReview{
+		{PullRequestID: "pr-1", ReviewerID: "sec", AssignedAt: time.Now().Add(-30 * time.Hour), SLAHours: 24, AutoReassign: true, Required: true},
+	}}
+	prs := &reassignMock{}
+
+	w := services.NewSLAWatcher(repo, prs, time.Minute, 10, zap.NewNop().Sugar())
+	_, err := w.CheckOnce(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"pr-1/sec"}, repo.flagged)
+	require.Empty(t, prs.reassigns)
+}
+
+func TestSLAWatcher_PagesThroughPendingReviews(t *testing.T) {
+	first := dto.PendingReview{PullRequestID: "pr-1", ReviewerID: "u1", AssignedAt: time.Now(), SLAHours: 24}
+	second := dto.PendingReview{PullRequestID: "pr-2", ReviewerID: "u2", AssignedAt: time.Now(), SLAHours: 24}
+	repo := &reviewSLARepoMock{pending: []dto.PendingReview{first, second}}
+
+	w := services.NewSLAWatcher(repo, &reassignMock{}, time.Minute, 2, zap.NewNop().Sugar())
+	_, err := w.CheckOnce(context.Background())
+	require.NoError(t, err)
+
+	repo.pending = repo.pending[:1]
+	_, err = w.CheckOnce(context.Background())
+	require.NoError(t, err)
+	_, err = w.CheckOnce(context.Background())
+	require.NoError(t, err)
+
+	// A full page continues after its last review, a short one starts over.
+	require.Equal(t, []dto.PendingReview{{}, second, {}}, repo.afters)
+	require.Empty(t, repo.flagged)
+}
+
+func TestSLAWatcher_ContinuesFromReviewThatFailedToMark(t *testing.T) {
+	assigned := time.Now().Add(-30 * time.Hour)
+	first := dto.PendingReview{PullRequestID: "pr-1", ReviewerID: "u1", AssignedAt: assigned, SLAHours: 24}
+	second := dto.PendingReview{PullRequestID: "pr-2", ReviewerID: "u2", AssignedAt: assigned, SLAHours: 24}
+	third := dto.PendingReview{PullRequestID: "pr-3", ReviewerID: "u3", AssignedAt: assigned, SLAHours: 24}
+	repo := &reviewSLARepoMock{pending: []dto.PendingReview{first, second, third}, failOn: "pr-2"}
+
+	w := services.NewSLAWatcher(repo, &reassignMock{}, time.Minute, 3, zap.NewNop().Sugar())
+	_, err := w.CheckOnce(context.Background())
+	require.Error(t, err)
+
+	repo.pending, repo.failOn = []dto.PendingReview{second, third}, ""
+	_, err = w.CheckOnce(context.Background())
+	require.NoError(t, err)
+
+	// pr-3 wasn't skipped: the second poll starts right after pr-1.
+	require.Equal(t, []dto.PendingReview{{}, first}, repo.afters)
+	require.Equal(t, []string{"pr-1/u1", "pr-2/u2", "pr-3/u3"}, repo.flagged)
+}
@@ -0,0 +1,47 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+type teamDeactivateMock struct {
+	repository.TeamRepository
+	deactivated []string
+}
+
+func (m *teamDeactivateMock) DeactivateMembers(ctx context.Context, teamName string, userIDs []string) error {
+	m.deactivated = append(m.deactivated, userIDs...)
+	return nil
+}
+
+func TestTeamServiceDeactivateMembers_ListsReviewsNotReassigned(t *testing.T) {
+	repo := &teamDeactivateMock{}
+	prs := &reassignMock{
+		open:   map[string][]string{"u1": {"pr-1", "pr-2"}, "u2": {"pr-3"}},
+		failPR: "pr-1",
+	}
+
+	resp, err := services.NewTeamService(repo, prs).DeactivateMembers(context.Background(), dto.TeamDeactivateRequest{
+		TeamName: "backend",
+		UserIDs:  []string{"u1", "u2"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"u1", "u2"}, repo.deactivated)
+
+	// A failure for u1 doesn't stop the hand-over of u2's reviews.
+	require.Equal(t, []dto.ReassignRequest{
+		{PullRequestID: "pr-2", OldUserID: "u1"},
+		{PullRequestID: "pr-3", OldUserID: "u2"},
+	}, prs.reassigns)
+	require.Equal(t, []dto.ReassignFailure{
+		{PullRequestID: "pr-1", UserID: "u1", Reason: errors2.ErrNoCandidate.Error()},
+	}, resp.NotReassigned)
+}
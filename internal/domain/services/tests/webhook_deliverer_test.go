@@ -0,0 +1,150 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/services"
+)
+
+type failedDelivery struct {
+	code int
+	next time.Time
+	dead bool
+}
+
+type webhookRepoMock struct {
+	jobs      []dto.WebhookJob
+	delivered map[int64]int
+	failed    map[int64]failedDelivery
+}
+
+func (m *webhookRepoMock) CreateSubscription(ctx context.Context, sub dto.WebhookSubscription) (*dto.WebhookSubscription, error) {
+	return &sub, nil
+}
+
+func (m *webhookRepoMock) ListSubscriptions(ctx context.Context, teamName string) ([]dto.WebhookSubscription, error) {
+	return nil, nil
+}
+
+func (m *webhookRepoMock) DeleteSubscription(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (m *webhookRepoMock) Enqueue(ctx context.Context, ev events.Event, userIDs, teams []string) (int, error) {
+	return 0, nil
+}
+
+func (m *webhookRepoMock) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]dto.WebhookJob, error) {
+	n := min(limit, len(m.jobs))
+	claimed := m.jobs[:n]
+	m.jobs = m.jobs[n:]
+	return claimed, nil
+}
+
+func (m *webhookRepoMock) MarkDelivered(ctx context.Context, id int64, statusCode int) error {
+	if m.delivered == nil {
+		m.delivered = make(map[int64]int)
+	}
+	m.delivered[id] = statusCode
+	return nil
+}
+
+func (m *webhookRepoMock) MarkFailed(ctx context.Context, id int64, statusCode int, reason string, next time.Time, dead bool) error {
+	if m.failed == nil {
+		m.failed = make(map[int64]failedDelivery)
+	}
+	m.failed[id] = failedDelivery{code: statusCode, next: next, dead: dead}
+	return nil
+}
+
+func (m *webhookRepoMock) ListDeliveries(ctx context.Context, subscriptionID int64, status string, limit int) ([]dto.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (m *webhookRepoMock) RetryDelivery(ctx context.Context, id int64) error {
+	return nil
+}
+
+func newDeliverer(repo *webhookRepoMock) *services.WebhookDeliverer {
+	return services.NewWebhookDeliverer(repo, services.WebhookDeliveryOptions{
+		MaxAttempts: 3,
+		BaseBackoff: time.Second,
+		MaxBackoff:  10 * time.Second,
+	}, zap.NewNop().Sugar())
+}
+
+func TestWebhookDeliverer_SignsAndMarksDelivered(t *testing.T) {
+	var gotSignature, gotEvent string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(services.WebhookSignatureHeader)
+		gotEvent = r.Header.Get(services.WebhookEventHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	repo := &webhookRepoMock{jobs: []dto.WebhookJob{{
+		DeliveryID: 7,
+		URL:        srv.URL,
+		Secret:     "s3cr3t",
+		Event: events.Event{
+			ID:          1,
+			Type:        events.ReviewerAssigned,
+			AggregateID: "pr-1",
+			Payload:     json.RawMessage(`{"user_id":"u2"}`),
+		},
+	}}}
+
+	n, err := newDeliverer(repo).DeliverOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, http.StatusNoContent, repo.delivered[7])
+	require.Equal(t, events.ReviewerAssigned, gotEvent)
+	require.Equal(t, services.SignPayload("s3cr3t", gotBody), gotSignature)
+	require.Contains(t, string(gotBody), `"user_id":"u2"`)
+}
+
+func TestWebhookDeliverer_FailureSchedulesRetry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	repo := &webhookRepoMock{jobs: []dto.WebhookJob{
+		{DeliveryID: 1, Attempts: 1, URL: srv.URL, Event: events.Event{Type: events.PRMerged}},
+		{DeliveryID: 2, Attempts: 2, URL: srv.URL, Event: events.Event{Type: events.PRMerged}},
+	}}
+
+	before := time.Now()
+	_, err := newDeliverer(repo).DeliverOnce(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, repo.delivered)
+
+	retry := repo.failed[1]
+	require.Equal(t, http.StatusInternalServerError, retry.code)
+	require.False(t, retry.dead)
+	require.WithinDuration(t, before.Add(2*time.Second), retry.next, time.Second)
+
+	require.True(t, repo.failed[2].dead)
+}
+
+func TestWebhookDeliverer_Backoff(t *testing.T) {
+	d := newDeliverer(&webhookRepoMock{})
+
+	require.Equal(t, time.Second, d.Backoff(1))
+	require.Equal(t, 2*time.Second, d.Backoff(2))
+	require.Equal(t, 8*time.Second, d.Backoff(4))
+	require.Equal(t, 10*time.Second, d.Backoff(10))
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"pr-reviwer-assigner/internal/domain/dto"
 	"pr-reviwer-assigner/internal/domain/repository"
 )
@@ -8,6 +9,14 @@ import (
 type UserService interface {
 	GetReview(userID string) ([]dto.PRShort, error)
 	SetIsActive(req dto.SIARequest) (*dto.User, error)
+	SetProfile(ctx context.Context, req dto.ProfileRequest) (*dto.User, error)
+	GetSkills(ctx context.Context, userID string) (*dto.UserSkills, error)
+	// SetSkills replaces all skill tags of the user.
+	SetSkills(ctx context.Context, req dto.UserSkills) (*dto.UserSkills, error)
+	SetVCSIdentity(ctx context.Context, identity dto.VCSIdentity) (*dto.VCSIdentity, error)
+	// ResolveVCSLogin maps a provider login to a user_id. Logins without an
+	// explicit mapping resolve to the user with the same user_id, if any.
+	ResolveVCSLogin(ctx context.Context, provider, login string) (string, error)
 }
 
 type userService struct {
@@ -32,3 +41,29 @@ func (s *userService) SetIsActive(user dto.SIARequest) (*dto.User, error) {
 
 	return u, nil
 }
+
+func (s *userService) SetProfile(ctx context.Context, req dto.ProfileRequest) (*dto.User, error) {
+	return s.repo.SetProfile(ctx, req)
+}
+
+func (s *userService) GetSkills(ctx context.Context, userID string) (*dto.UserSkills, error) {
+	return s.repo.GetSkills(ctx, userID)
+}
+
+func (s *userService) SetSkills(ctx context.Context, req dto.UserSkills) (*dto.UserSkills, error) {
+	skills, err := normalizeSkills(req.Skills)
+	if err != nil {
+		return nil, err
+	}
+	req.Skills = skills
+
+	return s.repo.SetSkills(ctx, req)
+}
+
+func (s *userService) SetVCSIdentity(ctx context.Context, identity dto.VCSIdentity) (*dto.VCSIdentity, error) {
+	return s.repo.SetVCSIdentity(ctx, identity)
+}
+
+func (s *userService) ResolveVCSLogin(ctx context.Context, provider, login string) (string, error) {
+	return s.repo.ResolveVCSLogin(ctx, provider, login)
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Headers sent with every outgoing webhook.
+const (
+	WebhookEventHeader     = "X-PR-Assigner-Event"
+	WebhookDeliveryHeader  = "X-PR-Assigner-Delivery"
+	WebhookSignatureHeader = "X-PR-Assigner-Signature-256"
+)
+
+// Outgoing webhook defaults, used when the config leaves them at zero.
+const (
+	DefaultWebhookMaxAttempts  = 8
+	DefaultWebhookBaseBackoff  = time.Second
+	DefaultWebhookMaxBackoff   = 10 * time.Minute
+	DefaultWebhookTimeout      = 5 * time.Second
+	DefaultWebhookPollInterval = time.Second
+	DefaultWebhookBatchSize    = 50
+)
+
+type WebhookDeliveryOptions struct {
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Timeout      time.Duration
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// WebhookDeliverer sends queued webhook deliveries, retrying failures with
+// exponential backoff and dead-lettering them after MaxAttempts.
+type WebhookDeliverer struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	opts   WebhookDeliveryOptions
+	logger *zap.SugaredLogger
+}
+
+func NewWebhookDeliverer(repo repository.WebhookRepository, opts WebhookDeliveryOptions, logger *zap.SugaredLogger) *WebhookDeliverer {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultWebhookMaxAttempts
+	}
+	if opts.BaseBackoff <= 0 {
+		opts.BaseBackoff = DefaultWebhookBaseBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultWebhookMaxBackoff
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultWebhookTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultWebhookPollInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultWebhookBatchSize
+	}
+
+	return &WebhookDeliverer{
+		repo:   repo,
+		client: &http.Client{Timeout: opts.Timeout},
+		opts:   opts,
+		logger: logger,
+	}
+}
+
+// SignPayload returns the sha256=<hex> HMAC of body sent in WebhookSignatureHeader.
+func SignPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Run delivers until ctx is cancelled.
+func (d *WebhookDeliverer) Run(ctx context.Context) {
+	poll(ctx, d.opts.PollInterval, d.opts.BatchSize, d.DeliverOnce, func(err error) {
+		d.logger.Error("deliver webhooks: ", err)
+	})
+}
+
+// DeliverOnce sends one batch of due deliveries and returns how many were claimed.
+func (d *WebhookDeliverer) DeliverOnce(ctx context.Context) (int, error) {
+	// Each job in the batch may take up to the client timeout.
+	lease := time.Duration(d.opts.BatchSize)*d.opts.Timeout + time.Minute
+	jobs, err := d.repo.ClaimDeliveries(ctx, d.opts.BatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		code, err := d.send(ctx, job)
+		if err == nil {
+			if err := d.repo.MarkDelivered(ctx, job.DeliveryID, code); err != nil {
+				return len(jobs), err
+			}
+			continue
+		}
+
+		attempt := job.Attempts + 1
+		dead := attempt >= d.opts.MaxAttempts
+		next := time.Now().Add(d.Backoff(attempt))
+		if dead {
+			d.logger.Error("webhook delivery ", job.DeliveryID, " dead after ", attempt, " attempts: ", err)
+		}
+
+		if err := d.repo.MarkFailed(ctx, job.DeliveryID, code, err.Error(), next, dead); err != nil {
+			return len(jobs), err
+		}
+	}
+
+	return len(jobs), nil
+}
+
+// Backoff is the wait after the given failed attempt: BaseBackoff doubled
+// per attempt, capped at MaxBackoff.
+func (d *WebhookDeliverer) Backoff(attempt int) time.Duration {
+	wait := d.opts.BaseBackoff
+	for i := 1; i < attempt && wait < d.opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, d.opts.MaxBackoff)
+}
+
+func (d *WebhookDeliverer) send(ctx context.Context, job dto.WebhookJob) (int, error) {
+	body, err := json.Marshal(job.Event)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, job.Event.Type)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(job.DeliveryID, 10))
+	req.Header.Set(WebhookSignatureHeader, SignPayload(job.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+)
+
+// DeliveriesPageSize caps how many deliveries ListDeliveries returns.
+const DeliveriesPageSize = 100
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, sub dto.WebhookSubscription) (*dto.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, teamName string) ([]dto.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, subscriptionID int64, status string) ([]dto.WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, id int64) error
+}
+
+type webhookService struct {
+	repo repository.WebhookRepository
+}
+
+func NewWebhookService(repo repository.WebhookRepository) WebhookService {
+	return &webhookService{
+		repo: repo,
+	}
+}
+
+// CreateSubscription generates a signing secret when the caller didn't pass one.
+func (s *webhookService) CreateSubscription(ctx context.Context, sub dto.WebhookSubscription) (*dto.WebhookSubscription, error) {
+	if sub.Secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		sub.Secret = hex.EncodeToString(buf)
+	}
+	if sub.EventTypes == nil {
+		sub.EventTypes = []string{}
+	}
+
+	return s.repo.CreateSubscription(ctx, sub)
+}
+
+func (s *webhookService) ListSubscriptions(ctx context.Context, teamName string) ([]dto.WebhookSubscription, error) {
+	return s.repo.ListSubscriptions(ctx, teamName)
+}
+
+func (s *webhookService) DeleteSubscription(ctx context.Context, id int64) error {
+	return s.repo.DeleteSubscription(ctx, id)
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, subscriptionID int64, status string) ([]dto.WebhookDelivery, error) {
+	return s.repo.ListDeliveries(ctx, subscriptionID, status, DeliveriesPageSize)
+}
+
+func (s *webhookService) RetryDelivery(ctx context.Context, id int64) error {
+	return s.repo.RetryDelivery(ctx, id)
+}
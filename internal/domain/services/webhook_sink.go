@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/repository"
+)
+
+type webhookSink struct {
+	repo repository.WebhookRepository
+}
+
+// NewWebhookSink returns a sink that queues a delivery for every team
+// subscription interested in the event. Sending happens in WebhookDeliverer.
+func NewWebhookSink(repo repository.WebhookRepository) events.Sink {
+	return &webhookSink{
+		repo: repo,
+	}
+}
+
+func (s *webhookSink) Name() string {
+	return "webhooks"
+}
+
+// Deliver routes the event to the author's or user's team and, for
+// assignments, to the team the reviewer was taken from.
+func (s *webhookSink) Deliver(ctx context.Context, ev events.Event) error {
+	var route struct {
+		AuthorID string `json:"author_id"`
+		UserID   string `json:"user_id"`
+		TeamName string `json:"team_name"`
+	}
+	if err := ev.Decode(&route); err != nil {
+		return err
+	}
+
+	var userIDs, teams []string
+	for _, id := range []string{route.AuthorID, route.UserID} {
+		if id != "" {
+			userIDs = append(userIDs, id)
+		}
+	}
+	if route.TeamName != "" {
+		teams = append(teams, route.TeamName)
+	}
+
+	_, err := s.repo.Enqueue(ctx, ev, userIDs, teams)
+	return err
+}
@@ -3,12 +3,22 @@ package errors2
 import "errors"
 
 var (
-	ErrTeamExists  = errors.New("TEAM_EXISTS")
-	ErrPRExists    = errors.New("PR_EXISTS")
-	ErrPRMerged    = errors.New("PR_MERGED")
-	ErrNotAssigned = errors.New("NOT_ASSIGNED")
-	ErrNoCandidate = errors.New("NO_CANDIDATE")
-	ErrNotFound    = errors.New("NOT_FOUND")
-	ErrInternal    = errors.New("INTERNAL_ERROR")
-	ErrBadRequest  = errors.New("BAD_REQUEST")
+	ErrTeamExists      = errors.New("TEAM_EXISTS")
+	ErrPRExists        = errors.New("PR_EXISTS")
+	ErrPRMerged        = errors.New("PR_MERGED")
+	ErrPRClosed        = errors.New("PR_CLOSED")
+	ErrPRDraft         = errors.New("PR_DRAFT")
+	ErrNotAssigned     = errors.New("NOT_ASSIGNED")
+	ErrAlreadyAssigned = errors.New("ALREADY_ASSIGNED")
+	ErrNoCandidate     = errors.New("NO_CANDIDATE")
+	ErrNotFound        = errors.New("NOT_FOUND")
+	ErrInternal        = errors.New("INTERNAL_ERROR")
+	ErrBadRequest      = errors.New("BAD_REQUEST")
+	ErrUnauthorized    = errors.New("UNAUTHORIZED")
+
+	ErrInvalidReviewersCount = errors.New("INVALID_REVIEWERS_COUNT")
+	ErrNotEnoughApprovals    = errors.New("NOT_ENOUGH_APPROVALS")
+	ErrInvalidCalendar       = errors.New("INVALID_CALENDAR")
+	ErrInvalidCodeowners     = errors.New("INVALID_CODEOWNERS")
+	ErrInvalidReviewers      = errors.New("INVALID_REVIEWERS")
 )
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+type AvailabilityHandler struct {
+	service services.AvailabilityService
+	logger  *zap.SugaredLogger
+}
+
+func NewAvailabilityHandler(service services.AvailabilityService, logger *zap.SugaredLogger) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AvailabilityHandler) Add(c fiber.Ctx) error {
+	var req dto.Unavailability
+
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error("add unavailability: failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	req.UserID = strings.TrimSpace(req.UserID)
+	if req.UserID == "" {
+		h.logger.Error("add unavailability: empty user id")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "user_id can't be empty",
+			},
+		})
+	}
+
+	if msg := validateWindow(&req); msg != "" {
+		h.logger.Error("add unavailability: ", msg)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: msg,
+			},
+		})
+	}
+
+	window, err := h.service.Add(c.Context(), req)
+	if err != nil {
+		return h.fail(c, "add unavailability", err)
+	}
+
+	h.logger.Info("add unavailability success: ", window.ID, " ", window.UserID)
+
+	return c.Status(fiber.StatusCreated).JSON(dto.UnavailabilityResponse{
+		Window: *window,
+	})
+}
+
+func (h *AvailabilityHandler) List(c fiber.Ctx) error {
+	userID := strings.TrimSpace(c.Query("user_id"))
+	if userID == "" {
+		h.logger.Error("list unavailability: empty user id")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "user_id can't be empty",
+			},
+		})
+	}
+
+	windows, err := h.service.List(c.Context(), userID)
+	if err != nil {
+		return h.fail(c, "list unavailability", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.UnavailabilitiesResponse{
+		UserID:  userID,
+		Windows: windows,
+	})
+}
+
+func (h *AvailabilityHandler) Update(c fiber.Ctx) error {
+	var req dto.Unavailability
+
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error("update unavailability: failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	if msg := validateWindow(&req); msg != "" {
+		h.logger.Error("update unavailability: ", msg)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: msg,
+			},
+		})
+	}
+
+	window, err := h.service.Update(c.Context(), req)
+	if err != nil {
+		return h.fail(c, "update unavailability", err)
+	}
+
+	h.logger.Info("update unavailability success: ", window.ID)
+
+	return c.Status(fiber.StatusOK).JSON(dto.UnavailabilityResponse{
+		Window: *window,
+	})
+}
+
+func (h *AvailabilityHandler) Delete(c fiber.Ctx) error {
+	var req dto.UnavailabilityDeleteRequest
+
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error("delete unavailability: failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	if err := h.service.Delete(c.Context(), req.ID); err != nil {
+		return h.fail(c, "delete unavailability", err)
+	}
+
+	h.logger.Info("delete unavailability success: ", req.ID)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ImportICS imports the calendar sent as the request body. With user_id in
+// the query every event belongs to that user; without it events are matched
+// by organizer and attendee emails.
+func (h *AvailabilityHandler) ImportICS(c fiber.Ctx) error {
+	userID := strings.TrimSpace(c.Query("user_id"))
+
+	resp, err := h.service.ImportICS(c.Context(), userID, bytes.NewReader(c.Body()))
+	if err != nil {
+		return h.fail(c, "import ics", err)
+	}
+
+	h.logger.Info("import ics success: ", userID, " imported ", resp.Imported, " removed ", resp.Removed)
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *AvailabilityHandler) ImportICSFile(c fiber.Ctx) error {
+	var req dto.ICSImportRequest
+
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error("import ics file: failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	req.UserID = strings.TrimSpace(req.UserID)
+	req.Path = strings.TrimSpace(req.Path)
+	if req.Path == "" {
+		h.logger.Error("import ics file: empty path")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "path can't be empty",
+			},
+		})
+	}
+
+	resp, err := h.service.ImportICSFile(c.Context(), req)
+	if err != nil {
+		return h.fail(c, "import ics file", err)
+	}
+
+	h.logger.Info("import ics file success: ", req.Path, " imported ", resp.Imported, " removed ", resp.Removed)
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *AvailabilityHandler) fail(c fiber.Ctx, op string, err error) error {
+	switch {
+	case errors.Is(err, errors2.ErrBadRequest):
+		h.logger.Error(op, ": bad request: ", err)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, errors2.ErrInvalidCalendar):
+		h.logger.Error(op, ": invalid calendar: ", err)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInvalidCalendar.Error(),
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, errors2.ErrNotFound):
+		h.logger.Error(op, ": not found: ", err)
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrNotFound.Error(),
+				Message: "resource not found",
+			},
+		})
+	default:
+		h.logger.Error(op, ": service error: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+}
+
+// validateWindow checks the range and trims the reason. It returns the
+// message for a 400 response, or "" when the window is valid.
+func validateWindow(w *dto.Unavailability) string {
+	w.Reason = strings.TrimSpace(w.Reason)
+
+	if w.StartsAt.IsZero() || w.EndsAt.IsZero() {
+		return "starts_at and ends_at are required"
+	}
+	if !w.EndsAt.After(w.StartsAt) {
+		return "ends_at must be after starts_at"
+	}
+
+	return ""
+}
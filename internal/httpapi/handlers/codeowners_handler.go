@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+type CodeownersHandler struct {
+	service services.CodeownersService
+	logger  *zap.SugaredLogger
+}
+
+func NewCodeownersHandler(service services.CodeownersService, logger *zap.SugaredLogger) *CodeownersHandler {
+	return &CodeownersHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *CodeownersHandler) Get(c fiber.Ctx) error {
+	teamName := strings.TrimSpace(c.Query("team_name"))
+	if teamName == "" {
+		h.logger.Error("get codeowners: empty team name")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "team_name can't be empty",
+			},
+		})
+	}
+
+	resp, err := h.service.Get(c.Context(), teamName)
+	if err != nil {
+		return h.fail(c, "get codeowners", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// Upload takes the CODEOWNERS file as the raw request body.
+func (h *CodeownersHandler) Upload(c fiber.Ctx) error {
+	teamName := strings.TrimSpace(c.Query("team_name"))
+	if teamName == "" {
+		h.logger.Error("upload codeowners: empty team name")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "team_name can't be empty",
+			},
+		})
+	}
+
+	resp, err := h.service.Upload(c.Context(), teamName, bytes.NewReader(c.Body()))
+	if err != nil {
+		return h.fail(c, "upload codeowners", err)
+	}
+
+	h.logger.Info("upload codeowners success: ", teamName, " rules ", len(resp.Rules))
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *CodeownersHandler) fail(c fiber.Ctx, op string, err error) error {
+	switch {
+	case errors.Is(err, errors2.ErrInvalidCodeowners):
+		h.logger.Error(op, ": invalid codeowners: ", err)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInvalidCodeowners.Error(),
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, errors2.ErrNotFound):
+		h.logger.Error(op, ": team not found: ", err)
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrNotFound.Error(),
+				Message: "resource not found",
+			},
+		})
+	default:
+		h.logger.Error(op, ": service error: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"pr-reviwer-assigner/internal/domain/dto"
 	"pr-reviwer-assigner/internal/domain/services"
 	errors2 "pr-reviwer-assigner/internal/errors"
@@ -37,25 +39,35 @@ func (h *PRHandler) CreatePR(c fiber.Ctx) error {
 		})
 	}
 
-	prReq.ID = strings.TrimSpace(prReq.ID)
-	prReq.Name = strings.TrimSpace(prReq.Name)
-	prReq.AuthorID = strings.TrimSpace(prReq.AuthorID)
-
-	if prReq.ID == "" || prReq.Name == "" || prReq.AuthorID == "" {
-		h.logger.Error("create PR: missing fields: ", prReq)
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: dto.Error{
-				Code:    errors2.ErrBadRequest.Error(),
-				Message: "all fields are required",
-			},
-		})
-	}
-
 	ctx := c.Context()
 
 	pr, err := h.service.Create(ctx, prReq)
 	if err != nil {
 		switch {
+		case errors.Is(err, errors2.ErrInvalidReviewersCount):
+			h.logger.Error("create PR: invalid reviewers_count: ", err)
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInvalidReviewersCount.Error(),
+					Message: err.Error(),
+				},
+			})
+		case errors.Is(err, errors2.ErrBadRequest):
+			h.logger.Error("create PR: bad request: ", err)
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrBadRequest.Error(),
+					Message: err.Error(),
+				},
+			})
+		case errors.Is(err, errors2.ErrInvalidReviewers):
+			h.logger.Error("create PR: invalid reviewers: ", err)
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInvalidReviewers.Error(),
+					Message: err.Error(),
+				},
+			})
 		case errors.Is(err, errors2.ErrPRExists):
 			h.logger.Error("create PR: already exists: ", prReq.ID)
 			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
@@ -128,6 +140,30 @@ func (h *PRHandler) MergePR(c fiber.Ctx) error {
 					Message: "resource not found",
 				},
 			})
+		case errors.Is(err, errors2.ErrPRClosed):
+			h.logger.Error("merge PR: closed: ", req.PullRequestID)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "cannot merge closed PR",
+				},
+			})
+		case errors.Is(err, errors2.ErrPRDraft):
+			h.logger.Error("merge PR: draft: ", req.PullRequestID)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "cannot merge draft PR",
+				},
+			})
+		case errors.Is(err, errors2.ErrNotEnoughApprovals):
+			h.logger.Error("merge PR: not enough approvals: ", req.PullRequestID, err)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrNotEnoughApprovals.Error(),
+					Message: err.Error(),
+				},
+			})
 		default:
 			h.logger.Error("merge PR: service error: ", err)
 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
@@ -162,6 +198,7 @@ func (h *PRHandler) ReassignViewer(c fiber.Ctx) error {
 
 	req.PullRequestID = strings.TrimSpace(req.PullRequestID)
 	req.OldUserID = strings.TrimSpace(req.OldUserID)
+	req.NewUserID = strings.TrimSpace(req.NewUserID)
 	if req.PullRequestID == "" || req.OldUserID == "" {
 		h.logger.Error("reassign PR: missing fields: ", req)
 		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
@@ -171,6 +208,15 @@ func (h *PRHandler) ReassignViewer(c fiber.Ctx) error {
 			},
 		})
 	}
+	if req.NewUserID == req.OldUserID {
+		h.logger.Error("reassign PR: new reviewer is the old one: ", req)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "new_user_id must differ from old_user_id",
+			},
+		})
+	}
 
 	ctx := c.Context()
 
@@ -193,6 +239,30 @@ func (h *PRHandler) ReassignViewer(c fiber.Ctx) error {
 					Message: "cannot reassign on merged PR",
 				},
 			})
+		case errors.Is(err, errors2.ErrPRClosed), errors.Is(err, errors2.ErrPRDraft):
+			h.logger.Error("reassign PR: not open: ", req.PullRequestID)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "cannot reassign on closed or draft PR",
+				},
+			})
+		case errors.Is(err, errors2.ErrInvalidReviewers):
+			h.logger.Error("reassign PR: invalid reviewers: ", err)
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInvalidReviewers.Error(),
+					Message: err.Error(),
+				},
+			})
+		case errors.Is(err, errors2.ErrAlreadyAssigned):
+			h.logger.Error("reassign PR: new reviewer already assigned: ", req)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "new reviewer is already assigned",
+				},
+			})
 		default:
 			h.logger.Error("reassign PR: service error: ", err)
 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
@@ -205,13 +275,7 @@ func (h *PRHandler) ReassignViewer(c fiber.Ctx) error {
 	}
 
 	response := dto.ReassignResponse{
-		PR: dto.PR{
-			ID:        pr.ID,
-			Name:      pr.Name,
-			AuthorID:  pr.AuthorID,
-			Status:    pr.Status,
-			Reviewers: pr.Reviewers,
-		},
+		PR:         *pr,
 		ReplacedBy: replacedBy,
 	}
 	h.logger.Info("reassign PR success: ", fiber.Map{
@@ -221,3 +285,268 @@ func (h *PRHandler) ReassignViewer(c fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(response)
 }
+
+func (h *PRHandler) AddReviewer(c fiber.Ctx) error {
+	return h.changeReviewer(c, "add reviewer", h.service.AddReviewer)
+}
+
+func (h *PRHandler) RemoveReviewer(c fiber.Ctx) error {
+	return h.changeReviewer(c, "remove reviewer", h.service.RemoveReviewer)
+}
+
+// changeReviewer handles the endpoints that add or remove a named reviewer.
+func (h *PRHandler) changeReviewer(
+	c fiber.Ctx,
+	op string,
+	change func(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error),
+) error {
+	var req dto.ReviewerRequest
+
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error(op+": failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	req.PullRequestID = strings.TrimSpace(req.PullRequestID)
+	req.UserID = strings.TrimSpace(req.UserID)
+	if req.PullRequestID == "" || req.UserID == "" {
+		h.logger.Error(op+": missing fields: ", req)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "all fields are required",
+			},
+		})
+	}
+
+	pr, err := change(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			h.logger.Error(op+": not found: ", req)
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "resource not found",
+				},
+			})
+		case errors.Is(err, errors2.ErrPRMerged),
+			errors.Is(err, errors2.ErrPRClosed),
+			errors.Is(err, errors2.ErrPRDraft):
+			h.logger.Error(op+": not open: ", req.PullRequestID, err)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: fmt.Sprintf("cannot %s: pull request is %s", op, strings.ToLower(strings.TrimPrefix(err.Error(), "PR_"))),
+				},
+			})
+		case errors.Is(err, errors2.ErrAlreadyAssigned), errors.Is(err, errors2.ErrNotAssigned):
+			h.logger.Error(op+": ", req, err)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: fmt.Sprintf("cannot %s: reviewer is %s", op, strings.ToLower(strings.ReplaceAll(err.Error(), "_", " "))),
+				},
+			})
+		case errors.Is(err, errors2.ErrInvalidReviewers):
+			h.logger.Error(op+": invalid reviewer: ", err)
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInvalidReviewers.Error(),
+					Message: err.Error(),
+				},
+			})
+		default:
+			h.logger.Error(op+": service error: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	h.logger.Info(op+" success: ", fiber.Map{
+		"pull_request_id": pr.ID,
+		"user_id":         req.UserID,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(dto.PRResponse{
+		PR: *pr,
+	})
+}
+
+func (h *PRHandler) ReviewPR(c fiber.Ctx) error {
+	var req dto.ReviewRequest
+
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error("review PR: failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	req.PullRequestID = strings.TrimSpace(req.PullRequestID)
+	req.ReviewerID = strings.TrimSpace(req.ReviewerID)
+	req.Verdict = strings.ToUpper(strings.TrimSpace(req.Verdict))
+	if req.PullRequestID == "" || req.ReviewerID == "" || req.Verdict == "" {
+		h.logger.Error("review PR: missing fields: ", req)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "all fields are required",
+			},
+		})
+	}
+
+	switch req.Verdict {
+	case dto.VerdictApproved, dto.VerdictChangesRequested, dto.VerdictCommented:
+	default:
+		h.logger.Error("review PR: unknown verdict: ", req.Verdict)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "verdict must be one of APPROVED, CHANGES_REQUESTED, COMMENTED",
+			},
+		})
+	}
+
+	pr, err := h.service.SubmitReview(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			h.logger.Error("review PR: not found: ", req.PullRequestID)
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "resource not found",
+				},
+			})
+		case errors.Is(err, errors2.ErrNotAssigned):
+			h.logger.Error("review PR: not assigned: ", req)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "reviewer is not assigned to this PR",
+				},
+			})
+		case errors.Is(err, errors2.ErrPRMerged),
+			errors.Is(err, errors2.ErrPRClosed),
+			errors.Is(err, errors2.ErrPRDraft):
+			h.logger.Error("review PR: PR is not open: ", req.PullRequestID)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "only open PRs can be reviewed",
+				},
+			})
+		default:
+			h.logger.Error("review PR: service error: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	h.logger.Info("review PR success: ", fiber.Map{
+		"pull_request_id": req.PullRequestID,
+		"reviewer_id":     req.ReviewerID,
+		"verdict":         req.Verdict,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(dto.PRResponse{
+		PR: *pr,
+	})
+}
+
+func (h *PRHandler) ClosePR(c fiber.Ctx) error {
+	return h.changeState(c, "close PR", h.service.Close)
+}
+
+func (h *PRHandler) ReopenPR(c fiber.Ctx) error {
+	return h.changeState(c, "reopen PR", h.service.Reopen)
+}
+
+func (h *PRHandler) MarkReadyPR(c fiber.Ctx) error {
+	return h.changeState(c, "mark PR ready", h.service.MarkReady)
+}
+
+// changeState handles the endpoints that only move a PR between statuses.
+func (h *PRHandler) changeState(
+	c fiber.Ctx,
+	op string,
+	change func(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error),
+) error {
+	var req dto.PRStateRequest
+
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error(op+": failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	req.PullRequestID = strings.TrimSpace(req.PullRequestID)
+	if req.PullRequestID == "" {
+		h.logger.Error(op + ": empty pull_request_id")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "missing pull request id",
+			},
+		})
+	}
+
+	pr, err := change(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			h.logger.Error(op+": not found: ", req.PullRequestID)
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "resource not found",
+				},
+			})
+		case errors.Is(err, errors2.ErrPRMerged),
+			errors.Is(err, errors2.ErrPRClosed),
+			errors.Is(err, errors2.ErrPRDraft):
+			h.logger.Error(op+": invalid transition: ", req.PullRequestID, err)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: fmt.Sprintf("cannot %s: pull request is %s", op, strings.ToLower(strings.TrimPrefix(err.Error(), "PR_"))),
+				},
+			})
+		default:
+			h.logger.Error(op+": service error: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	h.logger.Info(op+" success: ", pr.ID)
+
+	return c.Status(fiber.StatusOK).JSON(dto.PRResponse{
+		PR: *pr,
+	})
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func (h *PRHandler) ListPRs(c fiber.Ctx) error {
+	req, msg := parsePRListQuery(c)
+	if msg != "" {
+		h.logger.Error("list PRs: invalid query: ", msg)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: msg,
+			},
+		})
+	}
+
+	resp, err := h.service.List(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrBadRequest):
+			h.logger.Error("list PRs: bad request: ", err)
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrBadRequest.Error(),
+					Message: err.Error(),
+				},
+			})
+		default:
+			h.logger.Error("list PRs: service error: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// parsePRListQuery reads the filters of /pullRequest/list and returns why they
+// are invalid, or an empty string.
+func parsePRListQuery(c fiber.Ctx) (dto.PRListRequest, string) {
+	req := dto.PRListRequest{
+		PRListFilter: dto.PRListFilter{
+			AuthorID:   strings.TrimSpace(c.Query("author_id")),
+			ReviewerID: strings.TrimSpace(c.Query("reviewer_id")),
+			TeamName:   strings.TrimSpace(c.Query("team_name")),
+			SortBy:     dto.PRSortCreatedAt,
+			Desc:       true,
+		},
+		Cursor: strings.TrimSpace(c.Query("cursor")),
+	}
+
+	seen := make(map[string]bool)
+	for _, status := range strings.Split(c.Query("status"), ",") {
+		status = strings.ToUpper(strings.TrimSpace(status))
+		switch status {
+		case "":
+			continue
+		case dto.PRStatusOpen, dto.PRStatusMerged, dto.PRStatusClosed, dto.PRStatusDraft:
+		default:
+			return req, fmt.Sprintf("unknown status: %s", status)
+		}
+		if !seen[status] {
+			seen[status] = true
+			req.Statuses = append(req.Statuses, status)
+		}
+	}
+
+	for _, bound := range []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"created_from", &req.CreatedFrom},
+		{"created_to", &req.CreatedTo},
+	} {
+		raw := strings.TrimSpace(c.Query(bound.name))
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return req, fmt.Sprintf("%s must be an RFC 3339 time, e.g. 2025-01-15T09:00:00Z", bound.name)
+		}
+		t = t.UTC()
+		*bound.dst = &t
+	}
+	if req.CreatedFrom != nil && req.CreatedTo != nil && !req.CreatedFrom.Before(*req.CreatedTo) {
+		return req, "created_from must be before created_to"
+	}
+
+	switch sortBy := strings.TrimSpace(c.Query("sort")); sortBy {
+	case "":
+	case dto.PRSortCreatedAt, dto.PRSortUpdatedAt:
+		req.SortBy = sortBy
+	default:
+		return req, "sort must be created_at or updated_at"
+	}
+
+	switch order := strings.ToLower(strings.TrimSpace(c.Query("order"))); order {
+	case "", "desc":
+	case "asc":
+		req.Desc = false
+	default:
+		return req, "order must be asc or desc"
+	}
+
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > services.MaxPRListLimit {
+			return req, fmt.Sprintf("limit must be between 1 and %d", services.MaxPRListLimit)
+		}
+		req.Limit = limit
+	}
+
+	return req, ""
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+type StatsHandler struct {
+	service services.StatsService
+	logger  *zap.SugaredLogger
+}
+
+func NewStatsHandler(service services.StatsService, logger *zap.SugaredLogger) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *StatsHandler) Pairings(c fiber.Ctx) error {
+	teamName := strings.TrimSpace(c.Query("team_name"))
+	if teamName == "" {
+		h.logger.Error("pairing stats: empty team name")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "team_name can't be empty",
+			},
+		})
+	}
+
+	resp, err := h.service.Pairings(c.Context(), teamName)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			h.logger.Error("pairing stats: team not found: ", err)
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrNotFound.Error(),
+					Message: "resource not found",
+				},
+			})
+		default:
+			h.logger.Error("pairing stats: service error: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
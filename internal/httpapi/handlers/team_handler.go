@@ -3,7 +3,6 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"pr-reviwer-assigner/internal/domain/dto"
 	"pr-reviwer-assigner/internal/domain/services"
 	errors2 "pr-reviwer-assigner/internal/errors"
@@ -38,61 +37,19 @@ func (h *TeamHandler) Add(c fiber.Ctx) error {
 		})
 	}
 
-	req.Name = strings.TrimSpace(req.Name)
-	if req.Name == "" {
-		h.logger.Error("team add: empty team_name")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: dto.Error{
-				Code:    errors2.ErrBadRequest.Error(),
-				Message: "name can't be empty",
-			},
-		})
-	}
-
-	if len(req.Members) == 0 {
-		h.logger.Error("team add: members empty")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: dto.Error{
-				Code:    errors2.ErrBadRequest.Error(),
-				Message: "members can't be empty",
-			},
-		})
-	}
-
-	seen := make(map[string]struct{})
-	for i, m := range req.Members {
-		m.ID = strings.TrimSpace(m.ID)
-		m.Name = strings.TrimSpace(m.Name)
-
-		if m.ID == "" || m.Name == "" {
-			h.logger.Error("team add: member empty fields: ", i)
-			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-				Error: dto.Error{
-					Code:    errors2.ErrBadRequest.Error(),
-					Message: fmt.Sprintf("member[%d]: user_id and username can't be empty", i),
-				},
-			})
-		}
+	ctx := c.Context()
 
-		if _, ok := seen[m.ID]; ok {
-			h.logger.Error("team add: duplicate member: ", m.ID)
+	team, err := h.teamService.Add(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrBadRequest):
+			h.logger.Error("team add: bad request: ", err)
 			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
 				Error: dto.Error{
 					Code:    errors2.ErrBadRequest.Error(),
-					Message: fmt.Sprintf("duplicate user_id in members: %s", m.ID),
+					Message: err.Error(),
 				},
 			})
-		}
-		seen[m.ID] = struct{}{}
-
-		req.Members[i] = m
-	}
-
-	ctx := c.Context()
-
-	err := h.teamService.Add(ctx, req)
-	if err != nil {
-		switch {
 		case errors.Is(err, errors2.ErrTeamExists):
 			h.logger.Error("team add: team exists: ", req.Name)
 			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
@@ -101,6 +58,22 @@ func (h *TeamHandler) Add(c fiber.Ctx) error {
 					Message: "team_name already exists",
 				},
 			})
+		case errors.Is(err, errors2.ErrNotFound):
+			h.logger.Error("team add: fallback team not found: ", req.FallbackTeams)
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrNotFound.Error(),
+					Message: "fallback team not found",
+				},
+			})
+		case errors.Is(err, errors2.ErrInvalidReviewersCount):
+			h.logger.Error("team add: invalid reviewers bounds: ", err)
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInvalidReviewersCount.Error(),
+					Message: "expected min_reviewers <= reviewers_count <= max_reviewers",
+				},
+			})
 		default:
 			h.logger.Error("team add: service error: ", err)
 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
@@ -113,9 +86,9 @@ func (h *TeamHandler) Add(c fiber.Ctx) error {
 	}
 
 	response := dto.TeamResponse{
-		Team: req,
+		Team: *team,
 	}
-	h.logger.Info("team add success: ", req.Name)
+	h.logger.Info("team add success: ", team.Name)
 
 	return c.Status(fiber.StatusCreated).JSON(response)
 }
@@ -232,14 +205,6 @@ func (h *TeamHandler) DeactivateMembers(c fiber.Ctx) error {
 					Message: "resource not found",
 				},
 			})
-		case errors.Is(err, errors2.ErrNoCandidate):
-			h.logger.Error("team deactivate: no candidate: ", err)
-			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
-				Error: dto.Error{
-					Code:    errors2.ErrNoCandidate.Error(),
-					Message: "no active replacement candidate in team",
-				},
-			})
 		default:
 			h.logger.Error("team deactivate: internal error: ", err)
 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
@@ -251,7 +216,108 @@ func (h *TeamHandler) DeactivateMembers(c fiber.Ctx) error {
 		}
 	}
 
+	if len(resp.NotReassigned) > 0 {
+		h.logger.Error("team deactivate: reviews not reassigned: ", resp.NotReassigned)
+	}
 	h.logger.Info("team deactivate success: ", resp)
 
 	return c.Status(fiber.StatusOK).JSON(resp)
 }
+
+func (h *TeamHandler) GetSettings(c fiber.Ctx) error {
+	teamName := strings.TrimSpace(c.Query("team_name"))
+	if teamName == "" {
+		h.logger.Error("team settings get: empty team_name")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "team_name can't be empty",
+			},
+		})
+	}
+
+	settings, err := h.teamService.GetSettings(c.Context(), teamName)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			h.logger.Error("team settings get: not found: ", err)
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrNotFound.Error(),
+					Message: "resource not found",
+				},
+			})
+		default:
+			h.logger.Error("team settings get: service error: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	h.logger.Info("team settings get success: ", teamName)
+
+	return c.Status(fiber.StatusOK).JSON(dto.TeamSettingsResponse{
+		Settings: *settings,
+	})
+}
+
+func (h *TeamHandler) UpdateSettings(c fiber.Ctx) error {
+	var req dto.TeamSettingsRequest
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error("team settings update: failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	settings, err := h.teamService.UpdateSettings(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrBadRequest):
+			h.logger.Error("team settings update: bad request: ", err)
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrBadRequest.Error(),
+					Message: err.Error(),
+				},
+			})
+		case errors.Is(err, errors2.ErrNotFound):
+			h.logger.Error("team settings update: not found: ", err)
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrNotFound.Error(),
+					Message: "resource not found",
+				},
+			})
+		case errors.Is(err, errors2.ErrInvalidReviewersCount):
+			h.logger.Error("team settings update: invalid reviewers counts: ", err)
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInvalidReviewersCount.Error(),
+					Message: err.Error(),
+				},
+			})
+		default:
+			h.logger.Error("team settings update: service error: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	h.logger.Info("team settings update success: ", settings)
+
+	return c.Status(fiber.StatusOK).JSON(dto.TeamSettingsResponse{
+		Settings: *settings,
+	})
+}
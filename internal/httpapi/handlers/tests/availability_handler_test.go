@@ -0,0 +1,111 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"pr-reviwer-assigner/internal/httpapi/handlers"
+)
+
+type availabilityServiceMock struct {
+	addFn func(window dto.Unavailability) (*dto.Unavailability, error)
+}
+
+func (m *availabilityServiceMock) Add(ctx context.Context, window dto.Unavailability) (*dto.Unavailability, error) {
+	return m.addFn(window)
+}
+
+func (m *availabilityServiceMock) List(ctx context.Context, userID string) ([]dto.Unavailability, error) {
+	return []dto.Unavailability{}, nil
+}
+
+func (m *availabilityServiceMock) Update(ctx context.Context, window dto.Unavailability) (*dto.Unavailability, error) {
+	return &window, nil
+}
+
+func (m *availabilityServiceMock) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (m *availabilityServiceMock) ImportICS(ctx context.Context, userID string, r io.Reader) (*dto.ICSImportResponse, error) {
+	return &dto.ICSImportResponse{}, nil
+}
+
+func (m *availabilityServiceMock) ImportICSFile(ctx context.Context, req dto.ICSImportRequest) (*dto.ICSImportResponse, error) {
+	return &dto.ICSImportResponse{}, nil
+}
+
+func newAvailabilityApp(svc *availabilityServiceMock) *fiber.App {
+	app := fiber.New()
+	h := handlers.NewAvailabilityHandler(svc, zap.NewNop().Sugar())
+	app.Post("/users/availability", h.Add)
+	return app
+}
+
+func postAvailability(t *testing.T, app *fiber.App, body string) int {
+	req := httptest.NewRequest("POST", "/users/availability", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	return resp.StatusCode
+}
+
+func TestAvailabilityHandlerAdd_Success(t *testing.T) {
+	svc := &availabilityServiceMock{
+		addFn: func(window dto.Unavailability) (*dto.Unavailability, error) {
+			require.Equal(t, "u1", window.UserID)
+			require.Equal(t, "vacation", window.Reason)
+			require.True(t, window.StartsAt.Equal(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)))
+			window.ID = 3
+			return &window, nil
+		},
+	}
+	app := newAvailabilityApp(svc)
+
+	req := httptest.NewRequest("POST", "/users/availability", bytes.NewReader([]byte(
+		`{"user_id":"u1","starts_at":"2025-07-01T00:00:00Z","ends_at":"2025-07-15T00:00:00Z","reason":" vacation "}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	var out dto.UnavailabilityResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Equal(t, int64(3), out.Window.ID)
+}
+
+func TestAvailabilityHandlerAdd_Validation(t *testing.T) {
+	app := newAvailabilityApp(&availabilityServiceMock{})
+
+	require.Equal(t, fiber.StatusBadRequest, postAvailability(t, app,
+		`{"starts_at":"2025-07-01T00:00:00Z","ends_at":"2025-07-15T00:00:00Z"}`))
+	require.Equal(t, fiber.StatusBadRequest, postAvailability(t, app,
+		`{"user_id":"u1","ends_at":"2025-07-15T00:00:00Z"}`))
+	require.Equal(t, fiber.StatusBadRequest, postAvailability(t, app,
+		`{"user_id":"u1","starts_at":"2025-07-15T00:00:00Z","ends_at":"2025-07-01T00:00:00Z"}`))
+}
+
+func TestAvailabilityHandlerAdd_UserNotFound(t *testing.T) {
+	svc := &availabilityServiceMock{
+		addFn: func(window dto.Unavailability) (*dto.Unavailability, error) {
+			return nil, errors2.ErrNotFound
+		},
+	}
+	app := newAvailabilityApp(svc)
+
+	require.Equal(t, fiber.StatusNotFound, postAvailability(t, app,
+		`{"user_id":"ghost","starts_at":"2025-07-01T00:00:00Z","ends_at":"2025-07-15T00:00:00Z"}`))
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http/httptest"
 	"pr-reviwer-assigner/internal/httpapi/handlers"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/stretchr/testify/require"
@@ -18,8 +20,16 @@ import (
 
 type prServiceMock struct {
 	createFn   func(ctx context.Context, req dto.PRRequest) (dto.PR, error)
+	getFn      func(ctx context.Context, prID string) (*dto.PR, error)
+	listFn     func(ctx context.Context, req dto.PRListRequest) (*dto.PRListResponse, error)
 	mergeFn    func(ctx context.Context, req dto.MergeRequest) (*dto.PR, error)
 	reassignFn func(ctx context.Context, req dto.ReassignRequest) (*dto.PR, string, error)
+	closeFn    func(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error)
+	reopenFn   func(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error)
+	readyFn    func(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error)
+	reviewFn   func(ctx context.Context, req dto.ReviewRequest) (*dto.PR, error)
+	addFn      func(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error)
+	removeFn   func(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error)
 }
 
 func (m *prServiceMock) Create(ctx context.Context, req dto.PRRequest) (dto.PR, error) {
@@ -29,6 +39,20 @@ func (m *prServiceMock) Create(ctx context.Context, req dto.PRRequest) (dto.PR,
 	return m.createFn(ctx, req)
 }
 
+func (m *prServiceMock) Get(ctx context.Context, prID string) (*dto.PR, error) {
+	if m.getFn == nil {
+		return &dto.PR{ID: prID, Status: dto.PRStatusOpen}, nil
+	}
+	return m.getFn(ctx, prID)
+}
+
+func (m *prServiceMock) List(ctx context.Context, req dto.PRListRequest) (*dto.PRListResponse, error) {
+	if m.listFn == nil {
+		return &dto.PRListResponse{PullRequests: []dto.PR{}}, nil
+	}
+	return m.listFn(ctx, req)
+}
+
 func (m *prServiceMock) Merge(ctx context.Context, req dto.MergeRequest) (*dto.PR, error) {
 	if m.mergeFn == nil {
 		return nil, nil
@@ -36,6 +60,10 @@ func (m *prServiceMock) Merge(ctx context.Context, req dto.MergeRequest) (*dto.P
 	return m.mergeFn(ctx, req)
 }
 
+func (m *prServiceMock) RecordMerge(ctx context.Context, prID string) (*dto.PR, error) {
+	return &dto.PR{ID: prID, Status: dto.PRStatusMerged}, nil
+}
+
 func (m *prServiceMock) Reassign(ctx context.Context, req dto.ReassignRequest) (*dto.PR, string, error) {
 	if m.reassignFn == nil {
 		return nil, "", nil
@@ -43,6 +71,48 @@ func (m *prServiceMock) Reassign(ctx context.Context, req dto.ReassignRequest) (
 	return m.reassignFn(ctx, req)
 }
 
+func (m *prServiceMock) AddReviewer(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error) {
+	if m.addFn == nil {
+		return &dto.PR{ID: req.PullRequestID, Status: dto.PRStatusOpen, Reviewers: []string{req.UserID}}, nil
+	}
+	return m.addFn(ctx, req)
+}
+
+func (m *prServiceMock) RemoveReviewer(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error) {
+	if m.removeFn == nil {
+		return &dto.PR{ID: req.PullRequestID, Status: dto.PRStatusOpen}, nil
+	}
+	return m.removeFn(ctx, req)
+}
+
+func (m *prServiceMock) Close(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error) {
+	if m.closeFn == nil {
+		return &dto.PR{ID: req.PullRequestID, Status: dto.PRStatusClosed}, nil
+	}
+	return m.closeFn(ctx, req)
+}
+
+func (m *prServiceMock) Reopen(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error) {
+	if m.reopenFn == nil {
+		return &dto.PR{ID: req.PullRequestID, Status: dto.PRStatusOpen}, nil
+	}
+	return m.reopenFn(ctx, req)
+}
+
+func (m *prServiceMock) MarkReady(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error) {
+	if m.readyFn == nil {
+		return &dto.PR{ID: req.PullRequestID, Status: dto.PRStatusOpen}, nil
+	}
+	return m.readyFn(ctx, req)
+}
+
+func (m *prServiceMock) SubmitReview(ctx context.Context, req dto.ReviewRequest) (*dto.PR, error) {
+	if m.reviewFn == nil {
+		return &dto.PR{ID: req.PullRequestID, Status: dto.PRStatusOpen}, nil
+	}
+	return m.reviewFn(ctx, req)
+}
+
 func TestPRHandlerCreate_Success(t *testing.T) {
 	app := fiber.New()
 	mockSvc := &prServiceMock{
@@ -143,6 +213,7 @@ func TestPRHandlerReassign_Conflict(t *testing.T) {
 
 func TestPRHandlerReassign_Success(t *testing.T) {
 	app := fiber.New()
+	created := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
 	mockSvc := &prServiceMock{
 		reassignFn: func(ctx context.Context, req dto.ReassignRequest) (*dto.PR, string, error) {
 			return &dto.PR{
@@ -151,6 +222,8 @@ func TestPRHandlerReassign_Success(t *testing.T) {
 				AuthorID:  "u1",
 				Status:    "OPEN",
 				Reviewers: []string{"u3"},
+				CreatedAt: created,
+				UpdatedAt: created,
 			}, "u3", nil
 		},
 	}
@@ -167,4 +240,386 @@ func TestPRHandlerReassign_Success(t *testing.T) {
 	var body dto.ReassignResponse
 	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
 	require.Equal(t, "u3", body.ReplacedBy)
+	require.Equal(t, created, body.PR.CreatedAt)
+	require.Equal(t, created, body.PR.UpdatedAt)
+}
+
+func TestPRHandlerCreate_InvalidReviewersCount(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &prServiceMock{
+		createFn: func(ctx context.Context, req dto.PRRequest) (dto.PR, error) {
+			require.NotNil(t, req.ReviewersCount)
+			require.Equal(t, 9, *req.ReviewersCount)
+			return dto.PR{}, errors2.ErrInvalidReviewersCount
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/pullRequest/create", h.CreatePR)
+
+	payload := []byte(`{"pull_request_id":"pr-1","pull_request_name":"Add","author_id":"u1","reviewers_count":9}`)
+	req := httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var body dto.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, errors2.ErrInvalidReviewersCount.Error(), body.Error.Code)
+}
+
+func TestPRHandlerCreate_BadRequest(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &prServiceMock{
+		createFn: func(ctx context.Context, req dto.PRRequest) (dto.PR, error) {
+			return dto.PR{}, fmt.Errorf("%w: skill can't contain spaces: big data", errors2.ErrBadRequest)
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/pullRequest/create", h.CreatePR)
+
+	payload := []byte(`{"pull_request_id":"pr-1","pull_request_name":"Add","author_id":"u1","required_skills":["big data"]}`)
+	req := httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var body dto.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, errors2.ErrBadRequest.Error(), body.Error.Code)
+	require.Equal(t, "BAD_REQUEST: skill can't contain spaces: big data", body.Error.Message)
+}
+
+func TestPRHandlerCreate_InactiveRequiredReviewer(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &prServiceMock{
+		createFn: func(ctx context.Context, req dto.PRRequest) (dto.PR, error) {
+			return dto.PR{}, fmt.Errorf("%w: required reviewer sec is inactive", errors2.ErrInvalidReviewers)
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/pullRequest/create", h.CreatePR)
+
+	payload := []byte(`{"pull_request_id":"pr-1","pull_request_name":"Add","author_id":"u1","required_reviewers":["sec","sec",""]}`)
+	req := httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var body dto.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "INVALID_REVIEWERS: required reviewer sec is inactive", body.Error.Message)
+}
+
+func TestPRHandlerClose_Success(t *testing.T) {
+	app := fiber.New()
+	h := handlers.NewPRHandler(&prServiceMock{}, zap.NewNop().Sugar())
+	app.Post("/pullRequest/close", h.ClosePR)
+
+	req := httptest.NewRequest("POST", "/pullRequest/close", bytes.NewReader([]byte(`{"pull_request_id":"pr-1"}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body dto.PRResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, dto.PRStatusClosed, body.PR.Status)
+}
+
+func TestPRHandlerMarkReady_Conflict(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &prServiceMock{
+		readyFn: func(ctx context.Context, req dto.PRStateRequest) (*dto.PR, error) {
+			return nil, errors2.ErrPRMerged
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/pullRequest/markReady", h.MarkReadyPR)
+
+	req := httptest.NewRequest("POST", "/pullRequest/markReady", bytes.NewReader([]byte(`{"pull_request_id":"pr-1"}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	var body dto.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "PR_MERGED", body.Error.Code)
+}
+
+func TestPRHandlerMerge_Draft(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &prServiceMock{
+		mergeFn: func(ctx context.Context, req dto.MergeRequest) (*dto.PR, error) {
+			return nil, errors2.ErrPRDraft
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/pullRequest/merge", h.MergePR)
+
+	req := httptest.NewRequest("POST", "/pullRequest/merge", bytes.NewReader([]byte(`{"pull_request_id":"pr-1"}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
+
+func TestPRHandlerReview_Success(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &prServiceMock{
+		reviewFn: func(ctx context.Context, req dto.ReviewRequest) (*dto.PR, error) {
+			return &dto.PR{
+				ID:        req.PullRequestID,
+				Status:    dto.PRStatusOpen,
+				Reviewers: []string{req.ReviewerID},
+				Assignments: []dto.ReviewerAssignment{
+					{UserID: req.ReviewerID, TeamName: "backend", Verdict: req.Verdict},
+				},
+			}, nil
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/pullRequest/review", h.ReviewPR)
+
+	payload := []byte(`{"pull_request_id":"pr-1","reviewer_id":"u2","verdict":"approved"}`)
+	req := httptest.NewRequest("POST", "/pullRequest/review", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var out dto.PRResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Len(t, out.PR.Assignments, 1)
+	require.Equal(t, dto.VerdictApproved, out.PR.Assignments[0].Verdict)
+}
+
+func TestPRHandlerReview_UnknownVerdict(t *testing.T) {
+	app := fiber.New()
+	h := handlers.NewPRHandler(&prServiceMock{}, zap.NewNop().Sugar())
+	app.Post("/pullRequest/review", h.ReviewPR)
+
+	payload := []byte(`{"pull_request_id":"pr-1","reviewer_id":"u2","verdict":"LGTM"}`)
+	req := httptest.NewRequest("POST", "/pullRequest/review", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestPRHandlerMerge_NotEnoughApprovals(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &prServiceMock{
+		mergeFn: func(ctx context.Context, req dto.MergeRequest) (*dto.PR, error) {
+			return nil, fmt.Errorf("%w: 0 of 1", errors2.ErrNotEnoughApprovals)
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/pullRequest/merge", h.MergePR)
+
+	payload := []byte(`{"pull_request_id":"pr-1"}`)
+	req := httptest.NewRequest("POST", "/pullRequest/merge", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	var out dto.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Equal(t, errors2.ErrNotEnoughApprovals.Error(), out.Error.Code)
+}
+
+func TestPRHandlerAddReviewer_Success(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &prServiceMock{
+		addFn: func(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error) {
+			require.Equal(t, dto.ReviewerRequest{PullRequestID: "pr-1", UserID: "sec"}, req)
+			return &dto.PR{ID: "pr-1", Status: dto.PRStatusOpen, Reviewers: []string{"sec", "u2"}}, nil
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/pullRequest/addReviewer", h.AddReviewer)
+
+	req := httptest.NewRequest("POST", "/pullRequest/addReviewer", bytes.NewReader([]byte(`{"pull_request_id":"pr-1","user_id":" sec "}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body dto.PRResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, []string{"sec", "u2"}, body.PR.Reviewers)
+}
+
+func TestPRHandlerAddReviewer_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{errors2.ErrPRMerged, fiber.StatusConflict, "PR_MERGED"},
+		{errors2.ErrAlreadyAssigned, fiber.StatusConflict, "ALREADY_ASSIGNED"},
+		{fmt.Errorf("%w: reviewer u1 is the author of the pull request", errors2.ErrInvalidReviewers), fiber.StatusBadRequest, "INVALID_REVIEWERS"},
+		{errors2.ErrNotFound, fiber.StatusNotFound, "NOT_FOUND"},
+	} {
+		app := fiber.New()
+		mockSvc := &prServiceMock{
+			addFn: func(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error) {
+				return nil, tc.err
+			},
+		}
+		h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+		app.Post("/pullRequest/addReviewer", h.AddReviewer)
+
+		req := httptest.NewRequest("POST", "/pullRequest/addReviewer", bytes.NewReader([]byte(`{"pull_request_id":"pr-1","user_id":"u1"}`)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, tc.status, resp.StatusCode, tc.code)
+
+		var body dto.ErrorResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Equal(t, tc.code, body.Error.Code)
+	}
+}
+
+func TestPRHandlerRemoveReviewer_NotAssigned(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &prServiceMock{
+		removeFn: func(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error) {
+			return nil, errors2.ErrNotAssigned
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/pullRequest/removeReviewer", h.RemoveReviewer)
+
+	req := httptest.NewRequest("POST", "/pullRequest/removeReviewer", bytes.NewReader([]byte(`{"pull_request_id":"pr-1","user_id":"u9"}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
+
+func TestPRHandlerReassign_RejectsSameNewUser(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &prServiceMock{
+		reassignFn: func(ctx context.Context, req dto.ReassignRequest) (*dto.PR, string, error) {
+			t.Fatal("service must not be called")
+			return nil, "", nil
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/pullRequest/reassign", h.ReassignViewer)
+
+	req := httptest.NewRequest("POST", "/pullRequest/reassign", bytes.NewReader([]byte(`{"pull_request_id":"pr-1","old_user_id":"u2","new_user_id":"u2"}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestPRHandlerList_ParsesQuery(t *testing.T) {
+	app := fiber.New()
+	var got dto.PRListRequest
+	mockSvc := &prServiceMock{
+		listFn: func(ctx context.Context, req dto.PRListRequest) (*dto.PRListResponse, error) {
+			got = req
+			return &dto.PRListResponse{PullRequests: []dto.PR{{ID: "pr-1"}}, NextCursor: "next"}, nil
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Get("/pullRequest/list", h.ListPRs)
+
+	req := httptest.NewRequest("GET", "/pullRequest/list?status=open,MERGED,open&reviewer_id=u2&team_name=backend"+
+		"&created_from=2025-01-01T00:00:00Z&created_to=2025-02-01T03:00:00%2B03:00&sort=updated_at&order=asc&limit=10&cursor=abc", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	require.Equal(t, []string{"OPEN", "MERGED"}, got.Statuses)
+	require.Equal(t, "u2", got.ReviewerID)
+	require.Equal(t, "backend", got.TeamName)
+	require.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), *got.CreatedFrom)
+	require.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), *got.CreatedTo)
+	require.Equal(t, dto.PRSortUpdatedAt, got.SortBy)
+	require.False(t, got.Desc)
+	require.Equal(t, 10, got.Limit)
+	require.Equal(t, "abc", got.Cursor)
+
+	var out dto.PRListResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Equal(t, "next", out.NextCursor)
+	require.Len(t, out.PullRequests, 1)
+}
+
+func TestPRHandlerList_Defaults(t *testing.T) {
+	app := fiber.New()
+	var got dto.PRListRequest
+	mockSvc := &prServiceMock{
+		listFn: func(ctx context.Context, req dto.PRListRequest) (*dto.PRListResponse, error) {
+			got = req
+			return &dto.PRListResponse{PullRequests: []dto.PR{}}, nil
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Get("/pullRequest/list", h.ListPRs)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/pullRequest/list", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.Equal(t, dto.PRSortCreatedAt, got.SortBy)
+	require.True(t, got.Desc)
+	require.Empty(t, got.Statuses)
+	require.Zero(t, got.Limit)
+}
+
+func TestPRHandlerList_BadRequest(t *testing.T) {
+	for _, query := range []string{
+		"status=REVIEWING",
+		"limit=0",
+		"limit=1000",
+		"sort=name",
+		"order=up",
+		"created_from=yesterday",
+		"created_from=2025-02-01T00:00:00Z&created_to=2025-01-01T00:00:00Z",
+	} {
+		app := fiber.New()
+		h := handlers.NewPRHandler(&prServiceMock{}, zap.NewNop().Sugar())
+		app.Get("/pullRequest/list", h.ListPRs)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/pullRequest/list?"+query, nil))
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestPRHandlerList_InvalidCursor(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &prServiceMock{
+		listFn: func(ctx context.Context, req dto.PRListRequest) (*dto.PRListResponse, error) {
+			return nil, fmt.Errorf("%w: malformed cursor", errors2.ErrBadRequest)
+		},
+	}
+	h := handlers.NewPRHandler(mockSvc, zap.NewNop().Sugar())
+	app.Get("/pullRequest/list", h.ListPRs)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/pullRequest/list?cursor=zzz", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http/httptest"
 	"pr-reviwer-assigner/internal/httpapi/handlers"
 	"testing"
@@ -17,14 +18,16 @@ import (
 )
 
 type teamServiceMock struct {
-	addFn        func(ctx context.Context, team dto.Team) error
-	getFn        func(teamName string) ([]dto.TeamMember, error)
-	deactivateFn func(ctx context.Context, req dto.TeamDeactivateRequest) (*dto.TeamDeactivateResponse, error)
+	addFn            func(ctx context.Context, team dto.Team) (*dto.Team, error)
+	getFn            func(teamName string) ([]dto.TeamMember, error)
+	deactivateFn     func(ctx context.Context, req dto.TeamDeactivateRequest) (*dto.TeamDeactivateResponse, error)
+	getSettingsFn    func(ctx context.Context, teamName string) (*dto.TeamSettings, error)
+	updateSettingsFn func(ctx context.Context, req dto.TeamSettingsRequest) (*dto.TeamSettings, error)
 }
 
-func (m *teamServiceMock) Add(ctx context.Context, team dto.Team) error {
+func (m *teamServiceMock) Add(ctx context.Context, team dto.Team) (*dto.Team, error) {
 	if m.addFn == nil {
-		return nil
+		return &team, nil
 	}
 	return m.addFn(ctx, team)
 }
@@ -43,6 +46,20 @@ func (m *teamServiceMock) DeactivateMembers(ctx context.Context, req dto.TeamDea
 	return m.deactivateFn(ctx, req)
 }
 
+func (m *teamServiceMock) GetSettings(ctx context.Context, teamName string) (*dto.TeamSettings, error) {
+	if m.getSettingsFn == nil {
+		return &dto.TeamSettings{TeamName: teamName}, nil
+	}
+	return m.getSettingsFn(ctx, teamName)
+}
+
+func (m *teamServiceMock) UpdateSettings(ctx context.Context, req dto.TeamSettingsRequest) (*dto.TeamSettings, error) {
+	if m.updateSettingsFn == nil {
+		return &dto.TeamSettings{TeamName: req.TeamName}, nil
+	}
+	return m.updateSettingsFn(ctx, req)
+}
+
 func TestTeamHandlerGet_BadRequest(t *testing.T) {
 	app := fiber.New()
 	h := handlers.NewTeamHandler(&teamServiceMock{}, zap.NewNop().Sugar())
@@ -95,7 +112,12 @@ func TestTeamHandlerGet_Success(t *testing.T) {
 
 func TestTeamHandlerAdd_ValidationError(t *testing.T) {
 	app := fiber.New()
-	h := handlers.NewTeamHandler(&teamServiceMock{}, zap.NewNop().Sugar())
+	mockSvc := &teamServiceMock{
+		addFn: func(ctx context.Context, team dto.Team) (*dto.Team, error) {
+			return nil, fmt.Errorf("%w: team_name can't be empty", errors2.ErrBadRequest)
+		},
+	}
+	h := handlers.NewTeamHandler(mockSvc, zap.NewNop().Sugar())
 	app.Post("/team/add", h.Add)
 
 	payload := []byte(`{"team_name":"","members":[]}`)
@@ -105,17 +127,22 @@ func TestTeamHandlerAdd_ValidationError(t *testing.T) {
 	resp, err := app.Test(req)
 	require.NoError(t, err)
 	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var body dto.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, errors2.ErrBadRequest.Error(), body.Error.Code)
+	require.Equal(t, "BAD_REQUEST: team_name can't be empty", body.Error.Message)
 }
 
 func TestTeamHandlerAdd_Success(t *testing.T) {
 	app := fiber.New()
 	addCalled := false
 	mockSvc := &teamServiceMock{
-		addFn: func(ctx context.Context, team dto.Team) error {
+		addFn: func(ctx context.Context, team dto.Team) (*dto.Team, error) {
 			addCalled = true
 			require.Equal(t, "backend", team.Name)
 			require.Len(t, team.Members, 1)
-			return nil
+			return &team, nil
 		},
 	}
 	h := handlers.NewTeamHandler(mockSvc, zap.NewNop().Sugar())
@@ -175,3 +202,55 @@ func TestTeamHandlerDeactivateMembers_Success(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, fiber.StatusOK, resp.StatusCode)
 }
+
+func TestTeamHandlerUpdateSettings_Success(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &teamServiceMock{
+		updateSettingsFn: func(ctx context.Context, req dto.TeamSettingsRequest) (*dto.TeamSettings, error) {
+			require.Equal(t, "backend", req.TeamName)
+			require.NotNil(t, req.ReviewerStrategy)
+			return &dto.TeamSettings{
+				TeamName:         req.TeamName,
+				ReviewerStrategy: *req.ReviewerStrategy,
+			}, nil
+		},
+	}
+	h := handlers.NewTeamHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/team/settings", h.UpdateSettings)
+
+	payload := []byte(`{"team_name":"backend","reviewer_strategy":"least_loaded"}`)
+	req := httptest.NewRequest("POST", "/team/settings", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body dto.TeamSettingsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "least_loaded", body.Settings.ReviewerStrategy)
+}
+
+func TestTeamHandlerUpdateSettings_InvalidSettings(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &teamServiceMock{
+		updateSettingsFn: func(ctx context.Context, req dto.TeamSettingsRequest) (*dto.TeamSettings, error) {
+			return nil, fmt.Errorf("%w: pairing_window must be positive", errors2.ErrBadRequest)
+		},
+	}
+	h := handlers.NewTeamHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/team/settings", h.UpdateSettings)
+
+	payload := []byte(`{"team_name":"backend","pairing_window":0}`)
+	req := httptest.NewRequest("POST", "/team/settings", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var body dto.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "BAD_REQUEST", body.Error.Code)
+	require.Equal(t, "BAD_REQUEST: pairing_window must be positive", body.Error.Message)
+}
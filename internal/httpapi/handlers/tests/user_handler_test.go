@@ -2,7 +2,9 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http/httptest"
 	"pr-reviwer-assigner/internal/httpapi/handlers"
 	"testing"
@@ -18,6 +20,9 @@ import (
 type userServiceMock struct {
 	getReviewFn func(userID string) ([]dto.PRShort, error)
 	setFn       func(req dto.SIARequest) (*dto.User, error)
+	identityFn  func(identity dto.VCSIdentity) (*dto.VCSIdentity, error)
+	profileFn   func(req dto.ProfileRequest) (*dto.User, error)
+	skillsFn    func(req dto.UserSkills) (*dto.UserSkills, error)
 }
 
 func (m *userServiceMock) GetReview(userID string) ([]dto.PRShort, error) {
@@ -34,6 +39,35 @@ func (m *userServiceMock) SetIsActive(req dto.SIARequest) (*dto.User, error) {
 	return m.setFn(req)
 }
 
+func (m *userServiceMock) SetProfile(ctx context.Context, req dto.ProfileRequest) (*dto.User, error) {
+	if m.profileFn == nil {
+		return &dto.User{ID: req.ID}, nil
+	}
+	return m.profileFn(req)
+}
+
+func (m *userServiceMock) SetVCSIdentity(ctx context.Context, identity dto.VCSIdentity) (*dto.VCSIdentity, error) {
+	if m.identityFn == nil {
+		return &identity, nil
+	}
+	return m.identityFn(identity)
+}
+
+func (m *userServiceMock) ResolveVCSLogin(ctx context.Context, provider, login string) (string, error) {
+	return login, nil
+}
+
+func (m *userServiceMock) GetSkills(ctx context.Context, userID string) (*dto.UserSkills, error) {
+	return &dto.UserSkills{UserID: userID, Skills: []string{}}, nil
+}
+
+func (m *userServiceMock) SetSkills(ctx context.Context, req dto.UserSkills) (*dto.UserSkills, error) {
+	if m.skillsFn == nil {
+		return &req, nil
+	}
+	return m.skillsFn(req)
+}
+
 func TestUserHandlerSetIsActive_Success(t *testing.T) {
 	app := fiber.New()
 	mockSvc := &userServiceMock{
@@ -130,3 +164,94 @@ func TestUserHandlerGetReview_Success(t *testing.T) {
 	require.Equal(t, "u2", out.ID)
 	require.Len(t, out.PRs, 1)
 }
+
+func TestUserHandlerSetVCSIdentity_Success(t *testing.T) {
+	app := fiber.New()
+	h := handlers.NewUserHandler(&userServiceMock{}, zap.NewNop().Sugar())
+	app.Post("/users/setVcsIdentity", h.SetVCSIdentity)
+
+	payload := []byte(`{"provider":"GitHub","login":"alice-dev","user_id":"u1"}`)
+	req := httptest.NewRequest("POST", "/users/setVcsIdentity", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body dto.VCSIdentityResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "github", body.Identity.Provider)
+	require.Equal(t, "u1", body.Identity.UserID)
+}
+
+func TestUserHandlerSetProfile_NormalizesChatHandle(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &userServiceMock{
+		profileFn: func(req dto.ProfileRequest) (*dto.User, error) {
+			require.NotNil(t, req.ChatHandle)
+			return &dto.User{ID: req.ID, Name: "Alice", Team: "backend", IsActive: true, ChatHandle: *req.ChatHandle}, nil
+		},
+	}
+	h := handlers.NewUserHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/users/setProfile", h.SetProfile)
+
+	req := httptest.NewRequest("POST", "/users/setProfile", bytes.NewReader([]byte(`{"user_id":"u1","chat_handle":" @U024BE7LH "}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var out dto.UserResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Equal(t, "U024BE7LH", out.User.ChatHandle)
+}
+
+func TestUserHandlerSetProfile_RejectsInvalidWorkingHours(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &userServiceMock{
+		profileFn: func(req dto.ProfileRequest) (*dto.User, error) {
+			t.Fatal("service must not be called")
+			return nil, nil
+		},
+	}
+	h := handlers.NewUserHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/users/setProfile", h.SetProfile)
+
+	for _, body := range []string{
+		`{"user_id":"u1","working_hours":{"time_zone":"Mars/Olympus"}}`,
+		`{"user_id":"u1","working_hours":{"start":"9am"}}`,
+		`{"user_id":"u1","working_hours":{"days":[]}}`,
+		`{"user_id":"u1","working_hours":{"days":[0,1]}}`,
+	} {
+		req := httptest.NewRequest("POST", "/users/setProfile", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode, body)
+	}
+}
+
+func TestUserHandlerSetSkills_RejectsInvalidTags(t *testing.T) {
+	app := fiber.New()
+	mockSvc := &userServiceMock{
+		skillsFn: func(req dto.UserSkills) (*dto.UserSkills, error) {
+			return nil, fmt.Errorf("%w: skill can't contain spaces: machine learning", errors2.ErrBadRequest)
+		},
+	}
+	h := handlers.NewUserHandler(mockSvc, zap.NewNop().Sugar())
+	app.Post("/users/skills", h.SetSkills)
+
+	for _, body := range []string{
+		`{"user_id":"u1","skills":["machine learning"]}`,
+		`{"skills":["go"]}`,
+	} {
+		req := httptest.NewRequest("POST", "/users/skills", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,168 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"pr-reviwer-assigner/internal/config"
+	"pr-reviwer-assigner/internal/domain/dto"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"pr-reviwer-assigner/internal/httpapi/handlers"
+	"pr-reviwer-assigner/internal/vcs"
+)
+
+const gitlabMRMerged = `{"object_kind":"merge_request","user":{"username":"dan.park"},"project":{"path_with_namespace":"fintech/payments"},"object_attributes":{"iid":7,"title":"Retry failed captures","action":"merge"}}`
+
+const githubPROpened = `{"action":"opened","pull_request":{"number":7,"title":"Fix login","draft":false,"user":{"login":"alice-dev"}},"repository":{"full_name":"acme/web"}}`
+
+type applierMock struct {
+	applyFn func(ev vcs.Event) (vcs.Result, error)
+	events  []vcs.Event
+}
+
+func (m *applierMock) Apply(ctx context.Context, ev vcs.Event) (vcs.Result, error) {
+	m.events = append(m.events, ev)
+	if m.applyFn == nil {
+		return vcs.Result{Status: vcs.StatusApplied, PR: &dto.PR{ID: ev.PullRequestID}}, nil
+	}
+	return m.applyFn(ev)
+}
+
+func newGitHubRequest(secret, event, body string) *http.Request {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	req := httptest.NewRequest("POST", "/webhooks/github", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func newWebhookApp(applier vcs.Applier) *fiber.App {
+	app := fiber.New()
+	cfg := config.WebhooksConfig{
+		GitHub: config.GitHubWebhookConfig{Secret: "s3cret"},
+		GitLab: config.GitLabWebhookConfig{Token: "gl-token"},
+	}
+	h := handlers.NewWebhookHandler(applier, cfg, zap.NewNop().Sugar())
+	app.Post("/webhooks/github", h.GitHub)
+	app.Post("/webhooks/gitlab", h.GitLab)
+	return app
+}
+
+func TestWebhookHandlerGitHub_Opened(t *testing.T) {
+	applier := &applierMock{}
+	app := newWebhookApp(applier)
+
+	resp, err := app.Test(newGitHubRequest("s3cret", "pull_request", githubPROpened))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var out dto.WebhookResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Equal(t, vcs.StatusApplied, out.Status)
+	require.Equal(t, "acme/web#7", out.PullRequestID)
+
+	require.Len(t, applier.events, 1)
+	require.Equal(t, vcs.ActionOpened, applier.events[0].Action)
+	require.Equal(t, "alice-dev", applier.events[0].AuthorLogin)
+}
+
+func TestWebhookHandlerGitHub_BadSignature(t *testing.T) {
+	applier := &applierMock{}
+	app := newWebhookApp(applier)
+
+	resp, err := app.Test(newGitHubRequest("wrong", "pull_request", githubPROpened))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	require.Empty(t, applier.events)
+}
+
+func TestWebhookHandlerGitHub_Ping(t *testing.T) {
+	applier := &applierMock{}
+	app := newWebhookApp(applier)
+
+	resp, err := app.Test(newGitHubRequest("s3cret", "ping", `{"zen":"Design for failure."}`))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.Empty(t, applier.events)
+}
+
+func TestWebhookHandlerGitHub_UnknownAuthor(t *testing.T) {
+	applier := &applierMock{
+		applyFn: func(ev vcs.Event) (vcs.Result, error) {
+			return vcs.Result{}, errors2.ErrNotFound
+		},
+	}
+	app := newWebhookApp(applier)
+
+	resp, err := app.Test(newGitHubRequest("s3cret", "pull_request", githubPROpened))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestWebhookHandlerGitHub_InvalidPullRequestIsNotRetried(t *testing.T) {
+	for err, status := range map[error]int{
+		fmt.Errorf("%w: pull_request_name can't be empty", errors2.ErrBadRequest):          fiber.StatusUnprocessableEntity,
+		errors2.ErrInvalidReviewersCount:                                                   fiber.StatusUnprocessableEntity,
+		fmt.Errorf("%w: required reviewer u9 does not exist", errors2.ErrInvalidReviewers): fiber.StatusUnprocessableEntity,
+		errors2.ErrNoCandidate: fiber.StatusConflict,
+	} {
+		applier := &applierMock{
+			applyFn: func(ev vcs.Event) (vcs.Result, error) {
+				return vcs.Result{}, err
+			},
+		}
+		app := newWebhookApp(applier)
+
+		resp, rerr := app.Test(newGitHubRequest("s3cret", "pull_request", githubPROpened))
+		require.NoError(t, rerr)
+		require.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
+
+func TestWebhookHandlerGitLab_Merge(t *testing.T) {
+	applier := &applierMock{}
+	app := newWebhookApp(applier)
+
+	req := httptest.NewRequest("POST", "/webhooks/gitlab", bytes.NewReader([]byte(gitlabMRMerged)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Token", "gl-token")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	require.Len(t, applier.events, 1)
+	require.Equal(t, vcs.ActionMerged, applier.events[0].Action)
+	require.Equal(t, "fintech/payments!7", applier.events[0].PullRequestID)
+}
+
+func TestWebhookHandlerGitLab_BadToken(t *testing.T) {
+	applier := &applierMock{}
+	app := newWebhookApp(applier)
+
+	req := httptest.NewRequest("POST", "/webhooks/gitlab", bytes.NewReader([]byte(gitlabMRMerged)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Token", "guess")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	require.Empty(t, applier.events)
+}
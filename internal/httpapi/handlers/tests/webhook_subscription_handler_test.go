@@ -0,0 +1,120 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"pr-reviwer-assigner/internal/httpapi/handlers"
+)
+
+type webhookServiceMock struct {
+	createSubscriptionFn func(sub dto.WebhookSubscription) (*dto.WebhookSubscription, error)
+	retryDeliveryFn      func(id int64) error
+}
+
+func (m *webhookServiceMock) CreateSubscription(ctx context.Context, sub dto.WebhookSubscription) (*dto.WebhookSubscription, error) {
+	return m.createSubscriptionFn(sub)
+}
+
+func (m *webhookServiceMock) ListSubscriptions(ctx context.Context, teamName string) ([]dto.WebhookSubscription, error) {
+	return nil, nil
+}
+
+func (m *webhookServiceMock) DeleteSubscription(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (m *webhookServiceMock) ListDeliveries(ctx context.Context, subscriptionID int64, status string) ([]dto.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (m *webhookServiceMock) RetryDelivery(ctx context.Context, id int64) error {
+	return m.retryDeliveryFn(id)
+}
+
+func newSubscriptionApp(svc *webhookServiceMock) *fiber.App {
+	app := fiber.New()
+	h := handlers.NewWebhookSubscriptionHandler(svc, zap.NewNop().Sugar())
+	app.Post("/webhooks/subscriptions", h.Create)
+	app.Post("/webhooks/deliveries/retry", h.RetryDelivery)
+	return app
+}
+
+func TestWebhookSubscriptionHandlerCreate_Success(t *testing.T) {
+	svc := &webhookServiceMock{
+		createSubscriptionFn: func(sub dto.WebhookSubscription) (*dto.WebhookSubscription, error) {
+			require.Equal(t, "backend", sub.TeamName)
+			require.Equal(t, []string{events.ReviewerAssigned}, sub.EventTypes)
+			sub.ID = 1
+			sub.Secret = "generated"
+			return &sub, nil
+		},
+	}
+	app := newSubscriptionApp(svc)
+
+	body, _ := json.Marshal(dto.WebhookSubscription{
+		TeamName:   " backend ",
+		URL:        "https://bot.example.com/hooks",
+		EventTypes: []string{events.ReviewerAssigned},
+	})
+	req := httptest.NewRequest("POST", "/webhooks/subscriptions", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	var out dto.WebhookSubscriptionResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Equal(t, int64(1), out.Subscription.ID)
+	require.Equal(t, "generated", out.Subscription.Secret)
+}
+
+func TestWebhookSubscriptionHandlerCreate_Validation(t *testing.T) {
+	cases := map[string]dto.WebhookSubscription{
+		"missing url":        {TeamName: "backend"},
+		"relative url":       {TeamName: "backend", URL: "/hooks"},
+		"unsupported scheme": {TeamName: "backend", URL: "ftp://example.com"},
+		"unknown event":      {TeamName: "backend", URL: "https://example.com", EventTypes: []string{"pr.deleted"}},
+	}
+
+	for name, sub := range cases {
+		t.Run(name, func(t *testing.T) {
+			app := newSubscriptionApp(&webhookServiceMock{})
+
+			body, _ := json.Marshal(sub)
+			req := httptest.NewRequest("POST", "/webhooks/subscriptions", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestWebhookSubscriptionHandlerRetryDelivery_NotFound(t *testing.T) {
+	svc := &webhookServiceMock{
+		retryDeliveryFn: func(id int64) error {
+			return errors2.ErrNotFound
+		},
+	}
+	app := newSubscriptionApp(svc)
+
+	req := httptest.NewRequest("POST", "/webhooks/deliveries/retry", bytes.NewReader([]byte(`{"id":5}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
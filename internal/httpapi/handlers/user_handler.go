@@ -3,10 +3,14 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/mail"
 	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/selector"
 	"pr-reviwer-assigner/internal/domain/services"
 	errors2 "pr-reviwer-assigner/internal/errors"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v3"
 	"go.uber.org/zap"
@@ -112,3 +116,262 @@ func (h *UserHandler) GetReview(c fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(response)
 }
+
+func (h *UserHandler) SetVCSIdentity(c fiber.Ctx) error {
+	var req dto.VCSIdentity
+
+	err := json.Unmarshal(c.Body(), &req)
+	if err != nil {
+		h.logger.Error("failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	req.Provider = strings.ToLower(strings.TrimSpace(req.Provider))
+	req.Login = strings.TrimSpace(req.Login)
+	req.UserID = strings.TrimSpace(req.UserID)
+	if req.Provider == "" || req.Login == "" || req.UserID == "" {
+		h.logger.Error("set vcs identity: missing fields: ", req)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "provider, login and user_id are required",
+			},
+		})
+	}
+
+	identity, err := h.userService.SetVCSIdentity(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			h.logger.Error("set vcs identity: user not found: ", req.UserID)
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrNotFound.Error(),
+					Message: "resource not found",
+				},
+			})
+		default:
+			h.logger.Error("set vcs identity: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	h.logger.Info("SetVCSIdentity success: ", identity)
+
+	return c.Status(fiber.StatusOK).JSON(dto.VCSIdentityResponse{
+		Identity: *identity,
+	})
+}
+
+func (h *UserHandler) SetProfile(c fiber.Ctx) error {
+	var req dto.ProfileRequest
+
+	err := json.Unmarshal(c.Body(), &req)
+	if err != nil {
+		h.logger.Error("failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	req.ID = strings.TrimSpace(req.ID)
+	if req.ID == "" {
+		h.logger.Error("set profile: empty user id")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "user_id can't be empty",
+			},
+		})
+	}
+
+	if req.ChatHandle != nil {
+		handle := strings.TrimPrefix(strings.TrimSpace(*req.ChatHandle), "@")
+		req.ChatHandle = &handle
+	}
+
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if email != "" {
+			addr, err := mail.ParseAddress(email)
+			if err != nil || addr.Address != email {
+				h.logger.Error("set profile: invalid email: ", email)
+				return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+					Error: dto.Error{
+						Code:    errors2.ErrBadRequest.Error(),
+						Message: "email must be a plain address like name@example.com",
+					},
+				})
+			}
+		}
+		req.Email = &email
+	}
+
+	if req.WorkingHours != nil {
+		if msg := normalizeWorkingHours(req.WorkingHours); msg != "" {
+			h.logger.Error("set profile: invalid working_hours: ", msg)
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrBadRequest.Error(),
+					Message: msg,
+				},
+			})
+		}
+	}
+
+	user, err := h.userService.SetProfile(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			h.logger.Error("set profile: user not found: ", req.ID)
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrNotFound.Error(),
+					Message: "resource not found",
+				},
+			})
+		default:
+			h.logger.Error("set profile: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	h.logger.Info("SetProfile success: ", user)
+
+	return c.Status(fiber.StatusOK).JSON(dto.UserResponse{
+		User: *user,
+	})
+}
+
+func (h *UserHandler) GetSkills(c fiber.Ctx) error {
+	userID := strings.TrimSpace(c.Query("user_id"))
+	if userID == "" {
+		h.logger.Error("get skills: empty user id")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "user_id can't be empty",
+			},
+		})
+	}
+
+	skills, err := h.userService.GetSkills(c.Context(), userID)
+	if err != nil {
+		return h.skillsError(c, "get skills", userID, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(skills)
+}
+
+func (h *UserHandler) SetSkills(c fiber.Ctx) error {
+	var req dto.UserSkills
+
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error("set skills: failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	req.UserID = strings.TrimSpace(req.UserID)
+	if req.UserID == "" {
+		h.logger.Error("set skills: empty user id")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "user_id can't be empty",
+			},
+		})
+	}
+
+	resp, err := h.userService.SetSkills(c.Context(), req)
+	if err != nil {
+		return h.skillsError(c, "set skills", req.UserID, err)
+	}
+
+	h.logger.Info("SetSkills success: ", resp)
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *UserHandler) skillsError(c fiber.Ctx, op, userID string, err error) error {
+	switch {
+	case errors.Is(err, errors2.ErrBadRequest):
+		h.logger.Error(op, ": ", err)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, errors2.ErrNotFound):
+		h.logger.Error(op, ": user not found: ", userID)
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrNotFound.Error(),
+				Message: "resource not found",
+			},
+		})
+	default:
+		h.logger.Error(op, ": ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+}
+
+// normalizeWorkingHours trims the set fields and returns why they are invalid, or
+// an empty string. Empty fields keep their stored value.
+func normalizeWorkingHours(hours *dto.WorkingHours) string {
+	hours.TimeZone = strings.TrimSpace(hours.TimeZone)
+	if hours.TimeZone != "" {
+		if _, err := time.LoadLocation(hours.TimeZone); err != nil {
+			return fmt.Sprintf("unknown time_zone: %s", hours.TimeZone)
+		}
+	}
+
+	for _, clock := range []*string{&hours.Start, &hours.End} {
+		*clock = strings.TrimSpace(*clock)
+		if *clock == "" {
+			continue
+		}
+		if _, err := time.Parse(selector.ClockLayout, *clock); err != nil {
+			return fmt.Sprintf("working hours must be HH:MM, got %s", *clock)
+		}
+	}
+
+	if hours.Days != nil && len(hours.Days) == 0 {
+		return "days can't be empty"
+	}
+	for _, d := range hours.Days {
+		if d < 1 || d > 7 {
+			return fmt.Sprintf("days must be ISO weekdays 1..7, got %d", d)
+		}
+	}
+
+	return ""
+}
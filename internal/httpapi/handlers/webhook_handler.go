@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"pr-reviwer-assigner/internal/config"
+	"pr-reviwer-assigner/internal/domain/dto"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"pr-reviwer-assigner/internal/vcs"
+	"pr-reviwer-assigner/internal/vcs/github"
+	"pr-reviwer-assigner/internal/vcs/gitlab"
+
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+type WebhookHandler struct {
+	applier vcs.Applier
+	cfg     config.WebhooksConfig
+	logger  *zap.SugaredLogger
+}
+
+func NewWebhookHandler(applier vcs.Applier, cfg config.WebhooksConfig, logger *zap.SugaredLogger) *WebhookHandler {
+	return &WebhookHandler{
+		applier: applier,
+		cfg:     cfg,
+		logger:  logger,
+	}
+}
+
+func (h *WebhookHandler) GitHub(c fiber.Ctx) error {
+	body := c.Body()
+
+	if !github.VerifySignature(h.cfg.GitHub.Secret, body, c.Get(github.SignatureHeader)) {
+		h.logger.Error("github webhook: invalid signature, delivery: ", c.Get("X-GitHub-Delivery"))
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrUnauthorized.Error(),
+				Message: "invalid webhook signature",
+			},
+		})
+	}
+
+	ev, err := github.ParseEvent(c.Get(github.EventHeader), body)
+	if err != nil {
+		h.logger.Error("github webhook: ", err)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "malformed payload",
+			},
+		})
+	}
+
+	return h.apply(c, "github webhook", ev)
+}
+
+func (h *WebhookHandler) GitLab(c fiber.Ctx) error {
+	if !gitlab.VerifyToken(h.cfg.GitLab.Token, c.Get(gitlab.TokenHeader)) {
+		h.logger.Error("gitlab webhook: invalid token, event: ", c.Get(gitlab.EventHeader))
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrUnauthorized.Error(),
+				Message: "invalid webhook token",
+			},
+		})
+	}
+
+	ev, err := gitlab.ParseEvent(c.Get(gitlab.EventHeader), c.Body())
+	if err != nil {
+		h.logger.Error("gitlab webhook: ", err)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "malformed payload",
+			},
+		})
+	}
+
+	return h.apply(c, "gitlab webhook", ev)
+}
+
+// apply runs a parsed event through the applier and writes the response.
+// A nil event means the delivery is valid but irrelevant.
+func (h *WebhookHandler) apply(c fiber.Ctx, op string, ev *vcs.Event) error {
+	if ev == nil {
+		return c.Status(fiber.StatusOK).JSON(dto.WebhookResponse{
+			Status: vcs.StatusIgnored,
+		})
+	}
+
+	res, err := h.applier.Apply(c.Context(), *ev)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			h.logger.Error(op+": not found: ", err)
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrNotFound.Error(),
+					Message: err.Error(),
+				},
+			})
+		case errors.Is(err, errors2.ErrPRMerged),
+			errors.Is(err, errors2.ErrPRClosed),
+			errors.Is(err, errors2.ErrPRDraft):
+			h.logger.Error(op+": conflict: ", ev.PullRequestID, err)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "pull request state doesn't allow " + string(ev.Action),
+				},
+			})
+		case errors.Is(err, errors2.ErrBadRequest),
+			errors.Is(err, errors2.ErrInvalidReviewersCount),
+			errors.Is(err, errors2.ErrInvalidReviewers):
+			// Redelivering the same payload can't succeed, so it is not a 5xx.
+			h.logger.Error(op+": invalid pull request: ", ev.PullRequestID, err)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrBadRequest.Error(),
+					Message: err.Error(),
+				},
+			})
+		case errors.Is(err, errors2.ErrNoCandidate):
+			h.logger.Error(op+": no candidate: ", ev.PullRequestID, err)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrNoCandidate.Error(),
+					Message: "no active replacement candidate in team or its fallback teams",
+				},
+			})
+		case errors.Is(err, errors2.ErrNotEnoughApprovals):
+			h.logger.Error(op+": not enough approvals: ", ev.PullRequestID, err)
+			return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrNotEnoughApprovals.Error(),
+					Message: err.Error(),
+				},
+			})
+		default:
+			h.logger.Error(op+": apply: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	h.logger.Info(op+" success: ", fiber.Map{
+		"action":          ev.Action,
+		"pull_request_id": ev.PullRequestID,
+		"status":          res.Status,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(dto.WebhookResponse{
+		Status:        res.Status,
+		Action:        string(ev.Action),
+		PullRequestID: ev.PullRequestID,
+		Reason:        res.Reason,
+		PR:            res.PR,
+	})
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+type WebhookSubscriptionHandler struct {
+	service services.WebhookService
+	logger  *zap.SugaredLogger
+}
+
+func NewWebhookSubscriptionHandler(service services.WebhookService, logger *zap.SugaredLogger) *WebhookSubscriptionHandler {
+	return &WebhookSubscriptionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *WebhookSubscriptionHandler) Create(c fiber.Ctx) error {
+	var req dto.WebhookSubscription
+
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error("create subscription: failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	req.TeamName = strings.TrimSpace(req.TeamName)
+	req.URL = strings.TrimSpace(req.URL)
+	if req.TeamName == "" || req.URL == "" {
+		h.logger.Error("create subscription: missing fields: ", req.TeamName, req.URL)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "team_name and url are required",
+			},
+		})
+	}
+
+	if !services.IsHTTPURL(req.URL) {
+		h.logger.Error("create subscription: invalid url: ", req.URL)
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "url must be an absolute http(s) URL",
+			},
+		})
+	}
+
+	for _, t := range req.EventTypes {
+		if !events.IsKnown(t) {
+			h.logger.Error("create subscription: unknown event type: ", t)
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrBadRequest.Error(),
+					Message: "unknown event type " + t + ", expected one of " + strings.Join(events.Types, ", "),
+				},
+			})
+		}
+	}
+
+	sub, err := h.service.CreateSubscription(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			h.logger.Error("create subscription: team not found: ", req.TeamName)
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "resource not found",
+				},
+			})
+		default:
+			h.logger.Error("create subscription: service error: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	h.logger.Info("create subscription success: ", sub.ID, " ", sub.TeamName, " ", sub.URL)
+
+	return c.Status(fiber.StatusCreated).JSON(dto.WebhookSubscriptionResponse{
+		Subscription: *sub,
+	})
+}
+
+func (h *WebhookSubscriptionHandler) List(c fiber.Ctx) error {
+	teamName := strings.TrimSpace(c.Query("team_name"))
+	if teamName == "" {
+		h.logger.Error("list subscriptions: empty team_name")
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "team_name can't be empty",
+			},
+		})
+	}
+
+	subs, err := h.service.ListSubscriptions(c.Context(), teamName)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "resource not found",
+				},
+			})
+		default:
+			h.logger.Error("list subscriptions: service error: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.WebhookSubscriptionsResponse{
+		Subscriptions: subs,
+	})
+}
+
+func (h *WebhookSubscriptionHandler) Delete(c fiber.Ctx) error {
+	var req dto.WebhookIDRequest
+
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error("delete subscription: failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	if err := h.service.DeleteSubscription(c.Context(), req.ID); err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "resource not found",
+				},
+			})
+		default:
+			h.logger.Error("delete subscription: service error: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	h.logger.Info("delete subscription success: ", req.ID)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *WebhookSubscriptionHandler) ListDeliveries(c fiber.Ctx) error {
+	subscriptionID, err := strconv.ParseInt(c.Query("subscription_id"), 10, 64)
+	if err != nil {
+		h.logger.Error("list deliveries: invalid subscription_id: ", c.Query("subscription_id"))
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "subscription_id must be a number",
+			},
+		})
+	}
+
+	status := strings.ToUpper(strings.TrimSpace(c.Query("status")))
+	switch status {
+	case "", dto.DeliveryPending, dto.DeliveryDelivered, dto.DeliveryDead:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrBadRequest.Error(),
+				Message: "status must be one of PENDING, DELIVERED, DEAD",
+			},
+		})
+	}
+
+	deliveries, err := h.service.ListDeliveries(c.Context(), subscriptionID, status)
+	if err != nil {
+		h.logger.Error("list deliveries: service error: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.WebhookDeliveriesResponse{
+		Deliveries: deliveries,
+	})
+}
+
+func (h *WebhookSubscriptionHandler) RetryDelivery(c fiber.Ctx) error {
+	var req dto.WebhookIDRequest
+
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.logger.Error("retry delivery: failed to unmarshal body: ", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: dto.Error{
+				Code:    errors2.ErrInternal.Error(),
+				Message: "internal server error",
+			},
+		})
+	}
+
+	if err := h.service.RetryDelivery(c.Context(), req.ID); err != nil {
+		switch {
+		case errors.Is(err, errors2.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    err.Error(),
+					Message: "delivery not found or already delivered",
+				},
+			})
+		default:
+			h.logger.Error("retry delivery: service error: ", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Error: dto.Error{
+					Code:    errors2.ErrInternal.Error(),
+					Message: "internal server error",
+				},
+			})
+		}
+	}
+
+	h.logger.Info("retry delivery success: ", req.ID)
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
@@ -12,6 +12,11 @@ func RegisterRoutes(r *fiber.App, c *di.Container) {
 	teamHandler := handlers.NewTeamHandler(c.GetTeamService(), c.GetNamedLogger("teamHandler"))
 	userHandler := handlers.NewUserHandler(c.GetUserService(), c.GetNamedLogger("userHandler"))
 	prHandler := handlers.NewPRHandler(c.GetPRService(), c.GetNamedLogger("prHandler"))
+	webhookHandler := handlers.NewWebhookHandler(c.GetVCSApplier(), c.GetWebhooksConfig(), c.GetNamedLogger("webhookHandler"))
+	subscriptionHandler := handlers.NewWebhookSubscriptionHandler(c.GetWebhookService(), c.GetNamedLogger("subscriptionHandler"))
+	availabilityHandler := handlers.NewAvailabilityHandler(c.GetAvailabilityService(), c.GetNamedLogger("availabilityHandler"))
+	codeownersHandler := handlers.NewCodeownersHandler(c.GetCodeownersService(), c.GetNamedLogger("codeownersHandler"))
+	statsHandler := handlers.NewStatsHandler(c.GetStatsService(), c.GetNamedLogger("statsHandler"))
 	docs.RegisterRoutes(r)
 
 	// HEALTH
@@ -28,18 +33,55 @@ func RegisterRoutes(r *fiber.App, c *di.Container) {
 		r.Get("/team/get", teamHandler.Get)
 		r.Post("/team/add", teamHandler.Add)
 		r.Post("/team/deactivateMembers", teamHandler.DeactivateMembers)
+		r.Get("/team/settings", teamHandler.GetSettings)
+		r.Post("/team/settings", teamHandler.UpdateSettings)
+		r.Get("/team/codeowners", codeownersHandler.Get)
+		r.Post("/team/codeowners", codeownersHandler.Upload)
 	}
 
 	// USERS
 	{
 		r.Post("/users/setIsActive", userHandler.SetIsActive)
 		r.Get("/users/getReview", userHandler.GetReview)
+		r.Post("/users/setVcsIdentity", userHandler.SetVCSIdentity)
+		r.Post("/users/setProfile", userHandler.SetProfile)
+		r.Get("/users/skills", userHandler.GetSkills)
+		r.Post("/users/skills", userHandler.SetSkills)
+		r.Get("/users/availability", availabilityHandler.List)
+		r.Post("/users/availability", availabilityHandler.Add)
+		r.Post("/users/availability/update", availabilityHandler.Update)
+		r.Post("/users/availability/delete", availabilityHandler.Delete)
+		r.Post("/users/availability/import", availabilityHandler.ImportICS)
+		r.Post("/users/availability/importFile", availabilityHandler.ImportICSFile)
 	}
 
 	// PR
 	{
+		r.Get("/pullRequest/list", prHandler.ListPRs)
 		r.Post("/pullRequest/create", prHandler.CreatePR)
 		r.Post("/pullRequest/merge", prHandler.MergePR)
 		r.Post("/pullRequest/reassign", prHandler.ReassignViewer)
+		r.Post("/pullRequest/addReviewer", prHandler.AddReviewer)
+		r.Post("/pullRequest/removeReviewer", prHandler.RemoveReviewer)
+		r.Post("/pullRequest/close", prHandler.ClosePR)
+		r.Post("/pullRequest/reopen", prHandler.ReopenPR)
+		r.Post("/pullRequest/markReady", prHandler.MarkReadyPR)
+		r.Post("/pullRequest/review", prHandler.ReviewPR)
+	}
+
+	// STATS
+	{
+		r.Get("/stats/pairings", statsHandler.Pairings)
+	}
+
+	// WEBHOOKS
+	{
+		r.Post("/webhooks/github", webhookHandler.GitHub)
+		r.Post("/webhooks/gitlab", webhookHandler.GitLab)
+		r.Get("/webhooks/subscriptions", subscriptionHandler.List)
+		r.Post("/webhooks/subscriptions", subscriptionHandler.Create)
+		r.Post("/webhooks/subscriptions/delete", subscriptionHandler.Delete)
+		r.Get("/webhooks/deliveries", subscriptionHandler.ListDeliveries)
+		r.Post("/webhooks/deliveries/retry", subscriptionHandler.RetryDelivery)
 	}
 }
@@ -0,0 +1,342 @@
+// Package ical parses the subset of iCalendar (RFC 5545) needed to import
+// out-of-office events: VEVENT blocks with their dates, summary, categories,
+// status and attendees. Recurrence rules are not expanded.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var ErrNoCalendar = errors.New("no VCALENDAR found")
+
+type Event struct {
+	UID        string
+	Summary    string
+	Categories []string
+	Start      time.Time
+	End        time.Time
+	AllDay     bool
+	// Status is STATUS, e.g. CANCELLED.
+	Status string
+	// BusyStatus is X-MICROSOFT-CDO-BUSYSTATUS, which Outlook sets to OOF
+	// for out-of-office events.
+	BusyStatus string
+	// Emails are the organizer and attendee addresses, lower-cased.
+	Emails []string
+}
+
+// outOfOfficeWords mark an event as an absence when found in its summary
+// or categories.
+var outOfOfficeWords = []string{"ooo", "out of office", "vacation", "holiday", "pto", "leave", "sick", "отпуск", "больничный"}
+
+// IsOutOfOffice reports whether the event blocks the user from reviewing.
+func (e Event) IsOutOfOffice() bool {
+	if strings.EqualFold(e.BusyStatus, "OOF") {
+		return true
+	}
+
+	texts := append([]string{e.Summary}, e.Categories...)
+	for _, text := range texts {
+		text = strings.ToLower(text)
+		for _, word := range outOfOfficeWords {
+			if containsWord(text, word) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (e Event) Cancelled() bool {
+	return strings.EqualFold(e.Status, "CANCELLED")
+}
+
+// Parse reads every VEVENT of the calendar. Events without a start are
+// rejected; a missing end defaults to one day for all-day events and to the
+// start otherwise, as RFC 5545 prescribes.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events   []Event
+		cur      *Event
+		duration string
+		inCal    bool
+		depth    int
+	)
+
+	for n, line := range lines {
+		name, params, value, ok := splitLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			inCal = true
+			continue
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			cur, duration, depth = &Event{}, "", 0
+			continue
+		case name == "END" && strings.EqualFold(value, "VEVENT") && cur != nil:
+			if err := finish(cur, duration); err != nil {
+				return nil, fmt.Errorf("event %q: %w", cur.UID, err)
+			}
+			events = append(events, *cur)
+			cur = nil
+			continue
+		}
+
+		if cur == nil {
+			continue
+		}
+
+		// Skip nested components such as VALARM.
+		if name == "BEGIN" {
+			depth++
+			continue
+		}
+		if name == "END" {
+			depth--
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+
+		switch name {
+		case "UID":
+			cur.UID = value
+		case "SUMMARY":
+			cur.Summary = unescape(value)
+		case "CATEGORIES":
+			for _, c := range strings.Split(value, ",") {
+				if c = strings.TrimSpace(unescape(c)); c != "" {
+					cur.Categories = append(cur.Categories, c)
+				}
+			}
+		case "STATUS":
+			cur.Status = strings.ToUpper(value)
+		case "X-MICROSOFT-CDO-BUSYSTATUS":
+			cur.BusyStatus = strings.ToUpper(value)
+		case "ORGANIZER", "ATTENDEE":
+			if email, ok := mailto(value); ok {
+				cur.Emails = append(cur.Emails, email)
+			}
+		case "DTSTART":
+			cur.Start, cur.AllDay, err = parseTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DTSTART: %w", n+1, err)
+			}
+		case "DTEND":
+			cur.End, _, err = parseTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DTEND: %w", n+1, err)
+			}
+		case "DURATION":
+			duration = value
+		}
+	}
+
+	if !inCal {
+		return nil, ErrNoCalendar
+	}
+
+	return events, nil
+}
+
+func finish(e *Event, duration string) error {
+	if e.Start.IsZero() {
+		return errors.New("missing DTSTART")
+	}
+
+	if e.End.IsZero() {
+		switch {
+		case duration != "":
+			d, err := parseDuration(duration)
+			if err != nil {
+				return fmt.Errorf("DURATION: %w", err)
+			}
+			e.End = e.Start.Add(d)
+		case e.AllDay:
+			e.End = e.Start.AddDate(0, 0, 1)
+		default:
+			e.End = e.Start
+		}
+	}
+
+	return nil
+}
+
+// unfold joins continuation lines (starting with a space or tab) to the
+// line before them.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, sc.Err()
+}
+
+// splitLine splits "NAME;PARAM=V;...:VALUE". Quoted parameter values may
+// contain ':' and ';'.
+func splitLine(line string) (string, map[string]string, string, bool) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := strings.Split(head, ";")
+
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+
+	return strings.ToUpper(parts[0]), params, value, true
+}
+
+func parseTime(value string, params map[string]string) (time.Time, bool, error) {
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, location(params))
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, location(params))
+	return t, false, err
+}
+
+// location resolves TZID against the system zone database. Floating times
+// and unknown zones are treated as UTC.
+func location(params map[string]string) *time.Location {
+	if tzid := params["TZID"]; tzid != "" {
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// parseDuration handles the dur-value grammar: [+-]P[nW][nD][T[nH][nM][nS]].
+func parseDuration(value string) (time.Duration, error) {
+	s := strings.ToUpper(value)
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	s = s[1:]
+
+	var total time.Duration
+	inTime := false
+	num := ""
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		num = ""
+
+		switch {
+		case r == 'W' && !inTime:
+			total += time.Duration(n) * 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			total += time.Duration(n) * 24 * time.Hour
+		case r == 'H' && inTime:
+			total += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			total += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			total += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+	if num != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	return sign * total, nil
+}
+
+func mailto(value string) (string, bool) {
+	if len(value) < len("mailto:") || !strings.EqualFold(value[:len("mailto:")], "mailto:") {
+		return "", false
+	}
+	email := strings.ToLower(strings.TrimSpace(value[len("mailto:"):]))
+	return email, email != ""
+}
+
+func unescape(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
+
+// containsWord reports whether word occurs in text delimited by non-letters.
+func containsWord(text, word string) bool {
+	for i := 0; ; {
+		j := strings.Index(text[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !unicode.IsLetter(before) && !unicode.IsLetter(after) {
+			return true
+		}
+		i = end
+	}
+}
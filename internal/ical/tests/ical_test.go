@@ -0,0 +1,61 @@
+package ical_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/ical"
+)
+
+func TestParse_HRExport(t *testing.T) {
+	f, err := os.Open("testdata/hr_export.ics")
+	require.NoError(t, err)
+	defer f.Close()
+
+	events, err := ical.Parse(f)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	vacation := events[0]
+	require.Equal(t, "vac-1001@hr.acme", vacation.UID)
+	require.True(t, vacation.AllDay)
+	require.Equal(t, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), vacation.Start)
+	require.Equal(t, time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC), vacation.End)
+	require.Equal(t, []string{"bob@example.com"}, vacation.Emails)
+	require.True(t, vacation.IsOutOfOffice())
+
+	doctor := events[1]
+	require.Equal(t, time.Date(2025, 7, 3, 12, 0, 0, 0, time.UTC), doctor.Start.UTC())
+	require.Equal(t, 150*time.Minute, doctor.End.Sub(doctor.Start))
+	require.True(t, doctor.IsOutOfOffice())
+
+	meeting := events[2]
+	require.Equal(t, "Quarterly planning, all hands", meeting.Summary)
+	require.False(t, meeting.IsOutOfOffice())
+
+	trip := events[3]
+	require.True(t, trip.Cancelled())
+	require.Equal(t, []string{"Travel", "PTO"}, trip.Categories)
+	require.True(t, trip.IsOutOfOffice())
+	require.Equal(t, trip.Start.AddDate(0, 0, 1), trip.End)
+}
+
+func TestParse_RejectsNonCalendar(t *testing.T) {
+	_, err := ical.Parse(strings.NewReader("hello"))
+	require.ErrorIs(t, err, ical.ErrNoCalendar)
+}
+
+func TestParse_MissingStart(t *testing.T) {
+	_, err := ical.Parse(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\nEND:VEVENT\nEND:VCALENDAR\n"))
+	require.Error(t, err)
+}
+
+func TestIsOutOfOffice_WholeWordsOnly(t *testing.T) {
+	require.False(t, ical.Event{Summary: "Leaves review session"}.IsOutOfOffice())
+	require.True(t, ical.Event{Summary: "Parental leave"}.IsOutOfOffice())
+	require.True(t, ical.Event{Summary: "Отпуск"}.IsOutOfOffice())
+}
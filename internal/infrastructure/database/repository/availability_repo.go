@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type availabilityRepo struct {
+	db *sql.DB
+}
+
+func NewAvailabilityRepository(db *sql.DB) repository.AvailabilityRepository {
+	return &availabilityRepo{
+		db: db,
+	}
+}
+
+func (r *availabilityRepo) Add(ctx context.Context, window dto.Unavailability) (*dto.Unavailability, error) {
+	const query = `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, starts_at, ends_at, reason, COALESCE(external_id, ''), created_at,
+		          reassign_attempts, COALESCE(reassign_error, '')
+	`
+
+	created, err := scanUnavailability(r.db.QueryRowContext(ctx, query,
+		window.UserID,
+		window.StartsAt,
+		window.EndsAt,
+		window.Reason,
+	))
+	if err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return nil, errors2.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return created, nil
+}
+
+func (r *availabilityRepo) List(ctx context.Context, userID string) ([]dto.Unavailability, error) {
+	const query = `
+		SELECT id, user_id, starts_at, ends_at, reason, COALESCE(external_id, ''), created_at,
+		       reassign_attempts, COALESCE(reassign_error, '')
+		FROM user_unavailability
+		WHERE user_id = $1
+		ORDER BY starts_at, id
+	`
+
+	windows, err := r.query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(windows) == 0 {
+		const userExists = `SELECT 1 FROM users WHERE user_id = $1`
+		var dummy int
+		err = r.db.QueryRowContext(ctx, userExists, userID).Scan(&dummy)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return nil, errors2.ErrNotFound
+			default:
+				return nil, err
+			}
+		}
+	}
+
+	return windows, nil
+}
+
+// Update replaces the window's range and reason. The window is handed over
+// again if it has already started.
+func (r *availabilityRepo) Update(ctx context.Context, window dto.Unavailability) (*dto.Unavailability, error) {
+	const query = `
+		UPDATE user_unavailability
+		   SET starts_at         = $2,
+		       ends_at           = $3,
+		       reason            = $4,
+		       reassigned_at     = NULL,
+		       reassign_attempts = 0,
+		       reassign_error    = NULL,
+		       next_attempt_at   = NULL
+		 WHERE id = $1
+		RETURNING id, user_id, starts_at, ends_at, reason, COALESCE(external_id, ''), created_at,
+		          reassign_attempts, COALESCE(reassign_error, '')
+	`
+
+	updated, err := scanUnavailability(r.db.QueryRowContext(ctx, query,
+		window.ID,
+		window.StartsAt,
+		window.EndsAt,
+		window.Reason,
+	))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors2.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return updated, nil
+}
+
+func (r *availabilityRepo) Delete(ctx context.Context, id int64) error {
+	const query = `DELETE FROM user_unavailability WHERE id = $1`
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors2.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *availabilityRepo) UpsertExternal(ctx context.Context, window dto.Unavailability) error {
+	// A changed range is handed over again; an unchanged one keeps its state.
+	const query = `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason, external_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, external_id) WHERE external_id IS NOT NULL DO UPDATE
+		   SET starts_at     = EXCLUDED.starts_at,
+		       ends_at       = EXCLUDED.ends_at,
+		       reason        = EXCLUDED.reason,
+		       reassigned_at = CASE
+		           WHEN user_unavailability.starts_at = EXCLUDED.starts_at
+		            AND user_unavailability.ends_at = EXCLUDED.ends_at
+		           THEN user_unavailability.reassigned_at
+		       END,
+		       reassign_attempts = CASE
+		           WHEN user_unavailability.starts_at = EXCLUDED.starts_at
+		            AND user_unavailability.ends_at = EXCLUDED.ends_at
+		           THEN user_unavailability.reassign_attempts
+		           ELSE 0
+		       END,
+		       next_attempt_at = CASE
+		           WHEN user_unavailability.starts_at = EXCLUDED.starts_at
+		            AND user_unavailability.ends_at = EXCLUDED.ends_at
+		           THEN user_unavailability.next_attempt_at
+		       END
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		window.UserID,
+		window.StartsAt,
+		window.EndsAt,
+		window.Reason,
+		window.ExternalID,
+	)
+	if err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return errors2.ErrNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *availabilityRepo) DeleteExternal(ctx context.Context, userID, externalID string) (bool, error) {
+	const query = `DELETE FROM user_unavailability WHERE user_id = $1 AND external_id = $2`
+
+	res, err := r.db.ExecContext(ctx, query, userID, externalID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *availabilityRepo) UserIDsByEmail(ctx context.Context, emails []string) (map[string]string, error) {
+	const query = `
+		SELECT lower(email), user_id
+		FROM users
+		WHERE lower(email) = ANY($1)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(emails))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make(map[string]string, len(emails))
+	for rows.Next() {
+		var email, userID string
+		if err := rows.Scan(&email, &userID); err != nil {
+			return nil, err
+		}
+		users[email] = userID
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *availabilityRepo) ListStarted(ctx context.Context, limit int) ([]dto.Unavailability, error) {
+	const query = `
+		SELECT id, user_id, starts_at, ends_at, reason, COALESCE(external_id, ''), created_at,
+		       reassign_attempts, COALESCE(reassign_error, '')
+		FROM user_unavailability
+		WHERE reassigned_at IS NULL
+		  AND starts_at <= now()
+		  AND ends_at > now()
+		  AND (next_attempt_at IS NULL OR next_attempt_at <= now())
+		ORDER BY starts_at, id
+		LIMIT $1
+	`
+
+	return r.query(ctx, query, limit)
+}
+
+func (r *availabilityRepo) MarkReassigned(ctx context.Context, id int64, failure string) error {
+	const query = `
+		UPDATE user_unavailability
+		   SET reassigned_at  = now(),
+		       reassign_error = NULLIF($2, '')
+		 WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, failure)
+	return err
+}
+
+// MarkFailed records a failed hand-over and holds the window back until next.
+// A dead window is not retried any more.
+func (r *availabilityRepo) MarkFailed(ctx context.Context, id int64, reason string, next time.Time, dead bool) error {
+	const query = `
+		UPDATE user_unavailability
+		   SET reassign_attempts = reassign_attempts + 1,
+		       reassign_error    = $2,
+		       next_attempt_at   = $3,
+		       reassigned_at     = CASE WHEN $4 THEN now() END
+		 WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, reason, next, dead)
+	return err
+}
+
+func (r *availabilityRepo) query(ctx context.Context, query string, args ...any) ([]dto.Unavailability, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var windows []dto.Unavailability
+	for rows.Next() {
+		window, err := scanUnavailability(rows)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, *window)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+func scanUnavailability(row interface{ Scan(dest ...any) error }) (*dto.Unavailability, error) {
+	var w dto.Unavailability
+	err := row.Scan(
+		&w.ID,
+		&w.UserID,
+		&w.StartsAt,
+		&w.EndsAt,
+		&w.Reason,
+		&w.ExternalID,
+		&w.CreatedAt,
+		&w.ReassignAttempts,
+		&w.ReassignError,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	w.StartsAt = w.StartsAt.UTC()
+	w.EndsAt = w.EndsAt.UTC()
+	w.CreatedAt = w.CreatedAt.UTC()
+
+	return &w, nil
+}
@@ -0,0 +1,409 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/selector"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// teamPolicy is the part of the team row that drives reviewer selection.
+type teamPolicy struct {
+	TeamName       string
+	Strategy       string
+	Cursor         string
+	ReviewersCount int
+	MinReviewers   int
+	MaxReviewers   int
+	// LeadTime is how soon a reviewer's working hours must start for them to be
+	// preferred over reviewers that are off hours.
+	LeadTime time.Duration
+}
+
+// lockTeamPolicy reads the selection settings of a team and locks its row, so that
+// concurrent assignments in the same team see each other's round-robin cursor.
+func lockTeamPolicy(ctx context.Context, tx *sql.Tx, teamName string) (*teamPolicy, error) {
+	const query = `
+		SELECT
+		    reviewer_strategy,
+		    COALESCE(rr_cursor, ''),
+		    reviewers_count,
+		    min_reviewers,
+		    max_reviewers,
+		    working_hours_lead_minutes
+		FROM teams
+		WHERE team_name = $1
+		FOR UPDATE
+	`
+
+	return scanTeamPolicy(tx.QueryRowContext(ctx, query, teamName), teamName)
+}
+
+// readTeamPolicy is lockTeamPolicy without the row lock. It is used for fallback
+// teams, so that two teams falling back on each other can't deadlock.
+func readTeamPolicy(ctx context.Context, tx *sql.Tx, teamName string) (*teamPolicy, error) {
+	const query = `
+		SELECT
+		    reviewer_strategy,
+		    COALESCE(rr_cursor, ''),
+		    reviewers_count,
+		    min_reviewers,
+		    max_reviewers,
+		    working_hours_lead_minutes
+		FROM teams
+		WHERE team_name = $1
+	`
+
+	return scanTeamPolicy(tx.QueryRowContext(ctx, query, teamName), teamName)
+}
+
+func scanTeamPolicy(row *sql.Row, teamName string) (*teamPolicy, error) {
+	p := teamPolicy{TeamName: teamName}
+	var leadMinutes int
+	err := row.Scan(
+		&p.Strategy,
+		&p.Cursor,
+		&p.ReviewersCount,
+		&p.MinReviewers,
+		&p.MaxReviewers,
+		&leadMinutes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.LeadTime = time.Duration(leadMinutes) * time.Minute
+
+	return &p, nil
+}
+
+func listFallbackTeams(ctx context.Context, tx *sql.Tx, teamName string) ([]string, error) {
+	const query = `
+		SELECT fallback_team
+		FROM team_fallbacks
+		WHERE team_name = $1
+		ORDER BY position
+	`
+
+	rows, err := tx.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []string
+	for rows.Next() {
+		var team string
+		if err := rows.Scan(&team); err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// listCandidates returns active members of the team that are neither the author,
+// already assigned to or excluded from the pull request, nor inside an
+// unavailability window, with their current open review load, the number of
+// reviews they were ever assigned, their working hours, skills and how many of
+// the author's latest pull requests they reviewed.
+func listCandidates(ctx context.Context, tx *sql.Tx, teamName, authorID, prID string) ([]selector.Candidate, error) {
+	return queryCandidates(ctx, tx, "u.team_name = $1", teamName, authorID, prID)
+}
+
+// listUserCandidates is listCandidates over the given users instead of a team.
+func listUserCandidates(ctx context.Context, tx *sql.Tx, userIDs []string, authorID, prID string) ([]selector.Candidate, error) {
+	return queryCandidates(ctx, tx, "u.user_id = ANY($1)", pq.Array(userIDs), authorID, prID)
+}
+
+// queryCandidates runs the candidate query for users matching filter, which
+// refers to arg as $1.
+func queryCandidates(ctx context.Context, tx *sql.Tx, filter string, arg any, authorID, prID string) ([]selector.Candidate, error) {
+	const query = `
+		WITH recent AS (
+		    SELECT p.pull_request_id
+		    FROM pull_requests p
+		    WHERE p.author_id = $2
+		      AND p.pull_request_id <> $3
+		    ORDER BY p.created_at DESC
+		    LIMIT (
+		        SELECT t.pairing_window
+		        FROM users au
+		        JOIN teams t
+		            ON t.team_name = au.team_name
+		        WHERE au.user_id = $2
+		    )
+		)
+		SELECT
+		    u.user_id,
+		    COUNT(pr.pull_request_id) AS open_reviews,
+		    COUNT(a.pull_request_id) AS total_reviews,
+		    u.time_zone,
+		    to_char(u.work_start, 'HH24:MI'),
+		    to_char(u.work_end, 'HH24:MI'),
+		    u.work_days,
+		    ARRAY(SELECT s.skill FROM user_skills s WHERE s.user_id = u.user_id ORDER BY s.skill) AS skills,
+		    (
+		        SELECT COUNT(*)
+		        FROM recent r
+		        JOIN pull_request_reviewers h
+		            ON h.pull_request_id = r.pull_request_id
+		        WHERE h.reviewer_id = u.user_id
+		    ) AS recent_pairings
+		FROM users u
+		LEFT JOIN pull_request_reviewers a
+		    ON a.reviewer_id = u.user_id
+		LEFT JOIN pull_requests pr
+		    ON pr.pull_request_id = a.pull_request_id
+		   AND pr.status = 'OPEN'
+		WHERE %s
+			AND u.is_active = TRUE
+			AND u.user_id <> $2
+			AND NOT EXISTS (
+				SELECT 1
+				FROM pull_request_reviewers prr
+				WHERE prr.pull_request_id = $3
+					AND prr.reviewer_id = u.user_id
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM pull_request_reviewer_rules r
+				WHERE r.pull_request_id = $3
+					AND r.user_id = u.user_id
+					AND NOT r.required
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_unavailability ua
+				WHERE ua.user_id = u.user_id
+					AND ua.starts_at <= now()
+					AND ua.ends_at > now()
+			)
+		GROUP BY u.user_id
+		ORDER BY u.user_id
+	`
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(query, filter), arg, authorID, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []selector.Candidate
+	for rows.Next() {
+		var c selector.Candidate
+		var timeZone, start, end sql.NullString
+		var days pq.Int64Array
+		err := rows.Scan(&c.UserID, &c.OpenReviews, &c.TotalReviews, &timeZone, &start, &end, &days, pq.Array(&c.Skills), &c.RecentPairings)
+		if err != nil {
+			return nil, err
+		}
+		// Users who never set their working hours have none.
+		if timeZone.Valid {
+			c.Hours = workingHours(timeZone.String, start.String, end.String, days)
+		}
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// workingHours converts stored working hours. A window that can't be parsed is
+// dropped, which makes the user count as always working rather than block
+// assignment.
+func workingHours(timeZone, start, end string, days []int64) *selector.WorkingHours {
+	isoDays := make([]int, 0, len(days))
+	for _, d := range days {
+		isoDays = append(isoDays, int(d))
+	}
+
+	hours, err := selector.NewWorkingHours(timeZone, start, end, isoDays)
+	if err != nil {
+		return nil
+	}
+	return hours
+}
+
+func advanceCursor(ctx context.Context, tx *sql.Tx, teamName string, picked []string) error {
+	if len(picked) == 0 {
+		return nil
+	}
+
+	const query = `
+		UPDATE teams
+		SET rr_cursor = $2
+		WHERE team_name = $1
+	`
+
+	_, err := tx.ExecContext(ctx, query, teamName, picked[len(picked)-1])
+	return err
+}
+
+// assignReviewers picks up to count reviewers for the pull request and inserts
+// them. Required reviewers that are not assigned yet go first, even beyond
+// count unless a reviewer is being replaced, then owners of the changed files, see assignOwners; the remaining
+// seats are filled from the home team and then from its fallback teams in order.
+// Within a team, reviewers covering more of the PR's required skills go first;
+// among equally matching ones, reviewers inside their working hours go first,
+// then those whose working hours start within the team's lead time, then
+// everyone else, see selector.Choose. The team's strategy only chooses among
+// otherwise equal reviewers. replacing is the reviewer being replaced, if any:
+// they no longer count as an owner.
+func (s *prRepo) assignReviewers(ctx context.Context, tx *sql.Tx, home *teamPolicy, authorID, prID, replacing string, count int) ([]dto.ReviewerAssignment, error) {
+	const insertReviewerQuery = `
+		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, source_team, policy, match_score)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	now := time.Now()
+
+	needs, err := readPRNeeds(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A replacement adds exactly the reviewers it reports, so required
+	// reviewers are capped to count there too.
+	limit := 0
+	if replacing != "" {
+		limit = count
+	}
+	assigned, err := assignRequired(ctx, tx, prID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	owners, err := s.assignOwners(ctx, tx, home, authorID, prID, replacing, needs, count-len(assigned), now)
+	if err != nil {
+		return nil, err
+	}
+	assigned = append(assigned, owners...)
+	policy := home
+
+	var fallbacks []string
+	for next := 0; len(assigned) < count; next++ {
+		if next > 0 {
+			if next == 1 {
+				fallbacks, err = listFallbackTeams(ctx, tx, home.TeamName)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if next > len(fallbacks) {
+				break
+			}
+
+			policy, err = readTeamPolicy(ctx, tx, fallbacks[next-1])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		candidates, err := listCandidates(ctx, tx, policy.TeamName, authorID, prID)
+		if err != nil {
+			return nil, err
+		}
+
+		picks := selector.Choose(s.selectors.Get(policy.Strategy), selector.Request{
+			PullRequestID:  prID,
+			AuthorID:       authorID,
+			Count:          count - len(assigned),
+			Cursor:         policy.Cursor,
+			RequiredSkills: needs.Skills,
+		}, candidates, now, policy.LeadTime)
+
+		picked := make([]string, 0, len(picks))
+		for _, p := range picks {
+			score := needs.score(p)
+			if _, err := tx.ExecContext(ctx, insertReviewerQuery, prID, p.UserID, policy.TeamName, p.Policy, score); err != nil {
+				return nil, err
+			}
+			assigned = append(assigned, dto.ReviewerAssignment{
+				UserID:     p.UserID,
+				TeamName:   policy.TeamName,
+				Policy:     p.Policy,
+				MatchScore: score,
+			})
+			picked = append(picked, p.UserID)
+		}
+
+		if err := advanceCursor(ctx, tx, policy.TeamName, picked); err != nil {
+			return nil, err
+		}
+	}
+
+	return assigned, nil
+}
+
+func listAssignments(ctx context.Context, tx *sql.Tx, prID string) ([]dto.ReviewerAssignment, error) {
+	const query = `
+		SELECT reviewer_id, source_team, COALESCE(verdict::text, ''), verdict_at, policy, owner_pattern, match_score, assigned_at, sla_breached_at
+		FROM pull_request_reviewers
+		WHERE pull_request_id = $1
+		ORDER BY reviewer_id
+	`
+
+	rows, err := tx.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assignments []dto.ReviewerAssignment
+	for rows.Next() {
+		a, err := scanAssignment(rows)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+// scanAssignment reads the assignment columns listAssignments selects, in
+// order, followed by dest.
+func scanAssignment(rows *sql.Rows, dest ...any) (dto.ReviewerAssignment, error) {
+	var a dto.ReviewerAssignment
+	var verdictAt, breachedAt sql.NullTime
+	cols := append([]any{&a.UserID, &a.TeamName, &a.Verdict, &verdictAt, &a.Policy, &a.OwnerPattern, &a.MatchScore, &a.AssignedAt, &breachedAt}, dest...)
+	if err := rows.Scan(cols...); err != nil {
+		return a, err
+	}
+	a.AssignedAt = a.AssignedAt.UTC()
+	a.VerdictAt = utcTime(verdictAt)
+	a.SLABreachedAt = utcTime(breachedAt)
+	return a, nil
+}
+
+// utcTime returns t in UTC, nil when it is NULL.
+func utcTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	utc := t.Time.UTC()
+	return &utc
+}
+
+// setAssignments fills the reviewer fields of the PR from its assignments.
+func setAssignments(pr *dto.PR, assignments []dto.ReviewerAssignment) {
+	pr.Reviewers = make([]string, 0, len(assignments))
+	for _, a := range assignments {
+		pr.Reviewers = append(pr.Reviewers, a.UserID)
+	}
+	pr.Assignments = assignments
+	pr.Understaffed = pr.Status != dto.PRStatusDraft && len(pr.Reviewers) < pr.RequestedReviewers
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pr-reviwer-assigner/internal/codeowners"
+	"pr-reviwer-assigner/internal/domain/repository"
+	errors2 "pr-reviwer-assigner/internal/errors"
+
+	"github.com/lib/pq"
+)
+
+type codeownersRepo struct {
+	db *sql.DB
+}
+
+func NewCodeownersRepository(db *sql.DB) repository.CodeownersRepository {
+	return &codeownersRepo{
+		db: db,
+	}
+}
+
+func (r *codeownersRepo) Get(ctx context.Context, teamName string) (string, error) {
+	const query = `
+		SELECT COALESCE(c.content, '')
+		FROM teams t
+		LEFT JOIN team_codeowners c
+		    ON c.team_name = t.team_name
+		WHERE t.team_name = $1
+	`
+
+	var content string
+	err := r.db.QueryRowContext(ctx, query, teamName).Scan(&content)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return "", errors2.ErrNotFound
+		default:
+			return "", err
+		}
+	}
+
+	return content, nil
+}
+
+func (r *codeownersRepo) Set(ctx context.Context, teamName, content string) error {
+	const upsertQuery = `
+		INSERT INTO team_codeowners (team_name, content)
+		VALUES ($1, $2)
+		ON CONFLICT (team_name) DO UPDATE
+		SET content    = EXCLUDED.content,
+		    updated_at = now()
+	`
+
+	const deleteQuery = `
+		DELETE FROM team_codeowners
+		WHERE team_name = $1
+	`
+
+	if content == "" {
+		if _, err := r.Get(ctx, teamName); err != nil {
+			return err
+		}
+		_, err := r.db.ExecContext(ctx, deleteQuery, teamName)
+		return err
+	}
+
+	_, err := r.db.ExecContext(ctx, upsertQuery, teamName, content)
+	if err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return errors2.ErrNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *codeownersRepo) UnknownOwners(ctx context.Context, owners []codeowners.Owner) ([]codeowners.Owner, error) {
+	const query = `
+		SELECT o.kind, o.name
+		FROM unnest($1::text[], $2::text[]) AS o(kind, name)
+		WHERE NOT CASE o.kind
+		    WHEN 'user'  THEN EXISTS (SELECT 1 FROM users u WHERE u.user_id = o.name)
+		    WHEN 'email' THEN EXISTS (SELECT 1 FROM users u WHERE lower(u.email) = o.name)
+		    ELSE EXISTS (SELECT 1 FROM teams t WHERE t.team_name = o.name)
+		END
+	`
+
+	kinds := make([]string, 0, len(owners))
+	names := make([]string, 0, len(owners))
+	for _, o := range owners {
+		kinds = append(kinds, string(o.Kind))
+		names = append(names, o.Name)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(kinds), pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var unknown []codeowners.Owner
+	for rows.Next() {
+		var o codeowners.Owner
+		if err := rows.Scan(&o.Kind, &o.Name); err != nil {
+			return nil, err
+		}
+		unknown = append(unknown, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return unknown, nil
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+	errors2 "pr-reviwer-assigner/internal/errors"
+
+	"github.com/lib/pq"
+)
+
+type notificationRepo struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) repository.NotificationRepository {
+	return &notificationRepo{
+		db: db,
+	}
+}
+
+func (r *notificationRepo) AssignmentNotice(ctx context.Context, prID, reviewerID string) (*dto.AssignmentNotice, error) {
+	const query = `
+		SELECT pr.pull_request_id,
+		       pr.pull_request_name,
+		       a.user_id,
+		       a.username,
+		       t.team_name,
+		       t.chat_webhook_url,
+		       rv.user_id,
+		       rv.username,
+		       rv.chat_handle,
+		       rv.email
+		FROM pull_requests pr
+		JOIN users a  ON a.user_id = pr.author_id
+		JOIN teams t  ON t.team_name = a.team_name
+		JOIN users rv ON rv.user_id = $2
+		WHERE pr.pull_request_id = $1
+	`
+
+	var n dto.AssignmentNotice
+	err := r.db.QueryRowContext(ctx, query, prID, reviewerID).Scan(
+		&n.PullRequestID,
+		&n.PullRequestName,
+		&n.AuthorID,
+		&n.AuthorName,
+		&n.TeamName,
+		&n.ChatWebhookURL,
+		&n.Reviewer.UserID,
+		&n.Reviewer.Username,
+		&n.Reviewer.ChatHandle,
+		&n.Reviewer.Email,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors2.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &n, nil
+}
+
+func (r *notificationRepo) ChatTeams(ctx context.Context) ([]dto.ChatTeam, error) {
+	const query = `
+		SELECT t.team_name, t.chat_webhook_url, u.user_id, u.username, u.chat_handle
+		FROM teams t
+		JOIN users u ON u.team_name = t.team_name
+		WHERE t.chat_webhook_url <> ''
+		  AND u.is_active
+		ORDER BY t.team_name, u.username, u.user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []dto.ChatTeam
+	for rows.Next() {
+		var team dto.ChatTeam
+		var member dto.Recipient
+		err = rows.Scan(
+			&team.TeamName,
+			&team.ChatWebhookURL,
+			&member.UserID,
+			&member.Username,
+			&member.ChatHandle,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if n := len(teams); n == 0 || teams[n-1].TeamName != team.TeamName {
+			teams = append(teams, team)
+		}
+		last := &teams[len(teams)-1]
+		last.Members = append(last.Members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func (r *notificationRepo) Recipients(ctx context.Context, userIDs []string) ([]dto.Recipient, error) {
+	const query = `
+		SELECT user_id, username, chat_handle, email
+		FROM users
+		WHERE user_id = ANY($1)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []dto.Recipient
+	for rows.Next() {
+		var rcpt dto.Recipient
+		if err := rows.Scan(&rcpt.UserID, &rcpt.Username, &rcpt.ChatHandle, &rcpt.Email); err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, rcpt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return recipients, nil
+}
+
+func (r *notificationRepo) Emailed(ctx context.Context, eventID int64) ([]string, error) {
+	const query = `
+		SELECT user_id
+		FROM email_deliveries
+		WHERE event_id = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+func (r *notificationRepo) MarkEmailed(ctx context.Context, eventID int64, userID string) error {
+	const query = `
+		INSERT INTO email_deliveries (event_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, eventID, userID)
+	return err
+}
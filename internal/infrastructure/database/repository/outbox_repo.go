@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/repository"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type outboxRepo struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) repository.OutboxRepository {
+	return &outboxRepo{
+		db: db,
+	}
+}
+
+// appendEvent writes an event to the outbox inside the caller's transaction,
+// so it is published only if the state change commits.
+func appendEvent(ctx context.Context, tx *sql.Tx, eventType, aggregateID string, payload any) error {
+	const query = `
+		INSERT INTO outbox_events (event_type, aggregate_id, payload)
+		VALUES ($1, $2, $3)
+	`
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, eventType, aggregateID, data)
+	return err
+}
+
+// appendAssigned writes a reviewer.assigned event per new assignment.
+func appendAssigned(ctx context.Context, tx *sql.Tx, name, authorID, prID string, assignments []dto.ReviewerAssignment) error {
+	for _, a := range assignments {
+		err := appendEvent(ctx, tx, events.ReviewerAssigned, prID, events.ReviewerAssignedPayload{
+			PullRequestID: prID,
+			Name:          name,
+			AuthorID:      authorID,
+			ReviewerID:    a.UserID,
+			TeamName:      a.TeamName,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *outboxRepo) Claim(ctx context.Context, limit int, lease time.Duration) ([]events.Event, error) {
+	const query = `
+		UPDATE outbox_events
+		   SET locked_until = now() + $2 * interval '1 millisecond'
+		 WHERE id IN (
+		     SELECT id
+		     FROM outbox_events
+		     WHERE dispatched_at IS NULL
+		       AND dead_at IS NULL
+		       AND (locked_until IS NULL OR locked_until < now())
+		     ORDER BY id
+		     LIMIT $1
+		     FOR UPDATE SKIP LOCKED
+		 )
+		RETURNING id, event_type, aggregate_id, payload, created_at, delivered_to, attempts
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var claimed []events.Event
+	for rows.Next() {
+		var ev events.Event
+		if err := rows.Scan(&ev.ID, &ev.Type, &ev.AggregateID, &ev.Payload, &ev.CreatedAt, pq.Array(&ev.DeliveredTo), &ev.Attempts); err != nil {
+			return nil, err
+		}
+		claimed = append(claimed, ev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING order is unspecified; sinks get events in commit order.
+	sort.Slice(claimed, func(i, j int) bool { return claimed[i].ID < claimed[j].ID })
+
+	return claimed, nil
+}
+
+func (r *outboxRepo) MarkDelivered(ctx context.Context, id int64, sink string) error {
+	const query = `
+		UPDATE outbox_events
+		   SET delivered_to = array_append(delivered_to, $2)
+		 WHERE id = $1
+		   AND NOT $2 = ANY(delivered_to)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, sink)
+	return err
+}
+
+func (r *outboxRepo) MarkDispatched(ctx context.Context, id int64) error {
+	const query = `
+		UPDATE outbox_events
+		   SET dispatched_at = now(),
+		       locked_until  = NULL,
+		       last_error    = NULL
+		 WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// MarkFailed records the error and holds the event back for a second per
+// failed attempt, up to a minute, before it can be claimed again. A dead
+// event is never claimed again.
+func (r *outboxRepo) MarkFailed(ctx context.Context, id int64, reason string, dead bool) error {
+	const query = `
+		UPDATE outbox_events
+		   SET attempts     = attempts + 1,
+		       last_error   = $2,
+		       locked_until = now() + LEAST(attempts + 1, 60) * interval '1 second',
+		       dead_at      = CASE WHEN $3 THEN now() END
+		 WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, reason, dead)
+	return err
+}
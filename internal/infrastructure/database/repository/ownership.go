@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"pr-reviwer-assigner/internal/codeowners"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/selector"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// assignOwners picks one reviewer for every CODEOWNERS rule of the author's team
+// that owns a changed file of the pull request and has no owner among its
+// reviewers yet, until count reviewers are picked. Owners are picked with the
+// home team's strategy and working-hours policy but keep their own team.
+func (s *prRepo) assignOwners(ctx context.Context, tx *sql.Tx, home *teamPolicy, authorID, prID, replacing string, needs *prNeeds, count int, now time.Time) ([]dto.ReviewerAssignment, error) {
+	const insertOwnerQuery = `
+		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, source_team, policy, owner_pattern, match_score)
+		SELECT $1, user_id, team_name, $3, $4, $5
+		FROM users
+		WHERE user_id = $2
+		RETURNING source_team
+	`
+
+	assigned := make([]dto.ReviewerAssignment, 0, count)
+
+	owning, err := needs.owningRules()
+	if err != nil {
+		return nil, err
+	}
+	if len(owning) == 0 {
+		return assigned, nil
+	}
+
+	current, err := listAssignments(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+	reviewers := make(map[string]bool, len(current))
+	for _, a := range current {
+		if a.UserID != replacing {
+			reviewers[a.UserID] = true
+		}
+	}
+
+	for _, rule := range owning {
+		if len(assigned) >= count {
+			break
+		}
+
+		owners, err := resolveOwners(ctx, tx, rule.Owners)
+		if err != nil {
+			return nil, err
+		}
+		if coversAny(reviewers, owners) {
+			continue
+		}
+
+		candidates, err := listUserCandidates(ctx, tx, owners, authorID, prID)
+		if err != nil {
+			return nil, err
+		}
+
+		picks := selector.Choose(s.selectors.Get(home.Strategy), selector.Request{
+			PullRequestID:  prID,
+			AuthorID:       authorID,
+			Count:          1,
+			Cursor:         home.Cursor,
+			RequiredSkills: needs.Skills,
+		}, candidates, now, home.LeadTime)
+
+		for _, p := range picks {
+			a := dto.ReviewerAssignment{
+				UserID:       p.UserID,
+				Policy:       p.Policy,
+				OwnerPattern: rule.Pattern,
+				MatchScore:   needs.score(p),
+			}
+			err := tx.QueryRowContext(ctx, insertOwnerQuery, prID, p.UserID, p.Policy, rule.Pattern, a.MatchScore).Scan(&a.TeamName)
+			if err != nil {
+				return nil, err
+			}
+			assigned = append(assigned, a)
+			reviewers[p.UserID] = true
+		}
+	}
+
+	return assigned, nil
+}
+
+// prNeeds is what a pull request asks of its reviewers.
+type prNeeds struct {
+	ChangedFiles []string
+	Skills       []string
+	// Codeowners is the CODEOWNERS file of the author's team.
+	Codeowners string
+}
+
+func readPRNeeds(ctx context.Context, tx *sql.Tx, prID string) (*prNeeds, error) {
+	const query = `
+		SELECT p.changed_files, p.required_skills, COALESCE(c.content, '')
+		FROM pull_requests p
+		JOIN users u
+		    ON u.user_id = p.author_id
+		LEFT JOIN team_codeowners c
+		    ON c.team_name = u.team_name
+		WHERE p.pull_request_id = $1
+	`
+
+	var n prNeeds
+	err := tx.QueryRowContext(ctx, query, prID).Scan(pq.Array(&n.ChangedFiles), pq.Array(&n.Skills), &n.Codeowners)
+	if err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
+
+// owningRules returns the CODEOWNERS rules that own at least one changed file.
+func (n *prNeeds) owningRules() ([]codeowners.Rule, error) {
+	if len(n.ChangedFiles) == 0 || n.Codeowners == "" {
+		return nil, nil
+	}
+
+	rules, err := codeowners.Parse(strings.NewReader(n.Codeowners))
+	if err != nil {
+		return nil, err
+	}
+
+	return rules.Owning(n.ChangedFiles), nil
+}
+
+// score is the match score stored for the pick, nil when no skills are required.
+func (n *prNeeds) score(p selector.Pick) *float64 {
+	if len(n.Skills) == 0 {
+		return nil
+	}
+	score := p.MatchScore
+	return &score
+}
+
+// resolveOwners returns the IDs of the users the owners stand for: the users
+// themselves, the users with the email and the members of the teams.
+func resolveOwners(ctx context.Context, tx *sql.Tx, owners []codeowners.Owner) ([]string, error) {
+	const query = `
+		SELECT user_id
+		FROM users
+		WHERE user_id = ANY($1)
+		   OR (email <> '' AND lower(email) = ANY($2))
+		   OR team_name = ANY($3)
+		ORDER BY user_id
+	`
+
+	var users, emails, teams []string
+	for _, o := range owners {
+		switch o.Kind {
+		case codeowners.OwnerUser:
+			users = append(users, o.Name)
+		case codeowners.OwnerEmail:
+			emails = append(emails, o.Name)
+		case codeowners.OwnerTeam:
+			teams = append(teams, o.Name)
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, query, pq.Array(users), pq.Array(emails), pq.Array(teams))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func coversAny(reviewers map[string]bool, owners []string) bool {
+	for _, id := range owners {
+		if reviewers[id] {
+			return true
+		}
+	}
+	return false
+}
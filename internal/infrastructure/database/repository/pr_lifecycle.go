@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pr-reviwer-assigner/internal/domain/dto"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"time"
+)
+
+// lockPRStatus locks the pull request row and returns its status.
+func lockPRStatus(ctx context.Context, tx *sql.Tx, prID string) (string, error) {
+	const query = `
+		SELECT status::text
+		FROM pull_requests
+		WHERE pull_request_id = $1
+		FOR UPDATE
+	`
+
+	var status string
+	err := tx.QueryRowContext(ctx, query, prID).Scan(&status)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return "", errors2.ErrNotFound
+		default:
+			return "", err
+		}
+	}
+
+	return status, nil
+}
+
+// prColumns are the pull_requests columns scanPR reads, in order.
+const prColumns = `
+		    pull_request_id,
+		    pull_request_name,
+		    author_id,
+		    status::text,
+		    created_at,
+		    merged_at,
+		    closed_at,
+		    updated_at,
+		    requested_reviewers`
+
+func scanPR(row interface{ Scan(dest ...any) error }) (*dto.PR, error) {
+	var pr dto.PR
+	var mergedAt, closedAt sql.NullTime
+	err := row.Scan(
+		&pr.ID,
+		&pr.Name,
+		&pr.AuthorID,
+		&pr.Status,
+		&pr.CreatedAt,
+		&mergedAt,
+		&closedAt,
+		&pr.UpdatedAt,
+		&pr.RequestedReviewers,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.CreatedAt = pr.CreatedAt.UTC()
+	pr.MergedAt = utcTime(mergedAt)
+	pr.ClosedAt = utcTime(closedAt)
+	pr.UpdatedAt = pr.UpdatedAt.UTC()
+
+	return &pr, nil
+}
+
+// setStatus moves the pull request to status and returns the updated row.
+// closed_at is set when closing and cleared otherwise. A pull request already
+// in status keeps its updated_at, so repeated calls don't move it in listings.
+func setStatus(ctx context.Context, tx *sql.Tx, prID, status string) (*dto.PR, error) {
+	const query = `
+		UPDATE pull_requests
+		   SET status     = $2,
+		       closed_at  = CASE WHEN $2 = 'CLOSED' THEN COALESCE(closed_at, $3) END,
+		       updated_at = CASE WHEN status = $2 THEN updated_at ELSE $3 END
+		 WHERE pull_request_id = $1
+		RETURNING` + prColumns
+
+	return scanPR(tx.QueryRowContext(ctx, query, prID, status, time.Now().UTC()))
+}
+
+func (s *prRepo) Close(ctx context.Context, prID string) (*dto.PR, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	status, err := lockPRStatus(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == dto.PRStatusMerged {
+		return nil, errors2.ErrPRMerged
+	}
+
+	pr, err := setStatus(ctx, tx, prID, dto.PRStatusClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := listAssignments(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+	setAssignments(pr, assignments)
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// Reopen moves a closed pull request back to OPEN and tops up its reviewers,
+// which matters for drafts that were closed before getting any.
+func (s *prRepo) Reopen(ctx context.Context, prID string) (*dto.PR, error) {
+	return s.open(ctx, prID, dto.PRStatusClosed)
+}
+
+// MarkReady moves a draft to OPEN and assigns its reviewers.
+func (s *prRepo) MarkReady(ctx context.Context, prID string) (*dto.PR, error) {
+	return s.open(ctx, prID, dto.PRStatusDraft)
+}
+
+const restartSLAQuery = `
+	UPDATE pull_request_reviewers
+	   SET assigned_at     = now(),
+	       sla_breached_at = NULL
+	 WHERE pull_request_id = $1
+	   AND verdict IS NULL
+`
+
+// open moves the pull request from the given status to OPEN. Opening an
+// already open pull request is a no-op.
+func (s *prRepo) open(ctx context.Context, prID, from string) (*dto.PR, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	status, err := lockPRStatus(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != from && status != dto.PRStatusOpen {
+		switch status {
+		case dto.PRStatusMerged:
+			return nil, errors2.ErrPRMerged
+		case dto.PRStatusClosed:
+			return nil, errors2.ErrPRClosed
+		default:
+			return nil, errors2.ErrPRDraft
+		}
+	}
+
+	pr, err := setStatus(ctx, tx, prID, dto.PRStatusOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	// Time spent closed or in draft doesn't count against the review SLA.
+	if status != dto.PRStatusOpen {
+		_, err := tx.ExecContext(ctx, restartSLAQuery, prID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	assignments, err := listAssignments(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if missing := pr.RequestedReviewers - len(assignments); missing > 0 && status != dto.PRStatusOpen {
+		var authorTeam string
+		err = tx.QueryRowContext(ctx,
+			`SELECT team_name FROM users WHERE user_id = $1`,
+			pr.AuthorID,
+		).Scan(&authorTeam)
+		if err != nil {
+			return nil, err
+		}
+
+		policy, err := lockTeamPolicy(ctx, tx, authorTeam)
+		if err != nil {
+			return nil, err
+		}
+
+		picked, err := s.assignReviewers(ctx, tx, policy, pr.AuthorID, prID, "", missing)
+		if err != nil {
+			return nil, err
+		}
+		if err := appendAssigned(ctx, tx, pr.Name, pr.AuthorID, prID, picked); err != nil {
+			return nil, err
+		}
+
+		assignments, err = listAssignments(ctx, tx, prID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	setAssignments(pr, assignments)
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// SubmitReview records the reviewer's verdict on an open pull request. The
+// latest verdict is kept on the assignment, every verdict goes to the history.
+func (s *prRepo) SubmitReview(ctx context.Context, req dto.ReviewRequest) (*dto.PR, error) {
+	const updateVerdictQuery = `
+		UPDATE pull_request_reviewers
+		   SET verdict    = $3,
+		       verdict_at = $4
+		 WHERE pull_request_id = $1
+		   AND reviewer_id = $2
+	`
+
+	const insertHistoryQuery = `
+		INSERT INTO pull_request_reviews (pull_request_id, reviewer_id, verdict, submitted_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	const prQuery = `
+		SELECT` + prColumns + `
+		FROM pull_requests
+		WHERE pull_request_id = $1
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	status, err := lockPRStatus(ctx, tx, req.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case dto.PRStatusMerged:
+		return nil, errors2.ErrPRMerged
+	case dto.PRStatusClosed:
+		return nil, errors2.ErrPRClosed
+	case dto.PRStatusDraft:
+		return nil, errors2.ErrPRDraft
+	}
+
+	submittedAt := time.Now().UTC()
+	res, err := tx.ExecContext(ctx, updateVerdictQuery, req.PullRequestID, req.ReviewerID, req.Verdict, submittedAt)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, errors2.ErrNotAssigned
+	}
+
+	_, err = tx.ExecContext(ctx, insertHistoryQuery, req.PullRequestID, req.ReviewerID, req.Verdict, submittedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := scanPR(tx.QueryRowContext(ctx, prQuery, req.PullRequestID))
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := listAssignments(ctx, tx, req.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	setAssignments(pr, assignments)
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// List returns up to f.Limit pull requests after f.After in the sort order,
+// with their reviewers. Only the filters that are set make it into the query,
+// so every page is a range scan of one of the keyset indexes of the
+// 0022_pull_request_list_indexes migration.
+func (s *prRepo) List(ctx context.Context, f dto.PRListFilter) ([]dto.PR, error) {
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if len(f.Statuses) > 0 {
+		where = append(where, "status = ANY("+arg(pq.Array(f.Statuses))+"::pull_request_status[])")
+	}
+	if f.AuthorID != "" {
+		where = append(where, "author_id = "+arg(f.AuthorID))
+	}
+	if f.TeamName != "" {
+		where = append(where, "author_id IN (SELECT user_id FROM users WHERE team_name = "+arg(f.TeamName)+")")
+	}
+	if f.ReviewerID != "" {
+		where = append(where, `EXISTS (
+		        SELECT 1
+		        FROM pull_request_reviewers prr
+		        WHERE prr.pull_request_id = pull_requests.pull_request_id
+		          AND prr.reviewer_id = `+arg(f.ReviewerID)+`
+		    )`)
+	}
+	if f.CreatedFrom != nil {
+		where = append(where, "created_at >= "+arg(*f.CreatedFrom))
+	}
+	if f.CreatedTo != nil {
+		where = append(where, "created_at < "+arg(*f.CreatedTo))
+	}
+
+	sortColumn := "created_at"
+	if f.SortBy == dto.PRSortUpdatedAt {
+		sortColumn = "updated_at"
+	}
+	direction, after := "ASC", ">"
+	if f.Desc {
+		direction, after = "DESC", "<"
+	}
+	if f.After != nil {
+		where = append(where, fmt.Sprintf("(%s, pull_request_id) %s (%s, %s)", sortColumn, after, arg(f.After.Time), arg(f.After.ID)))
+	}
+
+	query := `
+		SELECT` + prColumns + `
+		FROM pull_requests`
+	if len(where) > 0 {
+		query += `
+		WHERE ` + strings.Join(where, `
+		  AND `)
+	}
+	query += fmt.Sprintf(`
+		ORDER BY %[1]s %[2]s, pull_request_id %[2]s
+		LIMIT %[3]s
+	`, sortColumn, direction, arg(f.Limit))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prs := make([]dto.PR, 0, f.Limit)
+	for rows.Next() {
+		pr, err := scanPR(rows)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, *pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := setPageAssignments(ctx, tx, prs); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return prs, nil
+}
+
+// setPageAssignments loads the reviewers of all the pull requests at once.
+func setPageAssignments(ctx context.Context, tx *sql.Tx, prs []dto.PR) error {
+	const query = `
+		SELECT reviewer_id, source_team, COALESCE(verdict::text, ''), verdict_at, policy, owner_pattern, match_score, assigned_at, sla_breached_at, pull_request_id
+		FROM pull_request_reviewers
+		WHERE pull_request_id = ANY($1)
+		ORDER BY pull_request_id, reviewer_id
+	`
+
+	if len(prs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.ID)
+	}
+
+	rows, err := tx.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	byPR := make(map[string][]dto.ReviewerAssignment, len(prs))
+	for rows.Next() {
+		var prID string
+		a, err := scanAssignment(rows, &prID)
+		if err != nil {
+			return err
+		}
+		byPR[prID] = append(byPR[prID], a)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range prs {
+		setAssignments(&prs[i], byPR[prs[i].ID])
+	}
+	return nil
+}
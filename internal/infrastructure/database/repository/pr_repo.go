@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
 	"pr-reviwer-assigner/internal/domain/repository"
+	"pr-reviwer-assigner/internal/domain/selector"
 	errors2 "pr-reviwer-assigner/internal/errors"
 	"time"
 
@@ -14,34 +17,21 @@ import (
 )
 
 type prRepo struct {
-	db *sql.DB
+	db        *sql.DB
+	selectors *selector.Registry
 }
 
-func NewPRRepository(db *sql.DB) repository.PRRepository {
+func NewPRRepository(db *sql.DB, selectors *selector.Registry) repository.PRRepository {
 	return &prRepo{
-		db: db,
+		db:        db,
+		selectors: selectors,
 	}
 }
 
-func (s *prRepo) Create(ctx context.Context, req dto.PRRequest) ([]string, error) {
+func (s *prRepo) Create(ctx context.Context, req dto.PRRequest) (*dto.PR, error) {
 	const createQuery = `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-	`
-
-	const pickupQuery = `
-		SELECT user_id
-		FROM users
-		WHERE team_name = $1
-			AND is_active = TRUE
-			AND user_id <> $2
-		ORDER BY user_id
-		LIMIT 2
-	`
-
-	const insertReviewerQuery = `
-		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id)
-		VALUES ($1, $2)
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, updated_at, requested_reviewers, changed_files, required_skills)
+		VALUES ($1, $2, $3, $4, $5, $5, $6, $7, $8)
 	`
 
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -67,13 +57,36 @@ func (s *prRepo) Create(ctx context.Context, req dto.PRRequest) ([]string, error
 		return nil, err
 	}
 
-	createdAt := time.Now().UTC().String()
+	policy, err := lockTeamPolicy(ctx, tx, authorTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := policy.ReviewersCount
+	if req.ReviewersCount != nil {
+		requested = *req.ReviewersCount
+		if requested < policy.MinReviewers || requested > policy.MaxReviewers {
+			return nil, fmt.Errorf("%w: team %s allows %d..%d reviewers",
+				errors2.ErrInvalidReviewersCount, authorTeam, policy.MinReviewers, policy.MaxReviewers)
+		}
+	}
+
+	status := dto.PRStatusOpen
+	if req.Draft {
+		status = dto.PRStatusDraft
+	}
+
+	// Truncated to what timestamptz keeps, so that the response matches the row.
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
 	_, err = tx.ExecContext(ctx, createQuery,
 		req.ID,
 		req.Name,
 		req.AuthorID,
-		"OPEN",
+		status,
 		createdAt,
+		requested,
+		pq.Array(req.ChangedFiles),
+		pq.Array(req.RequiredSkills),
 	)
 	if err != nil {
 		switch {
@@ -84,61 +97,122 @@ func (s *prRepo) Create(ctx context.Context, req dto.PRRequest) ([]string, error
 		}
 	}
 
-	rows, err := tx.QueryContext(ctx, pickupQuery,
-		authorTeam,
-		req.AuthorID,
-	)
-	if err != nil {
+	if err := saveReviewerRules(ctx, tx, req.ID, req.AuthorID, req.RequiredReviewers, req.ExcludedReviewers); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var reviewers []string
-	for rows.Next() {
-		var userID string
-		if err := rows.Scan(&userID); err != nil {
+	// Drafts get their reviewers when they are marked ready.
+	var assignments []dto.ReviewerAssignment
+	if status == dto.PRStatusOpen {
+		assignments, err = s.assignReviewers(ctx, tx, policy, req.AuthorID, req.ID, "", requested)
+		if err != nil {
 			return nil, err
 		}
-		reviewers = append(reviewers, userID)
 	}
-	if err := rows.Err(); err != nil {
+
+	pr := &dto.PR{
+		ID:                 req.ID,
+		Name:               req.Name,
+		AuthorID:           req.AuthorID,
+		Status:             status,
+		RequestedReviewers: requested,
+		CreatedAt:          createdAt,
+		UpdatedAt:          createdAt,
+	}
+	setAssignments(pr, assignments)
+
+	err = appendEvent(ctx, tx, events.PRCreated, pr.ID, events.PRCreatedPayload{
+		PullRequestID: pr.ID,
+		Name:          pr.Name,
+		AuthorID:      pr.AuthorID,
+		Status:        pr.Status,
+		Reviewers:     pr.Reviewers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := appendAssigned(ctx, tx, pr.Name, pr.AuthorID, pr.ID, assignments); err != nil {
 		return nil, err
 	}
 
-	for _, id := range reviewers {
-		if _, err := tx.ExecContext(ctx, insertReviewerQuery, req.ID, id); err != nil {
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *prRepo) Get(ctx context.Context, prID string) (*dto.PR, error) {
+	const query = `
+		SELECT` + prColumns + `
+		FROM pull_requests
+		WHERE pull_request_id = $1
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	pr, err := scanPR(tx.QueryRowContext(ctx, query, prID))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors2.ErrNotFound
+		default:
 			return nil, err
 		}
 	}
 
+	assignments, err := listAssignments(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+	setAssignments(pr, assignments)
+
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
 
-	return reviewers, nil
+	return pr, nil
 }
 
 func (s *prRepo) Merge(ctx context.Context, req dto.MergeRequest) (*dto.PR, error) {
+	return s.merge(ctx, req.PullRequestID, false)
+}
+
+func (s *prRepo) RecordMerge(ctx context.Context, prID string) (*dto.PR, error) {
+	return s.merge(ctx, prID, true)
+}
+
+// merge marks the pull request merged. An external merge already happened on
+// the VCS provider, so it is recorded whatever the status and approvals.
+func (s *prRepo) merge(ctx context.Context, prID string, external bool) (*dto.PR, error) {
 	const mergeQuery = `
 		UPDATE pull_requests
-   		SET status    = $2,
-       		merged_at = COALESCE(merged_at, $3)
- 		WHERE pull_request_id = $1
-		RETURNING 
-		    pull_request_id,
-            pull_request_name,
-            author_id,
-            status::text,
-            created_at,
-            merged_at
- 		`
-
-	const reviewersQuery = `
-		SELECT reviewer_id
-		FROM pull_request_reviewers
-		WHERE pull_request_id = $1
-		ORDER BY reviewer_id
-		`
+		   SET merged_at  = COALESCE(merged_at, $3),
+		       updated_at = CASE WHEN status = $2 THEN updated_at ELSE $3 END,
+		       status     = $2
+		 WHERE pull_request_id = $1
+		RETURNING` + prColumns
+
+	// min_approvals of the author's team against APPROVED verdicts of the
+	// current reviewers.
+	const approvalsQuery = `
+		SELECT
+		    t.min_approvals,
+		    (
+		        SELECT COUNT(*)
+		        FROM pull_request_reviewers prr
+		        WHERE prr.pull_request_id = pr.pull_request_id
+		          AND prr.verdict = 'APPROVED'
+		    )
+		FROM pull_requests pr
+		JOIN users u ON u.user_id = pr.author_id
+		JOIN teams t ON t.team_name = u.team_name
+		WHERE pr.pull_request_id = $1
+	`
 
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -146,16 +220,29 @@ func (s *prRepo) Merge(ctx context.Context, req dto.MergeRequest) (*dto.PR, erro
 	}
 	defer tx.Rollback()
 
-	mergedAt := time.Now().UTC().String()
-	var pr dto.PR
-	err = tx.QueryRowContext(ctx, mergeQuery, req.PullRequestID, "MERGED", mergedAt).Scan(
-		&pr.ID,
-		&pr.Name,
-		&pr.AuthorID,
-		&pr.Status,
-		&pr.CreatedAt,
-		&pr.MergedAt,
-	)
+	status, err := lockPRStatus(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case external:
+	case status == dto.PRStatusClosed:
+		return nil, errors2.ErrPRClosed
+	case status == dto.PRStatusDraft:
+		return nil, errors2.ErrPRDraft
+	case status == dto.PRStatusOpen:
+		var required, approved int
+		err = tx.QueryRowContext(ctx, approvalsQuery, prID).Scan(&required, &approved)
+		if err != nil {
+			return nil, err
+		}
+		if approved < required {
+			return nil, fmt.Errorf("%w: %d of %d", errors2.ErrNotEnoughApprovals, approved, required)
+		}
+	}
+
+	pr, err := scanPR(tx.QueryRowContext(ctx, mergeQuery, prID, dto.PRStatusMerged, time.Now().UTC()))
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -165,44 +252,33 @@ func (s *prRepo) Merge(ctx context.Context, req dto.MergeRequest) (*dto.PR, erro
 		}
 	}
 
-	rows, err := tx.QueryContext(ctx, reviewersQuery, pr.ID)
+	assignments, err := listAssignments(ctx, tx, pr.ID)
 	if err != nil {
 		return nil, err
 	}
-
-	var reviewers []string
-	for rows.Next() {
-		var userID string
-		if err := rows.Scan(&userID); err != nil {
+	setAssignments(pr, assignments)
+
+	// Merging an already merged PR is a no-op and publishes nothing.
+	if status != dto.PRStatusMerged {
+		err = appendEvent(ctx, tx, events.PRMerged, pr.ID, events.PRMergedPayload{
+			PullRequestID: pr.ID,
+			Name:          pr.Name,
+			AuthorID:      pr.AuthorID,
+			Reviewers:     pr.Reviewers,
+		})
+		if err != nil {
 			return nil, err
 		}
-		reviewers = append(reviewers, userID)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
 	}
 
-	pr.Reviewers = reviewers
-
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
 
-	return &pr, nil
+	return pr, nil
 }
 
 func (s *prRepo) Reassign(ctx context.Context, req dto.ReassignRequest) (*dto.PR, string, error) {
-	const prQuery = `
-		SELECT 
-		    pull_request_id,
-			pull_request_name,
-			author_id,
-			status::text
-		FROM pull_requests
-		WHERE pull_request_id = $1
-		FOR UPDATE
-	`
-
 	const oldUserQuery = `
 		SELECT 
 		    team_name
@@ -218,65 +294,21 @@ func (s *prRepo) Reassign(ctx context.Context, req dto.ReassignRequest) (*dto.PR
 			AND reviewer_id = $2
 	`
 
-	const findNewRevQuery = `
-		SELECT u.user_id
-		FROM users u
-		WHERE u.team_name = $1
-			AND u.is_active = TRUE
-			AND u.user_id <> $2
-		    AND u.user_id <> $3
-		    AND NOT EXISTS (
-			   	SELECT 1
-					FROM pull_request_reviewers prr
-				WHERE prr.pull_request_id = $4
-					AND prr.reviewer_id     = u.user_id
-		   )
-		ORDER BY u.user_id
-		LIMIT 1
-	`
-
 	const deleteOldRevQuery = `
 		DELETE FROM pull_request_reviewers
 		WHERE pull_request_id = $1
 		AND reviewer_id     = $2
 	`
 
-	const insertNewRevQuery = `
-		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id)
-		VALUES ($1, $2)
-	`
-
-	const collectRevQuery = `
-		SELECT reviewer_id
-		FROM pull_request_reviewers
-		WHERE pull_request_id = $1
-		ORDER BY reviewer_id
-	`
-
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, "", err
 	}
 	defer tx.Rollback()
 
-	var pr dto.PR
-	err = tx.QueryRowContext(ctx, prQuery, req.PullRequestID).Scan(
-		&pr.ID,
-		&pr.Name,
-		&pr.AuthorID,
-		&pr.Status,
-	)
+	pr, err := lockOpenPR(ctx, tx, req.PullRequestID)
 	if err != nil {
-		switch {
-		case errors.Is(err, sql.ErrNoRows):
-			return nil, "", errors2.ErrNotFound
-		default:
-			return nil, "", err
-		}
-	}
-
-	if pr.Status == "MERGED" {
-		return nil, "", errors2.ErrPRMerged
+		return nil, "", err
 	}
 
 	var oldUserTeam string
@@ -301,45 +333,57 @@ func (s *prRepo) Reassign(ctx context.Context, req dto.ReassignRequest) (*dto.PR
 		}
 	}
 
-	var newReviewerID string
-	err = tx.QueryRowContext(ctx, findNewRevQuery, oldUserTeam, pr.AuthorID, req.OldUserID, req.PullRequestID).Scan(&newReviewerID)
-	if err != nil {
-		switch {
-		case errors.Is(err, sql.ErrNoRows):
-			return nil, "", errors2.ErrNoCandidate
-		default:
+	if err := checkNotRequired(ctx, tx, req.PullRequestID, req.OldUserID); err != nil {
+		return nil, "", err
+	}
+
+	var picked []dto.ReviewerAssignment
+	if req.NewUserID != "" {
+		a, err := assignManual(ctx, tx, pr, req.NewUserID)
+		if err != nil {
+			return nil, "", err
+		}
+		picked = append(picked, *a)
+	} else {
+		policy, err := lockTeamPolicy(ctx, tx, oldUserTeam)
+		if err != nil {
 			return nil, "", err
 		}
+
+		// The new reviewer is picked while the old one is still assigned, so the
+		// old reviewer can't be picked again.
+		picked, err = s.assignReviewers(ctx, tx, policy, pr.AuthorID, req.PullRequestID, req.OldUserID, 1)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(picked) == 0 {
+			return nil, "", errors2.ErrNoCandidate
+		}
 	}
+	newReviewerID := picked[0].UserID
 
 	_, err = tx.ExecContext(ctx, deleteOldRevQuery, req.PullRequestID, req.OldUserID)
 	if err != nil {
 		return nil, "", err
 	}
 
-	_, err = tx.ExecContext(ctx, insertNewRevQuery, req.PullRequestID, newReviewerID)
+	assignments, err := listAssignments(ctx, tx, req.PullRequestID)
 	if err != nil {
 		return nil, "", err
 	}
+	setAssignments(pr, assignments)
 
-	rows, err := tx.QueryContext(ctx, collectRevQuery, req.PullRequestID)
-	if err != nil {
+	if err := appendAssigned(ctx, tx, pr.Name, pr.AuthorID, pr.ID, picked); err != nil {
 		return nil, "", err
 	}
-	defer rows.Close()
-
-	var reviewers []string
-	for rows.Next() {
-		var reviewerID string
-		if err := rows.Scan(&reviewerID); err != nil {
-			return nil, "", err
-		}
-		reviewers = append(reviewers, reviewerID)
-	}
-
-	pr.Reviewers = reviewers
-
-	if err := rows.Err(); err != nil {
+	err = appendEvent(ctx, tx, events.ReviewerReplaced, pr.ID, events.ReviewerReplacedPayload{
+		PullRequestID: pr.ID,
+		Name:          pr.Name,
+		AuthorID:      pr.AuthorID,
+		OldReviewerID: req.OldUserID,
+		NewReviewerID: newReviewerID,
+	})
+	if err != nil {
 		return nil, "", err
 	}
 
@@ -347,7 +391,7 @@ func (s *prRepo) Reassign(ctx context.Context, req dto.ReassignRequest) (*dto.PR
 		return nil, "", err
 	}
 
-	return &pr, newReviewerID, nil
+	return pr, newReviewerID, nil
 }
 
 func isUniqueViolation(err error) bool {
@@ -358,6 +402,22 @@ func isUniqueViolation(err error) bool {
 	return false
 }
 
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23503"
+	}
+	return false
+}
+
+func isCheckViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23514"
+	}
+	return false
+}
+
 func (s *prRepo) ListOpenAssignments(ctx context.Context, reviewerID string) ([]string, error) {
 	const query = `
 		SELECT pr.pull_request_id
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+// lockOpenPR reads the pull request and locks its row. Reviewers of merged,
+// closed and draft pull requests can't be changed.
+func lockOpenPR(ctx context.Context, tx *sql.Tx, prID string) (*dto.PR, error) {
+	const query = `
+		SELECT` + prColumns + `
+		FROM pull_requests
+		WHERE pull_request_id = $1
+		FOR UPDATE
+	`
+
+	pr, err := scanPR(tx.QueryRowContext(ctx, query, prID))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors2.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	switch pr.Status {
+	case dto.PRStatusMerged:
+		return nil, errors2.ErrPRMerged
+	case dto.PRStatusClosed:
+		return nil, errors2.ErrPRClosed
+	case dto.PRStatusDraft:
+		return nil, errors2.ErrPRDraft
+	}
+
+	return pr, nil
+}
+
+// assignManual assigns the named user to the locked pull request. The user's
+// row is locked as well, so they can't be deactivated meanwhile. The user must
+// be active, not the author and not excluded from the pull request.
+func assignManual(ctx context.Context, tx *sql.Tx, pr *dto.PR, userID string) (*dto.ReviewerAssignment, error) {
+	const userQuery = `
+		SELECT
+		    u.team_name,
+		    u.is_active,
+		    EXISTS (
+		        SELECT 1
+		        FROM pull_request_reviewer_rules r
+		        WHERE r.pull_request_id = $2
+		          AND r.user_id = u.user_id
+		          AND NOT r.required
+		    )
+		FROM users u
+		WHERE u.user_id = $1
+		FOR UPDATE OF u
+	`
+
+	const insertQuery = `
+		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, source_team, policy)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	a := dto.ReviewerAssignment{UserID: userID, Policy: dto.PolicyManual}
+	var isActive, excluded bool
+	err := tx.QueryRowContext(ctx, userQuery, userID, pr.ID).Scan(&a.TeamName, &isActive, &excluded)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors2.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	switch {
+	case userID == pr.AuthorID:
+		return nil, fmt.Errorf("%w: reviewer %s is the author of the pull request", errors2.ErrInvalidReviewers, userID)
+	case !isActive:
+		return nil, fmt.Errorf("%w: reviewer %s is inactive", errors2.ErrInvalidReviewers, userID)
+	case excluded:
+		return nil, fmt.Errorf("%w: reviewer %s is excluded from the pull request", errors2.ErrInvalidReviewers, userID)
+	}
+
+	_, err = tx.ExecContext(ctx, insertQuery, pr.ID, userID, a.TeamName, a.Policy)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err):
+			return nil, errors2.ErrAlreadyAssigned
+		default:
+			return nil, err
+		}
+	}
+
+	return &a, nil
+}
+
+func (s *prRepo) AddReviewer(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	pr, err := lockOpenPR(ctx, tx, req.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := assignManual(ctx, tx, pr, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := listAssignments(ctx, tx, pr.ID)
+	if err != nil {
+		return nil, err
+	}
+	setAssignments(pr, assignments)
+
+	if err := appendAssigned(ctx, tx, pr.Name, pr.AuthorID, pr.ID, []dto.ReviewerAssignment{*a}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// RemoveReviewer unassigns the reviewer without picking a replacement. Active
+// required reviewers can't be removed.
+func (s *prRepo) RemoveReviewer(ctx context.Context, req dto.ReviewerRequest) (*dto.PR, error) {
+	const deleteQuery = `
+		DELETE FROM pull_request_reviewers
+		WHERE pull_request_id = $1
+		  AND reviewer_id = $2
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	pr, err := lockOpenPR(ctx, tx, req.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkNotRequired(ctx, tx, pr.ID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	res, err := tx.ExecContext(ctx, deleteQuery, pr.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, errors2.ErrNotAssigned
+	}
+
+	assignments, err := listAssignments(ctx, tx, pr.ID)
+	if err != nil {
+		return nil, err
+	}
+	setAssignments(pr, assignments)
+
+	err = appendEvent(ctx, tx, events.ReviewerRemoved, pr.ID, events.ReviewerRemovedPayload{
+		PullRequestID: pr.ID,
+		Name:          pr.Name,
+		AuthorID:      pr.AuthorID,
+		ReviewerID:    req.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
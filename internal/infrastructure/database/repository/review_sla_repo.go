@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/repository"
+
+	"github.com/lib/pq"
+)
+
+type reviewSLARepo struct {
+	db *sql.DB
+}
+
+func NewReviewSLARepository(db *sql.DB) repository.ReviewSLARepository {
+	return &reviewSLARepo{
+		db: db,
+	}
+}
+
+func (r *reviewSLARepo) ListPending(ctx context.Context, after dto.PendingReview, limit int) ([]dto.PendingReview, error) {
+	// Working hours never add up faster than the wall clock, so reviews assigned
+	// less than review_sla_hours ago can't have breached the SLA yet.
+	const query = `
+		SELECT
+		    prr.pull_request_id,
+		    pr.pull_request_name,
+		    pr.author_id,
+		    prr.reviewer_id,
+		    t.team_name,
+		    prr.assigned_at,
+		    t.review_sla_hours,
+		    t.sla_auto_reassign,
+		    u.is_active AND EXISTS (
+		        SELECT 1
+		        FROM pull_request_reviewer_rules r
+		        WHERE r.pull_request_id = prr.pull_request_id
+		          AND r.user_id = prr.reviewer_id
+		          AND r.required
+		    ),
+		    u.time_zone,
+		    to_char(u.work_start, 'HH24:MI'),
+		    to_char(u.work_end, 'HH24:MI'),
+		    u.work_days
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr
+		    ON pr.pull_request_id = prr.pull_request_id
+		   AND pr.status = 'OPEN'
+		JOIN users a
+		    ON a.user_id = pr.author_id
+		JOIN teams t
+		    ON t.team_name = a.team_name
+		JOIN users u
+		    ON u.user_id = prr.reviewer_id
+		WHERE prr.verdict IS NULL
+			AND prr.sla_breached_at IS NULL
+			AND t.review_sla_hours > 0
+			AND prr.assigned_at <= now() - make_interval(hours => t.review_sla_hours)
+			AND (prr.assigned_at, prr.pull_request_id, prr.reviewer_id) > ($1, $2, $3)
+		ORDER BY prr.assigned_at, prr.pull_request_id, prr.reviewer_id
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, after.AssignedAt, after.PullRequestID, after.ReviewerID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pending []dto.PendingReview
+	for rows.Next() {
+		var p dto.PendingReview
+		var timeZone, start, end sql.NullString
+		var days pq.Int64Array
+		err := rows.Scan(
+			&p.PullRequestID,
+			&p.PullRequestName,
+			&p.AuthorID,
+			&p.ReviewerID,
+			&p.TeamName,
+			&p.AssignedAt,
+			&p.SLAHours,
+			&p.AutoReassign,
+			&p.Required,
+			&timeZone,
+			&start,
+			&end,
+			&days,
+		)
+		if err != nil {
+			return nil, err
+		}
+		p.AssignedAt = p.AssignedAt.UTC()
+		if timeZone.Valid {
+			p.WorkingHours = &dto.WorkingHours{TimeZone: timeZone.String, Start: start.String, End: end.String}
+			for _, d := range days {
+				p.WorkingHours.Days = append(p.WorkingHours.Days, int(d))
+			}
+		}
+		pending = append(pending, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+func (r *reviewSLARepo) MarkBreached(ctx context.Context, review dto.PendingReview) (bool, error) {
+	// Matching assigned_at keeps a review that was restarted by a reopen from
+	// being flagged for its previous run.
+	const query = `
+		UPDATE pull_request_reviewers
+		   SET sla_breached_at = now()
+		 WHERE pull_request_id = $1
+		   AND reviewer_id = $2
+		   AND assigned_at = $3
+		   AND verdict IS NULL
+		   AND sla_breached_at IS NULL
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, query, review.PullRequestID, review.ReviewerID, review.AssignedAt)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	err = appendEvent(ctx, tx, events.ReviewSLABreached, review.PullRequestID, events.ReviewSLABreachedPayload{
+		PullRequestID: review.PullRequestID,
+		Name:          review.PullRequestName,
+		AuthorID:      review.AuthorID,
+		ReviewerID:    review.ReviewerID,
+		TeamName:      review.TeamName,
+		AssignedAt:    review.AssignedAt,
+		SLAHours:      review.SLAHours,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/dto"
+	errors2 "pr-reviwer-assigner/internal/errors"
+
+	"github.com/lib/pq"
+)
+
+// saveReviewerRules checks the required and excluded reviewers of a new pull
+// request and stores them. Every reviewer must exist, required ones must be
+// active and neither the author nor excluded.
+func saveReviewerRules(ctx context.Context, tx *sql.Tx, prID, authorID string, required, excluded []string) error {
+	const usersQuery = `
+		SELECT user_id, is_active
+		FROM users
+		WHERE user_id = ANY($1)
+	`
+
+	const insertQuery = `
+		INSERT INTO pull_request_reviewer_rules (pull_request_id, user_id, required)
+		SELECT $1, unnest($2::text[]), TRUE
+		UNION ALL
+		SELECT $1, unnest($3::text[]), FALSE
+	`
+
+	if len(required) == 0 && len(excluded) == 0 {
+		return nil
+	}
+
+	isExcluded := make(map[string]bool, len(excluded))
+	for _, id := range excluded {
+		isExcluded[id] = true
+	}
+	for _, id := range required {
+		switch {
+		case id == authorID:
+			return fmt.Errorf("%w: required reviewer %s is the author of the pull request", errors2.ErrInvalidReviewers, id)
+		case isExcluded[id]:
+			return fmt.Errorf("%w: reviewer %s is both required and excluded", errors2.ErrInvalidReviewers, id)
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, usersQuery, pq.Array(append(append([]string{}, required...), excluded...)))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	active := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		var isActive bool
+		if err := rows.Scan(&id, &isActive); err != nil {
+			return err
+		}
+		active[id] = isActive
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range required {
+		isActive, ok := active[id]
+		switch {
+		case !ok:
+			return fmt.Errorf("%w: required reviewer %s does not exist", errors2.ErrInvalidReviewers, id)
+		case !isActive:
+			return fmt.Errorf("%w: required reviewer %s is inactive", errors2.ErrInvalidReviewers, id)
+		}
+	}
+	for _, id := range excluded {
+		if _, ok := active[id]; !ok {
+			return fmt.Errorf("%w: excluded reviewer %s does not exist", errors2.ErrInvalidReviewers, id)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, insertQuery, prID, pq.Array(required), pq.Array(excluded))
+	return err
+}
+
+// checkNotRequired fails if userID is an active required reviewer of the pull
+// request; such reviewers are never unassigned, only inactive ones are.
+func checkNotRequired(ctx context.Context, tx *sql.Tx, prID, userID string) error {
+	const query = `
+		SELECT 1
+		FROM pull_request_reviewer_rules r
+		JOIN users u
+		    ON u.user_id = r.user_id
+		WHERE r.pull_request_id = $1
+		  AND r.user_id = $2
+		  AND r.required
+		  AND u.is_active = TRUE
+	`
+
+	var dummy int
+	err := tx.QueryRowContext(ctx, query, prID, userID).Scan(&dummy)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w: required reviewer %s can't be unassigned", errors2.ErrInvalidReviewers, userID)
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	default:
+		return err
+	}
+}
+
+// assignRequired assigns the active required reviewers of the pull request that
+// are not assigned yet, never the author. Each keeps their own team. A positive
+// limit caps how many are assigned; zero assigns them all.
+func assignRequired(ctx context.Context, tx *sql.Tx, prID string, limit int) ([]dto.ReviewerAssignment, error) {
+	const query = `
+		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, source_team, policy)
+		SELECT r.pull_request_id, u.user_id, u.team_name, $2
+		FROM pull_request_reviewer_rules r
+		JOIN users u
+		    ON u.user_id = r.user_id
+		JOIN pull_requests pr
+		    ON pr.pull_request_id = r.pull_request_id
+		WHERE r.pull_request_id = $1
+			AND r.required
+			AND u.is_active = TRUE
+			AND u.user_id <> pr.author_id
+			AND NOT EXISTS (
+				SELECT 1
+				FROM pull_request_reviewers prr
+				WHERE prr.pull_request_id = $1
+					AND prr.reviewer_id = u.user_id
+			)
+		ORDER BY u.user_id
+		LIMIT NULLIF($3, 0)
+		RETURNING reviewer_id, source_team
+	`
+
+	rows, err := tx.QueryContext(ctx, query, prID, dto.PolicyRequired, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assigned []dto.ReviewerAssignment
+	for rows.Next() {
+		a := dto.ReviewerAssignment{Policy: dto.PolicyRequired}
+		if err := rows.Scan(&a.UserID, &a.TeamName); err != nil {
+			return nil, err
+		}
+		assigned = append(assigned, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assigned, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/repository"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+type statsRepo struct {
+	db *sql.DB
+}
+
+func NewStatsRepository(db *sql.DB) repository.StatsRepository {
+	return &statsRepo{
+		db: db,
+	}
+}
+
+func (r *statsRepo) Pairings(ctx context.Context, teamName string) (*dto.PairingStats, error) {
+	// The outer joins keep a row for the team and for each of its members, so an
+	// unknown team is told apart from a team without reviews.
+	const query = `
+		SELECT t.pairing_window, a.user_id, prr.reviewer_id, COUNT(prr.reviewer_id)
+		FROM teams t
+		LEFT JOIN users a
+		    ON a.team_name = t.team_name
+		LEFT JOIN pull_requests pr
+		    ON pr.author_id = a.user_id
+		LEFT JOIN pull_request_reviewers prr
+		    ON prr.pull_request_id = pr.pull_request_id
+		WHERE t.team_name = $1
+		GROUP BY t.pairing_window, a.user_id, prr.reviewer_id
+		ORDER BY a.user_id, prr.reviewer_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats *dto.PairingStats
+	for rows.Next() {
+		var window, count int
+		var authorID, reviewerID sql.NullString
+		if err := rows.Scan(&window, &authorID, &reviewerID, &count); err != nil {
+			return nil, err
+		}
+
+		if stats == nil {
+			stats = &dto.PairingStats{
+				TeamName:      teamName,
+				PairingWindow: window,
+				Pairings:      make(map[string]map[string]int),
+			}
+		}
+		if !authorID.Valid {
+			continue
+		}
+		if stats.Pairings[authorID.String] == nil {
+			stats.Pairings[authorID.String] = make(map[string]int)
+		}
+		if reviewerID.Valid {
+			stats.Pairings[authorID.String][reviewerID.String] = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if stats == nil {
+		return nil, errors2.ErrNotFound
+	}
+
+	return stats, nil
+}
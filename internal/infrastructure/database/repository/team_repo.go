@@ -4,9 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
 	"pr-reviwer-assigner/internal/domain/repository"
 	errors2 "pr-reviwer-assigner/internal/errors"
+
+	"github.com/lib/pq"
 )
 
 type teamRepo struct {
@@ -20,7 +24,15 @@ func NewTeamRepository(db *sql.DB) repository.TeamRepository {
 }
 
 func (r *teamRepo) Get(teamName string) ([]dto.TeamMember, error) {
-	const query = `SELECT user_id, username, is_active FROM users WHERE team_name = $1`
+	const query = `
+		SELECT
+		    u.user_id,
+		    u.username,
+		    u.is_active,
+		    ARRAY(SELECT s.skill FROM user_skills s WHERE s.user_id = u.user_id ORDER BY s.skill)
+		FROM users u
+		WHERE u.team_name = $1
+	`
 
 	rows, err := r.db.Query(query, teamName)
 	if err != nil {
@@ -35,6 +47,7 @@ func (r *teamRepo) Get(teamName string) ([]dto.TeamMember, error) {
 			&member.ID,
 			&member.Name,
 			&member.IsActive,
+			pq.Array(&member.Skills),
 		)
 
 		if err != nil {
@@ -81,13 +94,21 @@ func (r *teamRepo) Add(ctx context.Context, team dto.Team) error {
 	defer tx.Rollback()
 
 	_, err = tx.ExecContext(ctx,
-		`INSERT INTO teams (team_name) VALUES ($1)`,
+		`INSERT INTO teams (team_name, reviewer_strategy, reviewers_count, min_reviewers, max_reviewers, min_approvals)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
 		team.Name,
+		team.ReviewerStrategy,
+		team.ReviewersCount,
+		team.MinReviewers,
+		team.MaxReviewers,
+		team.MinApprovals,
 	)
 	if err != nil {
 		switch {
 		case isUniqueViolation(err):
 			return errors2.ErrTeamExists
+		case isCheckViolation(err):
+			return teamCheckError(err)
 		default:
 			return err
 		}
@@ -103,6 +124,17 @@ func (r *teamRepo) Add(ctx context.Context, team dto.Team) error {
 		); err != nil {
 			return err
 		}
+		if m.Skills != nil {
+			if err := replaceSkills(ctx, tx, m.ID, m.Skills); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(team.FallbackTeams) > 0 {
+		if err := replaceFallbacks(ctx, tx, team.Name, team.FallbackTeams); err != nil {
+			return err
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -142,6 +174,14 @@ func (r *teamRepo) DeactivateMembers(ctx context.Context, teamName string, userI
 		if affected == 0 {
 			return errors2.ErrNotFound
 		}
+
+		err = appendEvent(ctx, tx, events.UserDeactivated, id, events.UserDeactivatedPayload{
+			UserID:   id,
+			TeamName: teamName,
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -150,3 +190,177 @@ func (r *teamRepo) DeactivateMembers(ctx context.Context, teamName string, userI
 
 	return nil
 }
+
+func (r *teamRepo) GetSettings(ctx context.Context, teamName string) (*dto.TeamSettings, error) {
+	const query = `
+		SELECT team_name, reviewer_strategy, reviewers_count, min_reviewers, max_reviewers, min_approvals, chat_webhook_url, working_hours_lead_minutes, pairing_window, review_sla_hours, sla_auto_reassign
+		FROM teams
+		WHERE team_name = $1
+	`
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var settings dto.TeamSettings
+	err = tx.QueryRowContext(ctx, query, teamName).Scan(
+		&settings.TeamName,
+		&settings.ReviewerStrategy,
+		&settings.ReviewersCount,
+		&settings.MinReviewers,
+		&settings.MaxReviewers,
+		&settings.MinApprovals,
+		&settings.ChatWebhookURL,
+		&settings.WorkingHoursLeadMinutes,
+		&settings.PairingWindow,
+		&settings.ReviewSLAHours,
+		&settings.SLAAutoReassign,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors2.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	settings.FallbackTeams, err = listFallbackTeams(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (r *teamRepo) UpdateSettings(ctx context.Context, req dto.TeamSettingsRequest) (*dto.TeamSettings, error) {
+	const query = `
+		UPDATE teams
+		   SET reviewer_strategy          = COALESCE($2, reviewer_strategy),
+		       reviewers_count            = COALESCE($3, reviewers_count),
+		       min_reviewers              = COALESCE($4, min_reviewers),
+		       max_reviewers              = COALESCE($5, max_reviewers),
+		       min_approvals              = COALESCE($6, min_approvals),
+		       chat_webhook_url           = COALESCE($7, chat_webhook_url),
+		       working_hours_lead_minutes = COALESCE($8, working_hours_lead_minutes),
+		       pairing_window             = COALESCE($9, pairing_window),
+		       review_sla_hours           = COALESCE($10, review_sla_hours),
+		       sla_auto_reassign          = COALESCE($11, sla_auto_reassign)
+		 WHERE team_name = $1
+		RETURNING team_name, reviewer_strategy, reviewers_count, min_reviewers, max_reviewers, min_approvals, chat_webhook_url, working_hours_lead_minutes, pairing_window, review_sla_hours, sla_auto_reassign
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var settings dto.TeamSettings
+	err = tx.QueryRowContext(ctx, query,
+		req.TeamName,
+		req.ReviewerStrategy,
+		req.ReviewersCount,
+		req.MinReviewers,
+		req.MaxReviewers,
+		req.MinApprovals,
+		req.ChatWebhookURL,
+		req.WorkingHoursLeadMinutes,
+		req.PairingWindow,
+		req.ReviewSLAHours,
+		req.SLAAutoReassign,
+	).Scan(
+		&settings.TeamName,
+		&settings.ReviewerStrategy,
+		&settings.ReviewersCount,
+		&settings.MinReviewers,
+		&settings.MaxReviewers,
+		&settings.MinApprovals,
+		&settings.ChatWebhookURL,
+		&settings.WorkingHoursLeadMinutes,
+		&settings.PairingWindow,
+		&settings.ReviewSLAHours,
+		&settings.SLAAutoReassign,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors2.ErrNotFound
+		case isCheckViolation(err):
+			return nil, teamCheckError(err)
+		default:
+			return nil, err
+		}
+	}
+
+	if req.FallbackTeams != nil {
+		if err := replaceFallbacks(ctx, tx, req.TeamName, *req.FallbackTeams); err != nil {
+			return nil, err
+		}
+	}
+
+	settings.FallbackTeams, err = listFallbackTeams(ctx, tx, req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func replaceFallbacks(ctx context.Context, tx *sql.Tx, teamName string, fallbacks []string) error {
+	const deleteQuery = `DELETE FROM team_fallbacks WHERE team_name = $1`
+
+	const insertQuery = `
+		INSERT INTO team_fallbacks (team_name, fallback_team, position)
+		VALUES ($1, $2, $3)
+	`
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, teamName); err != nil {
+		return err
+	}
+
+	for i, fallback := range fallbacks {
+		if _, err := tx.ExecContext(ctx, insertQuery, teamName, fallback, i); err != nil {
+			switch {
+			case isForeignKeyViolation(err):
+				return errors2.ErrNotFound
+			default:
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// teamCheckError maps a CHECK violation on the team settings to the error of
+// the setting it guards.
+func teamCheckError(err error) error {
+	var pqErr *pq.Error
+	errors.As(err, &pqErr)
+
+	switch pqErr.Constraint {
+	case "teams_reviewers_bounds":
+		return fmt.Errorf("%w: expected min_reviewers <= reviewers_count <= max_reviewers", errors2.ErrInvalidReviewersCount)
+	case "teams_min_approvals_non_negative":
+		return fmt.Errorf("%w: min_approvals can't be negative", errors2.ErrBadRequest)
+	case "teams_working_hours_lead_non_negative":
+		return fmt.Errorf("%w: working_hours_lead_minutes can't be negative", errors2.ErrBadRequest)
+	case "teams_pairing_window_positive":
+		return fmt.Errorf("%w: pairing_window must be positive", errors2.ErrBadRequest)
+	case "teams_review_sla_non_negative":
+		return fmt.Errorf("%w: review_sla_hours can't be negative", errors2.ErrBadRequest)
+	default:
+		return fmt.Errorf("%w: violates constraint %s", errors2.ErrBadRequest, pqErr.Constraint)
+	}
+}
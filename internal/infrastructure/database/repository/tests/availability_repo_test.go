@@ -0,0 +1,88 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	repo "pr-reviwer-assigner/internal/infrastructure/database/repository"
+)
+
+var unavailabilityColumns = []string{"id", "user_id", "starts_at", "ends_at", "reason", "external_id", "created_at", "reassign_attempts", "reassign_error"}
+
+func TestAvailabilityRepoAdd_UnknownUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewAvailabilityRepository(db)
+
+	starts := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	ends := starts.Add(14 * 24 * time.Hour)
+	mock.ExpectQuery(`INSERT INTO user_unavailability \(user_id, starts_at, ends_at, reason\)`).
+		WithArgs("ghost", starts, ends, "").
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	_, err = r.Add(context.Background(), dto.Unavailability{UserID: "ghost", StartsAt: starts, EndsAt: ends})
+	require.ErrorIs(t, err, errors2.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAvailabilityRepoListStarted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewAvailabilityRepository(db)
+
+	now := time.Now()
+	mock.ExpectQuery(`FROM user_unavailability\s+WHERE reassigned_at IS NULL\s+AND starts_at <= now\(\)\s+AND ends_at > now\(\)\s+AND \(next_attempt_at IS NULL OR next_attempt_at <= now\(\)\)`).
+		WithArgs(50).
+		WillReturnRows(sqlmock.NewRows(unavailabilityColumns).
+			AddRow(4, "u1", now.Add(-time.Hour), now.Add(time.Hour), "sick", "", now, 2, "pr-3: NO_CANDIDATE"))
+
+	windows, err := r.ListStarted(context.Background(), 50)
+	require.NoError(t, err)
+	require.Len(t, windows, 1)
+	require.Equal(t, "u1", windows[0].UserID)
+	require.Equal(t, "sick", windows[0].Reason)
+	require.Equal(t, 2, windows[0].ReassignAttempts)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAvailabilityRepoMarkReassigned_RecordsFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewAvailabilityRepository(db)
+
+	mock.ExpectExec(`SET reassigned_at\s+= now\(\),\s+reassign_error = NULLIF\(\$2, ''\)`).
+		WithArgs(int64(4), "pr-3: NO_CANDIDATE").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, r.MarkReassigned(context.Background(), 4, "pr-3: NO_CANDIDATE"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAvailabilityRepoMarkFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewAvailabilityRepository(db)
+
+	next := time.Now().Add(time.Minute)
+	mock.ExpectExec(`SET reassign_attempts = reassign_attempts \+ 1,\s+reassign_error\s+= \$2,\s+next_attempt_at\s+= \$3,\s+reassigned_at\s+= CASE WHEN \$4 THEN now\(\) END`).
+		WithArgs(int64(4), "pr-3: NO_CANDIDATE", next, false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, r.MarkFailed(context.Background(), 4, "pr-3: NO_CANDIDATE", next, false))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
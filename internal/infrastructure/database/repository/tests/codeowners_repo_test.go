@@ -0,0 +1,67 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/codeowners"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	repo "pr-reviwer-assigner/internal/infrastructure/database/repository"
+)
+
+func TestCodeownersRepoGet_UnknownTeam(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewCodeownersRepository(db)
+
+	mock.ExpectQuery(`SELECT COALESCE\(c\.content, ''\)\s+FROM teams t`).
+		WithArgs("ghosts").
+		WillReturnRows(sqlmock.NewRows([]string{"content"}))
+
+	_, err = r.Get(context.Background(), "ghosts")
+	require.ErrorIs(t, err, errors2.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCodeownersRepoSet_EmptyContentDeletes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewCodeownersRepository(db)
+
+	mock.ExpectQuery(`SELECT COALESCE\(c\.content, ''\)\s+FROM teams t`).
+		WithArgs("backend").
+		WillReturnRows(sqlmock.NewRows([]string{"content"}).AddRow("* @alice\n"))
+	mock.ExpectExec(`DELETE FROM team_codeowners`).
+		WithArgs("backend").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, r.Set(context.Background(), "backend", ""))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCodeownersRepoUnknownOwners(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewCodeownersRepository(db)
+
+	mock.ExpectQuery(`FROM unnest\(\$1::text\[\], \$2::text\[\]\) AS o\(kind, name\)`).
+		WithArgs("{\"user\",\"team\"}", "{\"alice\",\"ghosts\"}").
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "name"}).AddRow("team", "ghosts"))
+
+	unknown, err := r.UnknownOwners(context.Background(), []codeowners.Owner{
+		{Kind: codeowners.OwnerUser, Name: "alice"},
+		{Kind: codeowners.OwnerTeam, Name: "ghosts", Org: "acme"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []codeowners.Owner{{Kind: codeowners.OwnerTeam, Name: "ghosts"}}, unknown)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
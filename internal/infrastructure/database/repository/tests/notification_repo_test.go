@@ -0,0 +1,49 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	repo "pr-reviwer-assigner/internal/infrastructure/database/repository"
+)
+
+func TestNotificationRepoChatTeams_GroupsMembers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewNotificationRepository(db)
+
+	mock.ExpectQuery(`SELECT t.team_name, t.chat_webhook_url, u.user_id, u.username, u.chat_handle\s+FROM teams t`).
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "chat_webhook_url", "user_id", "username", "chat_handle"}).
+			AddRow("backend", "http://chat/backend", "u1", "Alice", "UALICE").
+			AddRow("backend", "http://chat/backend", "u2", "Bob", "").
+			AddRow("frontend", "http://chat/frontend", "u3", "Carol", ""))
+
+	teams, err := r.ChatTeams(context.Background())
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+	require.Equal(t, "backend", teams[0].TeamName)
+	require.Len(t, teams[0].Members, 2)
+	require.Equal(t, "UALICE", teams[0].Members[0].ChatHandle)
+	require.Equal(t, "http://chat/frontend", teams[1].ChatWebhookURL)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepoMarkEmailed_IgnoresRepeats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewNotificationRepository(db)
+
+	mock.ExpectExec(`INSERT INTO email_deliveries \(event_id, user_id\)\s+VALUES \(\$1, \$2\)\s+ON CONFLICT DO NOTHING`).
+		WithArgs(int64(7), "u2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, r.MarkEmailed(context.Background(), 7, "u2"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
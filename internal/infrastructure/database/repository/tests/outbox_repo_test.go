@@ -0,0 +1,67 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	repo "pr-reviwer-assigner/internal/infrastructure/database/repository"
+)
+
+func TestOutboxRepoClaim_OrdersByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewOutboxRepository(db)
+
+	now := time.Now()
+	mock.ExpectQuery(`UPDATE outbox_events\s+SET locked_until.+WHERE dispatched_at IS NULL\s+AND dead_at IS NULL`).
+		WithArgs(50, int64(30000)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "aggregate_id", "payload", "created_at", "delivered_to", "attempts"}).
+			AddRow(8, "reviewer.assigned", "pr-1", []byte(`{"reviewer_id":"u2"}`), now, "{}", 0).
+			AddRow(7, "pr.created", "pr-1", []byte(`{"pull_request_id":"pr-1"}`), now, "{chat,webhooks}", 3))
+
+	claimed, err := r.Claim(context.Background(), 50, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	require.Equal(t, int64(7), claimed[0].ID)
+	require.Equal(t, "pr.created", claimed[0].Type)
+	require.Equal(t, []string{"chat", "webhooks"}, claimed[0].DeliveredTo)
+	require.Equal(t, 3, claimed[0].Attempts)
+	require.JSONEq(t, `{"reviewer_id":"u2"}`, string(claimed[1].Payload))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepoMarkFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewOutboxRepository(db)
+
+	mock.ExpectExec(`UPDATE outbox_events\s+SET attempts\s+= attempts \+ 1,.+dead_at\s+= CASE WHEN \$3 THEN now\(\) END`).
+		WithArgs(int64(7), "log: boom", true).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, r.MarkFailed(context.Background(), 7, "log: boom", true))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepoMarkDelivered(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewOutboxRepository(db)
+
+	mock.ExpectExec(`SET delivered_to = array_append\(delivered_to, \$2\)`).
+		WithArgs(int64(7), "chat").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, r.MarkDelivered(context.Background(), 7, "chat"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
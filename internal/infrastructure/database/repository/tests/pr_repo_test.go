@@ -2,12 +2,15 @@ package repository_test
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
 
 	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/selector"
 	errors2 "pr-reviwer-assigner/internal/errors"
 	repo "pr-reviwer-assigner/internal/infrastructure/database/repository"
 )
@@ -17,13 +20,13 @@ func TestPRRepoReassign_Success(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	r := repo.NewPRRepository(db)
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
 
 	mock.ExpectBegin()
 	mock.ExpectQuery(`SELECT\s+pull_request_id`).
 		WithArgs("pr-1").
-		WillReturnRows(sqlmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status"}).
-			AddRow("pr-1", "Add search", "author-1", "OPEN"))
+		WillReturnRows(prRows().
+			AddRow("pr-1", "Add search", "author-1", "OPEN", createdAt, nil, nil, createdAt, 2))
 
 	mock.ExpectQuery(`SELECT\s+team_name\s+FROM users WHERE user_id = \$1`).
 		WithArgs("old-user").
@@ -32,25 +35,40 @@ func TestPRRepoReassign_Success(t *testing.T) {
 	mock.ExpectQuery(`SELECT 1\s+FROM pull_request_reviewers`).
 		WithArgs("pr-1", "old-user").
 		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	expectNotRequired(mock, "pr-1", "old-user")
 
-	mock.ExpectQuery(`SELECT u\.user_id\s+FROM users u`).
-		WithArgs("backend", "author-1", "old-user", "pr-1").
-		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("new-user"))
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
 
-	mock.ExpectExec(`DELETE FROM pull_request_reviewers`).
-		WithArgs("pr-1", "old-user").
-		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectNoOwnership(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+u\.user_id,\s+COUNT\(pr\.pull_request_id\) AS open_reviews`).
+		WithArgs("backend", "author-1", "pr-1").
+		WillReturnRows(candidateRows().
+			AddRow("busy-user", 4, 9, "UTC", "00:00", "00:00", allWeek, "{}", 0).
+			// Without working hours new-user counts as always working.
+			AddRow("new-user", 1, 12, nil, nil, nil, nil, "{}", 0))
 
 	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
-		WithArgs("pr-1", "new-user").
+		WithArgs("pr-1", "new-user", "backend", "working_hours", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec(`UPDATE teams\s+SET rr_cursor = \$2`).
+		WithArgs("backend", "new-user").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec(`DELETE FROM pull_request_reviewers`).
+		WithArgs("pr-1", "old-user").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	mock.ExpectQuery(`SELECT reviewer_id FROM pull_request_reviewers`).
+	mock.ExpectQuery(`SELECT reviewer_id, source_team, COALESCE\(verdict::text, ''\), verdict_at, policy, owner_pattern, match_score, assigned_at, sla_breached_at\s+FROM pull_request_reviewers`).
 		WithArgs("pr-1").
-		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id"}).
-			AddRow("new-user").
-			AddRow("another"))
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at"}).
+			AddRow("new-user", "backend", "", nil, "working_hours", "", nil, createdAt, nil).
+			AddRow("another", "backend", "", nil, "working_hours", "", nil, createdAt, nil))
 
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	expectEvent(mock, "reviewer.replaced", "pr-1")
 	mock.ExpectCommit()
 
 	req := dto.ReassignRequest{
@@ -65,18 +83,66 @@ func TestPRRepoReassign_Success(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestPRRepoReassign_AddsOneRequiredReviewer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	expectOpenPR(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+team_name\s+FROM users WHERE user_id = \$1`).
+		WithArgs("old-user").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name"}).AddRow("backend"))
+	mock.ExpectQuery(`SELECT 1\s+FROM pull_request_reviewers`).
+		WithArgs("pr-1", "old-user").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	expectNotRequired(mock, "pr-1", "old-user")
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+
+	// Two required reviewers are waiting to be assigned, but the replacement
+	// takes only one seat.
+	mock.ExpectQuery(`SELECT p\.changed_files, p\.required_skills, COALESCE\(c\.content, ''\)`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"changed_files", "required_skills", "content"}).AddRow("{}", "{}", ""))
+	mock.ExpectQuery(`INSERT INTO pull_request_reviewers \(pull_request_id, reviewer_id, source_team, policy\)\s+SELECT r\.pull_request_id.+LIMIT NULLIF\(\$3, 0\)`).
+		WithArgs("pr-1", "required", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team"}).AddRow("sec", "security"))
+
+	mock.ExpectExec(`DELETE FROM pull_request_reviewers`).
+		WithArgs("pr-1", "old-user").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT reviewer_id, source_team, COALESCE\(verdict::text, ''\), verdict_at, policy, owner_pattern, match_score, assigned_at, sla_breached_at\s+FROM pull_request_reviewers`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at"}).
+			AddRow("another", "backend", "", nil, "working_hours", "", nil, createdAt, nil).
+			AddRow("sec", "security", "", nil, "required", "", nil, createdAt, nil))
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	expectEvent(mock, "reviewer.replaced", "pr-1")
+	mock.ExpectCommit()
+
+	pr, newReviewer, err := r.Reassign(context.Background(), dto.ReassignRequest{PullRequestID: "pr-1", OldUserID: "old-user"})
+	require.NoError(t, err)
+	require.Equal(t, "sec", newReviewer)
+	require.Equal(t, []string{"another", "sec"}, pr.Reviewers)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestPRRepoReassign_PRMerged(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
-	r := repo.NewPRRepository(db)
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
 
 	mock.ExpectBegin()
 	mock.ExpectQuery(`SELECT\s+pull_request_id`).
 		WithArgs("pr-merged").
-		WillReturnRows(sqlmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status"}).
-			AddRow("pr-merged", "Title", "author-1", "MERGED"))
+		WillReturnRows(prRows().
+			AddRow("pr-merged", "Title", "author-1", "MERGED", createdAt, nil, nil, createdAt, 2))
 	mock.ExpectRollback()
 
 	req := dto.ReassignRequest{
@@ -88,3 +154,1132 @@ func TestPRRepoReassign_PRMerged(t *testing.T) {
 	require.ErrorIs(t, err, errors2.ErrPRMerged)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestPRRepoCreate_UsesTeamStrategy(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("backend", true))
+
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("round_robin", "u2", 2))
+
+	mock.ExpectExec(`INSERT INTO pull_requests`).
+		WithArgs("pr-1", "Add search", "author-1", "OPEN", sqlmock.AnyArg(), 2, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	expectNoOwnership(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+u\.user_id,\s+COUNT\(pr\.pull_request_id\) AS open_reviews`).
+		WithArgs("backend", "author-1", "pr-1").
+		WillReturnRows(candidateRows().
+			AddRow("u1", 0, 0, "UTC", "00:00", "00:00", allWeek, "{}", 0).
+			AddRow("u2", 0, 0, "UTC", "00:00", "00:00", allWeek, "{}", 0).
+			AddRow("u3", 0, 0, "UTC", "00:00", "00:00", allWeek, "{}", 0))
+
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
+		WithArgs("pr-1", "u3", "backend", "working_hours", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
+		WithArgs("pr-1", "u1", "backend", "working_hours", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec(`UPDATE teams\s+SET rr_cursor = \$2`).
+		WithArgs("backend", "u1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	expectEvent(mock, "pr.created", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	mock.ExpectCommit()
+
+	pr, err := r.Create(context.Background(), dto.PRRequest{
+		ID:       "pr-1",
+		Name:     "Add search",
+		AuthorID: "author-1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"u3", "u1"}, pr.Reviewers)
+	require.False(t, pr.Understaffed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoCreate_AssignsRequiredAndSkipsExcluded(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("backend", true))
+
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+
+	mock.ExpectExec(`INSERT INTO pull_requests`).
+		WithArgs("pr-1", "Rotate keys", "author-1", "OPEN", sqlmock.AnyArg(), 2, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectQuery(`SELECT user_id, is_active\s+FROM users\s+WHERE user_id = ANY\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "is_active"}).AddRow("sec", true).AddRow("u1", true))
+	mock.ExpectExec(`INSERT INTO pull_request_reviewer_rules`).
+		WithArgs("pr-1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	mock.ExpectQuery(`SELECT p\.changed_files, p\.required_skills, COALESCE\(c\.content, ''\)`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"changed_files", "required_skills", "content"}).AddRow("{}", "{}", ""))
+	mock.ExpectQuery(`INSERT INTO pull_request_reviewers \(pull_request_id, reviewer_id, source_team, policy\)\s+SELECT r\.pull_request_id`).
+		WithArgs("pr-1", "required", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team"}).AddRow("sec", "security"))
+
+	// The excluded u1 is filtered out by the candidate query itself.
+	mock.ExpectQuery(`SELECT\s+u\.user_id,\s+COUNT\(pr\.pull_request_id\) AS open_reviews.+FROM pull_request_reviewer_rules r`).
+		WithArgs("backend", "author-1", "pr-1").
+		WillReturnRows(candidateRows().
+			AddRow("u2", 3, 3, "UTC", "00:00", "00:00", allWeek, "{}", 0))
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
+		WithArgs("pr-1", "u2", "backend", "working_hours", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE teams\s+SET rr_cursor = \$2`).
+		WithArgs("backend", "u2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	expectEvent(mock, "pr.created", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	mock.ExpectCommit()
+
+	pr, err := r.Create(context.Background(), dto.PRRequest{
+		ID:                "pr-1",
+		Name:              "Rotate keys",
+		AuthorID:          "author-1",
+		RequiredReviewers: []string{"sec"},
+		ExcludedReviewers: []string{"u1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []dto.ReviewerAssignment{
+		{UserID: "sec", TeamName: "security", Policy: "required"},
+		{UserID: "u2", TeamName: "backend", Policy: "working_hours"},
+	}, pr.Assignments)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoCreate_RejectsInactiveRequiredReviewer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("backend", true))
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+	mock.ExpectExec(`INSERT INTO pull_requests`).
+		WithArgs("pr-1", "Rotate keys", "author-1", "OPEN", sqlmock.AnyArg(), 2, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT user_id, is_active\s+FROM users\s+WHERE user_id = ANY\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "is_active"}).AddRow("sec", false))
+	mock.ExpectRollback()
+
+	_, err = r.Create(context.Background(), dto.PRRequest{
+		ID:                "pr-1",
+		Name:              "Rotate keys",
+		AuthorID:          "author-1",
+		RequiredReviewers: []string{"sec"},
+	})
+	require.ErrorIs(t, err, errors2.ErrInvalidReviewers)
+	require.ErrorContains(t, err, "required reviewer sec is inactive")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoCreate_RejectsAuthorAsRequiredReviewer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("backend", true))
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+	mock.ExpectExec(`INSERT INTO pull_requests`).
+		WithArgs("pr-1", "Rotate keys", "author-1", "OPEN", sqlmock.AnyArg(), 2, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	_, err = r.Create(context.Background(), dto.PRRequest{
+		ID:                "pr-1",
+		Name:              "Rotate keys",
+		AuthorID:          "author-1",
+		RequiredReviewers: []string{"author-1"},
+	})
+	require.ErrorIs(t, err, errors2.ErrInvalidReviewers)
+	require.ErrorContains(t, err, "required reviewer author-1 is the author")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoCreate_RejectsRequiredAndExcludedReviewer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("backend", true))
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+	mock.ExpectExec(`INSERT INTO pull_requests`).
+		WithArgs("pr-1", "Rotate keys", "author-1", "OPEN", sqlmock.AnyArg(), 2, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	_, err = r.Create(context.Background(), dto.PRRequest{
+		ID:                "pr-1",
+		Name:              "Rotate keys",
+		AuthorID:          "author-1",
+		RequiredReviewers: []string{"sec"},
+		ExcludedReviewers: []string{"sec"},
+	})
+	require.ErrorIs(t, err, errors2.ErrInvalidReviewers)
+	require.EqualError(t, err, "INVALID_REVIEWERS: reviewer sec is both required and excluded")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoCreate_PrefersReviewersInWorkingHours(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("backend", true))
+
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+
+	mock.ExpectExec(`INSERT INTO pull_requests`).
+		WithArgs("pr-1", "Add search", "author-1", "OPEN", sqlmock.AnyArg(), 2, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// "asleep" has the lowest load but no working days, so it is never inside
+	// its working hours and only fills the seat nobody working could take.
+	expectNoOwnership(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+u\.user_id,\s+COUNT\(pr\.pull_request_id\) AS open_reviews`).
+		WithArgs("backend", "author-1", "pr-1").
+		WillReturnRows(candidateRows().
+			AddRow("asleep", 0, 0, "Asia/Tokyo", "09:00", "18:00", "{}", "{}", 0).
+			AddRow("awake", 3, 7, "Europe/Berlin", "00:00", "00:00", allWeek, "{}", 0))
+
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
+		WithArgs("pr-1", "awake", "backend", "working_hours", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
+		WithArgs("pr-1", "asleep", "backend", "off_hours", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec(`UPDATE teams\s+SET rr_cursor = \$2`).
+		WithArgs("backend", "asleep").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	expectEvent(mock, "pr.created", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	mock.ExpectCommit()
+
+	pr, err := r.Create(context.Background(), dto.PRRequest{
+		ID:       "pr-1",
+		Name:     "Add search",
+		AuthorID: "author-1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []dto.ReviewerAssignment{
+		{UserID: "awake", TeamName: "backend", Policy: "working_hours"},
+		{UserID: "asleep", TeamName: "backend", Policy: "off_hours"},
+	}, pr.Assignments)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoCreate_PrefersMatchingSkills(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("backend", true))
+
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+
+	mock.ExpectExec(`INSERT INTO pull_requests`).
+		WithArgs("pr-1", "Tune queries", "author-1", "OPEN", sqlmock.AnyArg(), 2, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// "idle" has the lowest load, but "dba" and "gopher" cover more of the
+	// required skills.
+	mock.ExpectQuery(`SELECT p\.changed_files, p\.required_skills, COALESCE\(c\.content, ''\)`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"changed_files", "required_skills", "content"}).AddRow("{}", "{go,postgres}", ""))
+	expectNoRequired(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+u\.user_id,\s+COUNT\(pr\.pull_request_id\) AS open_reviews`).
+		WithArgs("backend", "author-1", "pr-1").
+		WillReturnRows(candidateRows().
+			AddRow("idle", 0, 0, "UTC", "00:00", "00:00", allWeek, "{}", 0).
+			AddRow("gopher", 2, 5, "UTC", "00:00", "00:00", allWeek, "{go}", 0).
+			AddRow("dba", 4, 9, "UTC", "00:00", "00:00", allWeek, "{go,postgres}", 0))
+
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
+		WithArgs("pr-1", "dba", "backend", "working_hours", 1.0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
+		WithArgs("pr-1", "gopher", "backend", "working_hours", 0.5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec(`UPDATE teams\s+SET rr_cursor = \$2`).
+		WithArgs("backend", "gopher").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	expectEvent(mock, "pr.created", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	mock.ExpectCommit()
+
+	pr, err := r.Create(context.Background(), dto.PRRequest{
+		ID:             "pr-1",
+		Name:           "Tune queries",
+		AuthorID:       "author-1",
+		RequiredSkills: []string{"go", "postgres"},
+	})
+	require.NoError(t, err)
+	require.Len(t, pr.Assignments, 2)
+	require.Equal(t, "dba", pr.Assignments[0].UserID)
+	require.Equal(t, 1.0, *pr.Assignments[0].MatchScore)
+	require.Equal(t, "gopher", pr.Assignments[1].UserID)
+	require.Equal(t, 0.5, *pr.Assignments[1].MatchScore)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoCreate_AssignsCodeOwnersFirst(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("backend", true))
+
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+
+	mock.ExpectExec(`INSERT INTO pull_requests`).
+		WithArgs("pr-1", "Add index", "author-1", "OPEN", sqlmock.AnyArg(), 2, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectQuery(`SELECT p\.changed_files, p\.required_skills, COALESCE\(c\.content, ''\)`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"changed_files", "required_skills", "content"}).
+			AddRow("{migrations/002_index.sql,cmd/main.go}", "{}", "* @acme/backend\n/migrations/ @dbadmin\n"))
+	expectNoRequired(mock, "pr-1")
+
+	mock.ExpectQuery(`SELECT reviewer_id, source_team, COALESCE\(verdict::text, ''\), verdict_at, policy, owner_pattern, match_score, assigned_at, sla_breached_at\s+FROM pull_request_reviewers`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at"}))
+
+	// The migration is owned by dbadmin from another team.
+	mock.ExpectQuery(`SELECT user_id\s+FROM users\s+WHERE user_id = ANY\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("dbadmin"))
+	mock.ExpectQuery(`SELECT\s+u\.user_id,\s+COUNT\(pr\.pull_request_id\) AS open_reviews.*WHERE u\.user_id = ANY\(\$1\)`).
+		WithArgs(sqlmock.AnyArg(), "author-1", "pr-1").
+		WillReturnRows(candidateRows().AddRow("dbadmin", 5, 20, "UTC", "00:00", "00:00", allWeek, "{}", 0))
+	mock.ExpectQuery(`INSERT INTO pull_request_reviewers \(pull_request_id, reviewer_id, source_team, policy, owner_pattern, match_score\)`).
+		WithArgs("pr-1", "dbadmin", "working_hours", "/migrations/", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"source_team"}).AddRow("platform"))
+
+	// cmd/main.go is owned by the author's own team.
+	mock.ExpectQuery(`SELECT user_id\s+FROM users\s+WHERE user_id = ANY\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("author-1").AddRow("u1").AddRow("u2"))
+	mock.ExpectQuery(`SELECT\s+u\.user_id,\s+COUNT\(pr\.pull_request_id\) AS open_reviews.*WHERE u\.user_id = ANY\(\$1\)`).
+		WithArgs(sqlmock.AnyArg(), "author-1", "pr-1").
+		WillReturnRows(candidateRows().
+			AddRow("u1", 2, 2, "UTC", "00:00", "00:00", allWeek, "{}", 0).
+			AddRow("u2", 1, 9, "UTC", "00:00", "00:00", allWeek, "{}", 0))
+	mock.ExpectQuery(`INSERT INTO pull_request_reviewers \(pull_request_id, reviewer_id, source_team, policy, owner_pattern, match_score\)`).
+		WithArgs("pr-1", "u2", "working_hours", "*", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"source_team"}).AddRow("backend"))
+
+	expectEvent(mock, "pr.created", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	mock.ExpectCommit()
+
+	pr, err := r.Create(context.Background(), dto.PRRequest{
+		ID:           "pr-1",
+		Name:         "Add index",
+		AuthorID:     "author-1",
+		ChangedFiles: []string{"migrations/002_index.sql", "cmd/main.go"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []dto.ReviewerAssignment{
+		{UserID: "dbadmin", TeamName: "platform", Policy: "working_hours", OwnerPattern: "/migrations/"},
+		{UserID: "u2", TeamName: "backend", Policy: "working_hours", OwnerPattern: "*"},
+	}, pr.Assignments)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoCreate_ReviewersCountOutOfBounds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("backend", true))
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+	mock.ExpectRollback()
+
+	count := 9
+	_, err = r.Create(context.Background(), dto.PRRequest{
+		ID:             "pr-1",
+		Name:           "Add search",
+		AuthorID:       "author-1",
+		ReviewersCount: &count,
+	})
+	require.ErrorIs(t, err, errors2.ErrInvalidReviewersCount)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoCreate_Understaffed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("backend", true))
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+	mock.ExpectExec(`INSERT INTO pull_requests`).
+		WithArgs("pr-1", "Add search", "author-1", "OPEN", sqlmock.AnyArg(), 3, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectNoOwnership(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+u\.user_id,\s+COUNT\(pr\.pull_request_id\) AS open_reviews`).
+		WithArgs("backend", "author-1", "pr-1").
+		WillReturnRows(candidateRows().
+			AddRow("u1", 0, 0, "UTC", "00:00", "00:00", allWeek, "{}", 0))
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
+		WithArgs("pr-1", "u1", "backend", "working_hours", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE teams\s+SET rr_cursor = \$2`).
+		WithArgs("backend", "u1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT fallback_team\s+FROM team_fallbacks`).
+		WithArgs("backend").
+		WillReturnRows(sqlmock.NewRows([]string{"fallback_team"}))
+	expectEvent(mock, "pr.created", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	mock.ExpectCommit()
+
+	count := 3
+	pr, err := r.Create(context.Background(), dto.PRRequest{
+		ID:             "pr-1",
+		Name:           "Add search",
+		AuthorID:       "author-1",
+		ReviewersCount: &count,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, pr.RequestedReviewers)
+	require.True(t, pr.Understaffed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoCreate_FallsBackToOtherTeam(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("tiny", true))
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("tiny").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+	mock.ExpectExec(`INSERT INTO pull_requests`).
+		WithArgs("pr-1", "Add search", "author-1", "OPEN", sqlmock.AnyArg(), 2, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	expectNoOwnership(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+u\.user_id,\s+COUNT\(pr\.pull_request_id\) AS open_reviews`).
+		WithArgs("tiny", "author-1", "pr-1").
+		WillReturnRows(candidateRows().
+			AddRow("t1", 0, 0, "UTC", "00:00", "00:00", allWeek, "{}", 0))
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
+		WithArgs("pr-1", "t1", "tiny", "working_hours", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE teams\s+SET rr_cursor = \$2`).
+		WithArgs("tiny", "t1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectQuery(`SELECT fallback_team\s+FROM team_fallbacks`).
+		WithArgs("tiny").
+		WillReturnRows(sqlmock.NewRows([]string{"fallback_team"}).AddRow("platform"))
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("platform").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+	mock.ExpectQuery(`SELECT\s+u\.user_id,\s+COUNT\(pr\.pull_request_id\) AS open_reviews`).
+		WithArgs("platform", "author-1", "pr-1").
+		WillReturnRows(candidateRows().
+			AddRow("p1", 3, 3, "UTC", "00:00", "00:00", allWeek, "{}", 0).
+			AddRow("p2", 1, 8, "UTC", "00:00", "00:00", allWeek, "{}", 0))
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
+		WithArgs("pr-1", "p2", "platform", "working_hours", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE teams\s+SET rr_cursor = \$2`).
+		WithArgs("platform", "p2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEvent(mock, "pr.created", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	mock.ExpectCommit()
+
+	pr, err := r.Create(context.Background(), dto.PRRequest{
+		ID:       "pr-1",
+		Name:     "Add search",
+		AuthorID: "author-1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"t1", "p2"}, pr.Reviewers)
+	require.Equal(t, []dto.ReviewerAssignment{
+		{UserID: "t1", TeamName: "tiny", Policy: "working_hours"},
+		{UserID: "p2", TeamName: "platform", Policy: "working_hours"},
+	}, pr.Assignments)
+	require.False(t, pr.Understaffed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func expectEvent(mock sqlmock.Sqlmock, eventType, aggregateID string) {
+	mock.ExpectExec(`INSERT INTO outbox_events`).
+		WithArgs(eventType, aggregateID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// allWeek with a 00:00-00:00 window keeps a candidate inside working hours.
+const allWeek = "{1,2,3,4,5,6,7}"
+
+// expectNoOwnership expects the lookups of a pull request without changed
+// files and required reviewers.
+func expectNoOwnership(mock sqlmock.Sqlmock, prID string) {
+	mock.ExpectQuery(`SELECT p\.changed_files, p\.required_skills, COALESCE\(c\.content, ''\)`).
+		WithArgs(prID).
+		WillReturnRows(sqlmock.NewRows([]string{"changed_files", "required_skills", "content"}).AddRow("{}", "{}", ""))
+	expectNoRequired(mock, prID)
+}
+
+// expectNoRequired expects the assignment of required reviewers to find nobody.
+func expectNoRequired(mock sqlmock.Sqlmock, prID string) {
+	mock.ExpectQuery(`INSERT INTO pull_request_reviewers \(pull_request_id, reviewer_id, source_team, policy\)\s+SELECT r\.pull_request_id`).
+		WithArgs(prID, "required", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team"}))
+}
+
+// createdAt is when the pull requests in these tests were created.
+var createdAt = time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+
+func prRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at", "closed_at", "updated_at", "requested_reviewers"})
+}
+
+func candidateRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"user_id", "open_reviews", "total_reviews", "time_zone", "work_start", "work_end", "work_days", "skills", "recent_pairings"})
+}
+
+func teamPolicyRows(strategy, cursor string, reviewersCount int) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"reviewer_strategy", "rr_cursor", "reviewers_count", "min_reviewers", "max_reviewers", "working_hours_lead_minutes"}).
+		AddRow(strategy, cursor, reviewersCount, 1, 5, 60)
+}
+
+func TestPRRepoCreate_DraftGetsNoReviewers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT team_name, is_active\s+FROM users`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active"}).AddRow("backend", true))
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 2))
+	mock.ExpectExec(`INSERT INTO pull_requests`).
+		WithArgs("pr-1", "WIP", "author-1", "DRAFT", sqlmock.AnyArg(), 2, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEvent(mock, "pr.created", "pr-1")
+	mock.ExpectCommit()
+
+	pr, err := r.Create(context.Background(), dto.PRRequest{
+		ID:       "pr-1",
+		Name:     "WIP",
+		AuthorID: "author-1",
+		Draft:    true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, dto.PRStatusDraft, pr.Status)
+	require.Empty(t, pr.Reviewers)
+	require.False(t, pr.Understaffed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoMerge_ClosedPR(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status::text\s+FROM pull_requests`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("CLOSED"))
+	mock.ExpectRollback()
+
+	_, err = r.Merge(context.Background(), dto.MergeRequest{PullRequestID: "pr-1"})
+	require.ErrorIs(t, err, errors2.ErrPRClosed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoMerge_PublishesEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status::text\s+FROM pull_requests`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OPEN"))
+	mock.ExpectQuery(`SELECT\s+t\.min_approvals`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"min_approvals", "approved"}).AddRow(0, 0))
+	mock.ExpectQuery(`UPDATE pull_requests\s+SET merged_at\s+= COALESCE\(merged_at, \$3\)`).
+		WithArgs("pr-1", "MERGED", sqlmock.AnyArg()).
+		WillReturnRows(prRows().
+			AddRow("pr-1", "Add search", "author-1", "MERGED", createdAt, createdAt.Add(time.Hour), nil, createdAt.Add(time.Hour), 1))
+	mock.ExpectQuery(`SELECT reviewer_id, source_team, COALESCE\(verdict::text, ''\), verdict_at, policy, owner_pattern, match_score, assigned_at, sla_breached_at\s+FROM pull_request_reviewers`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at"}).AddRow("u2", "backend", "", nil, "working_hours", "", nil, createdAt, nil))
+	expectEvent(mock, "pr.merged", "pr-1")
+	mock.ExpectCommit()
+
+	pr, err := r.Merge(context.Background(), dto.MergeRequest{PullRequestID: "pr-1"})
+	require.NoError(t, err)
+	require.Equal(t, dto.PRStatusMerged, pr.Status)
+	require.Equal(t, createdAt, pr.CreatedAt)
+	require.NotNil(t, pr.MergedAt)
+	require.Equal(t, createdAt.Add(time.Hour), *pr.MergedAt)
+	require.Nil(t, pr.ClosedAt)
+	require.Equal(t, createdAt, pr.Assignments[0].AssignedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoMerge_NotEnoughApprovals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status::text\s+FROM pull_requests`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OPEN"))
+	mock.ExpectQuery(`SELECT\s+t\.min_approvals`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"min_approvals", "approved"}).AddRow(2, 1))
+	mock.ExpectRollback()
+
+	_, err = r.Merge(context.Background(), dto.MergeRequest{PullRequestID: "pr-1"})
+	require.ErrorIs(t, err, errors2.ErrNotEnoughApprovals)
+	require.Contains(t, err.Error(), "1 of 2")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoRecordMerge_SkipsApprovalGate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	// min_approvals isn't read at all: the PR is merged on the provider
+	// whatever the team requires.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status::text\s+FROM pull_requests`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("DRAFT"))
+	mock.ExpectQuery(`UPDATE pull_requests\s+SET merged_at\s+= COALESCE\(merged_at, \$3\)`).
+		WithArgs("pr-1", "MERGED", sqlmock.AnyArg()).
+		WillReturnRows(prRows().
+			AddRow("pr-1", "Add search", "author-1", "MERGED", createdAt, createdAt.Add(time.Hour), nil, createdAt.Add(time.Hour), 1))
+	mock.ExpectQuery(`SELECT reviewer_id, source_team`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at"}))
+	expectEvent(mock, "pr.merged", "pr-1")
+	mock.ExpectCommit()
+
+	pr, err := r.RecordMerge(context.Background(), "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, dto.PRStatusMerged, pr.Status)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoSubmitReview_NotAssigned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status::text\s+FROM pull_requests`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OPEN"))
+	mock.ExpectExec(`UPDATE pull_request_reviewers\s+SET verdict`).
+		WithArgs("pr-1", "u9", dto.VerdictApproved, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err = r.SubmitReview(context.Background(), dto.ReviewRequest{
+		PullRequestID: "pr-1",
+		ReviewerID:    "u9",
+		Verdict:       dto.VerdictApproved,
+	})
+	require.ErrorIs(t, err, errors2.ErrNotAssigned)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoClose_MergedPR(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status::text\s+FROM pull_requests`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("MERGED"))
+	mock.ExpectRollback()
+
+	_, err = r.Close(context.Background(), "pr-1")
+	require.ErrorIs(t, err, errors2.ErrPRMerged)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoClose_AlreadyClosedKeepsUpdatedAt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	closedAt := createdAt.Add(time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status::text\s+FROM pull_requests`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("CLOSED"))
+	mock.ExpectQuery(`UPDATE pull_requests\s+SET status = \$2,.+updated_at = CASE WHEN status = \$2 THEN updated_at ELSE \$3 END`).
+		WithArgs("pr-1", "CLOSED", sqlmock.AnyArg()).
+		WillReturnRows(prRows().
+			AddRow("pr-1", "Add search", "author-1", "CLOSED", createdAt, nil, closedAt, closedAt, 2))
+	mock.ExpectQuery(`SELECT reviewer_id, source_team, COALESCE\(verdict::text, ''\), verdict_at, policy, owner_pattern, match_score, assigned_at, sla_breached_at\s+FROM pull_request_reviewers`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at"}))
+	mock.ExpectCommit()
+
+	pr, err := r.Close(context.Background(), "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, closedAt, pr.UpdatedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoMarkReady_AssignsReviewers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status::text\s+FROM pull_requests`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("DRAFT"))
+	mock.ExpectQuery(`UPDATE pull_requests\s+SET status = \$2`).
+		WithArgs("pr-1", "OPEN", sqlmock.AnyArg()).
+		WillReturnRows(prRows().
+			AddRow("pr-1", "WIP", "author-1", "OPEN", createdAt, nil, nil, createdAt.Add(time.Hour), 1))
+	mock.ExpectExec(`UPDATE pull_request_reviewers\s+SET assigned_at\s+= now\(\)`).
+		WithArgs("pr-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT reviewer_id, source_team, COALESCE\(verdict::text, ''\), verdict_at, policy, owner_pattern, match_score, assigned_at, sla_breached_at\s+FROM pull_request_reviewers`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at"}))
+	mock.ExpectQuery(`SELECT team_name FROM users WHERE user_id = \$1`).
+		WithArgs("author-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name"}).AddRow("backend"))
+	mock.ExpectQuery(`SELECT\s+reviewer_strategy,\s+COALESCE\(rr_cursor, ''\)`).
+		WithArgs("backend").
+		WillReturnRows(teamPolicyRows("least_loaded", "", 1))
+	expectNoOwnership(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+u\.user_id,\s+COUNT\(pr\.pull_request_id\) AS open_reviews`).
+		WithArgs("backend", "author-1", "pr-1").
+		WillReturnRows(candidateRows().
+			AddRow("u2", 0, 0, "UTC", "00:00", "00:00", allWeek, "{}", 0))
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers`).
+		WithArgs("pr-1", "u2", "backend", "working_hours", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE teams\s+SET rr_cursor = \$2`).
+		WithArgs("backend", "u2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	mock.ExpectQuery(`SELECT reviewer_id, source_team, COALESCE\(verdict::text, ''\), verdict_at, policy, owner_pattern, match_score, assigned_at, sla_breached_at\s+FROM pull_request_reviewers`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at"}).AddRow("u2", "backend", "", nil, "working_hours", "", nil, createdAt, nil))
+	mock.ExpectCommit()
+
+	pr, err := r.MarkReady(context.Background(), "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, dto.PRStatusOpen, pr.Status)
+	require.Equal(t, []string{"u2"}, pr.Reviewers)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func expectOpenPR(mock sqlmock.Sqlmock, prID string) {
+	mock.ExpectQuery(`SELECT\s+pull_request_id,\s+pull_request_name.+FOR UPDATE`).
+		WithArgs(prID).
+		WillReturnRows(prRows().
+			AddRow(prID, "Add search", "author-1", "OPEN", createdAt, nil, nil, createdAt, 2))
+}
+
+// expectNotRequired expects the check that userID isn't an active required
+// reviewer of the pull request.
+func expectNotRequired(mock sqlmock.Sqlmock, prID, userID string) {
+	mock.ExpectQuery(`SELECT 1\s+FROM pull_request_reviewer_rules r`).
+		WithArgs(prID, userID).
+		WillReturnError(sql.ErrNoRows)
+}
+
+func TestPRRepoAddReviewer_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	expectOpenPR(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+u\.team_name,\s+u\.is_active.+FOR UPDATE OF u`).
+		WithArgs("sec", "pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active", "excluded"}).AddRow("security", true, false))
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers \(pull_request_id, reviewer_id, source_team, policy\)`).
+		WithArgs("pr-1", "sec", "security", "manual").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT reviewer_id, source_team, COALESCE\(verdict::text, ''\), verdict_at, policy, owner_pattern, match_score, assigned_at, sla_breached_at\s+FROM pull_request_reviewers`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at"}).
+			AddRow("sec", "security", "", nil, "manual", "", nil, createdAt, nil).
+			AddRow("u2", "backend", "", nil, "working_hours", "", nil, createdAt, nil))
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	mock.ExpectCommit()
+
+	pr, err := r.AddReviewer(context.Background(), dto.ReviewerRequest{PullRequestID: "pr-1", UserID: "sec"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"sec", "u2"}, pr.Reviewers)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoAddReviewer_Excluded(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	expectOpenPR(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+u\.team_name,\s+u\.is_active.+FOR UPDATE OF u`).
+		WithArgs("u4", "pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active", "excluded"}).AddRow("backend", true, true))
+	mock.ExpectRollback()
+
+	_, err = r.AddReviewer(context.Background(), dto.ReviewerRequest{PullRequestID: "pr-1", UserID: "u4"})
+	require.ErrorIs(t, err, errors2.ErrInvalidReviewers)
+	require.ErrorContains(t, err, "reviewer u4 is excluded from the pull request")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoRemoveReviewer_KeepsRequired(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	expectOpenPR(mock, "pr-1")
+	mock.ExpectQuery(`SELECT 1\s+FROM pull_request_reviewer_rules r`).
+		WithArgs("pr-1", "sec").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err = r.RemoveReviewer(context.Background(), dto.ReviewerRequest{PullRequestID: "pr-1", UserID: "sec"})
+	require.ErrorIs(t, err, errors2.ErrInvalidReviewers)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoRemoveReviewer_NotAssigned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	expectOpenPR(mock, "pr-1")
+	expectNotRequired(mock, "pr-1", "u9")
+	mock.ExpectExec(`DELETE FROM pull_request_reviewers`).
+		WithArgs("pr-1", "u9").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err = r.RemoveReviewer(context.Background(), dto.ReviewerRequest{PullRequestID: "pr-1", UserID: "u9"})
+	require.ErrorIs(t, err, errors2.ErrNotAssigned)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoReassign_NamedUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	expectOpenPR(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+team_name\s+FROM users WHERE user_id = \$1`).
+		WithArgs("old-user").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name"}).AddRow("backend"))
+	mock.ExpectQuery(`SELECT 1\s+FROM pull_request_reviewers`).
+		WithArgs("pr-1", "old-user").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	expectNotRequired(mock, "pr-1", "old-user")
+	mock.ExpectQuery(`SELECT\s+u\.team_name,\s+u\.is_active.+FOR UPDATE OF u`).
+		WithArgs("u7", "pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "is_active", "excluded"}).AddRow("platform", true, false))
+	mock.ExpectExec(`INSERT INTO pull_request_reviewers \(pull_request_id, reviewer_id, source_team, policy\)`).
+		WithArgs("pr-1", "u7", "platform", "manual").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM pull_request_reviewers`).
+		WithArgs("pr-1", "old-user").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT reviewer_id, source_team, COALESCE\(verdict::text, ''\), verdict_at, policy, owner_pattern, match_score, assigned_at, sla_breached_at\s+FROM pull_request_reviewers`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at"}).
+			AddRow("u7", "platform", "", nil, "manual", "", nil, createdAt, nil))
+	expectEvent(mock, "reviewer.assigned", "pr-1")
+	expectEvent(mock, "reviewer.replaced", "pr-1")
+	mock.ExpectCommit()
+
+	pr, newID, err := r.Reassign(context.Background(), dto.ReassignRequest{
+		PullRequestID: "pr-1",
+		OldUserID:     "old-user",
+		NewUserID:     "u7",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "u7", newID)
+	require.Equal(t, []string{"u7"}, pr.Reviewers)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoReassign_KeepsRequired(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	expectOpenPR(mock, "pr-1")
+	mock.ExpectQuery(`SELECT\s+team_name\s+FROM users WHERE user_id = \$1`).
+		WithArgs("sec").
+		WillReturnRows(sqlmock.NewRows([]string{"team_name"}).AddRow("security"))
+	mock.ExpectQuery(`SELECT 1\s+FROM pull_request_reviewers`).
+		WithArgs("pr-1", "sec").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery(`SELECT 1\s+FROM pull_request_reviewer_rules r`).
+		WithArgs("pr-1", "sec").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectRollback()
+
+	// Neither a named replacement nor the team's strategy can take over.
+	_, _, err = r.Reassign(context.Background(), dto.ReassignRequest{PullRequestID: "pr-1", OldUserID: "sec", NewUserID: "u7"})
+	require.ErrorIs(t, err, errors2.ErrInvalidReviewers)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoGet_ReturnsReviewers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT\s+pull_request_id,.*FROM pull_requests\s+WHERE pull_request_id = \$1`).
+		WithArgs("pr-1").
+		WillReturnRows(prRows().AddRow("pr-1", "Fix", "author-1", "OPEN", createdAt, nil, nil, createdAt, 1))
+	mock.ExpectQuery(`SELECT reviewer_id, source_team`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at"}).AddRow("u2", "backend", "", nil, "working_hours", "", nil, createdAt, nil))
+	mock.ExpectCommit()
+
+	pr, err := r.Get(context.Background(), "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, "Fix", pr.Name)
+	require.Equal(t, []string{"u2"}, pr.Reviewers)
+	require.Equal(t, createdAt, pr.Assignments[0].AssignedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoGet_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM pull_requests\s+WHERE pull_request_id = \$1`).
+		WithArgs("missing").
+		WillReturnRows(prRows())
+	mock.ExpectRollback()
+
+	_, err = r.Get(context.Background(), "missing")
+	require.ErrorIs(t, err, errors2.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoList_FiltersAndKeyset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	from := createdAt.AddDate(0, -1, 0)
+	after := createdAt.Add(2 * time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM pull_requests\s+`+
+		`WHERE status = ANY\(\$1::pull_request_status\[\]\)\s+`+
+		`AND author_id IN \(SELECT user_id FROM users WHERE team_name = \$2\)\s+`+
+		`AND EXISTS \(\s+SELECT 1\s+FROM pull_request_reviewers prr\s+WHERE prr\.pull_request_id = pull_requests\.pull_request_id\s+AND prr\.reviewer_id = \$3\s+\)\s+`+
+		`AND created_at >= \$4\s+`+
+		`AND \(updated_at, pull_request_id\) < \(\$5, \$6\)\s+`+
+		`ORDER BY updated_at DESC, pull_request_id DESC\s+LIMIT \$7`).
+		WithArgs(sqlmock.AnyArg(), "backend", "u2", from, after, "pr-9", 3).
+		WillReturnRows(prRows().
+			AddRow("pr-8", "Fix", "u1", "OPEN", createdAt, nil, nil, createdAt.Add(time.Hour), 2).
+			AddRow("pr-7", "Docs", "u3", "OPEN", createdAt, nil, nil, createdAt, 1))
+	mock.ExpectQuery(`SELECT reviewer_id, source_team, .*, pull_request_id\s+FROM pull_request_reviewers\s+WHERE pull_request_id = ANY\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"reviewer_id", "source_team", "verdict", "verdict_at", "policy", "owner_pattern", "match_score", "assigned_at", "sla_breached_at", "pull_request_id"}).
+			AddRow("u2", "backend", "", nil, "working_hours", "", nil, createdAt, nil, "pr-7").
+			AddRow("u2", "backend", "APPROVED", createdAt, "working_hours", "", nil, createdAt, nil, "pr-8").
+			AddRow("u4", "backend", "", nil, "working_hours", "", nil, createdAt, nil, "pr-8"))
+	mock.ExpectCommit()
+
+	prs, err := r.List(context.Background(), dto.PRListFilter{
+		Statuses:    []string{"OPEN"},
+		TeamName:    "backend",
+		ReviewerID:  "u2",
+		CreatedFrom: &from,
+		SortBy:      dto.PRSortUpdatedAt,
+		Desc:        true,
+		After:       &dto.PRListCursor{SortBy: dto.PRSortUpdatedAt, Desc: true, Time: after, ID: "pr-9"},
+		Limit:       3,
+	})
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	require.Equal(t, []string{"u2", "u4"}, prs[0].Reviewers)
+	require.False(t, prs[0].Understaffed)
+	require.Equal(t, []string{"u2"}, prs[1].Reviewers)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepoList_Unfiltered(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewPRRepository(db, selector.NewRegistry(1))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM pull_requests\s+ORDER BY created_at ASC, pull_request_id ASC\s+LIMIT \$1`).
+		WithArgs(51).
+		WillReturnRows(prRows())
+	mock.ExpectCommit()
+
+	prs, err := r.List(context.Background(), dto.PRListFilter{SortBy: dto.PRSortCreatedAt, Limit: 51})
+	require.NoError(t, err)
+	require.Empty(t, prs)
+	require.NotNil(t, prs)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,89 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	repo "pr-reviwer-assigner/internal/infrastructure/database/repository"
+)
+
+func TestReviewSLARepoListPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewReviewSLARepository(db)
+
+	assigned := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	after := dto.PendingReview{PullRequestID: "pr-0", ReviewerID: "u9", AssignedAt: assigned.Add(-time.Hour)}
+
+	mock.ExpectQuery(`FROM pull_request_reviewers prr\s+JOIN pull_requests pr`).
+		WithArgs(after.AssignedAt, "pr-0", "u9", 50).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"pull_request_id", "pull_request_name", "author_id", "reviewer_id", "team_name", "assigned_at",
+			"review_sla_hours", "sla_auto_reassign", "required", "time_zone", "work_start", "work_end", "work_days",
+		}).AddRow("pr-1", "Add search", "author-1", "u2", "backend", assigned, 24, true, true, "Europe/Berlin", "09:00", "17:00", "{1,2,3,4,5}"))
+
+	pending, err := r.ListPending(context.Background(), after, 50)
+	require.NoError(t, err)
+	require.Equal(t, []dto.PendingReview{{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorID:        "author-1",
+		ReviewerID:      "u2",
+		TeamName:        "backend",
+		AssignedAt:      assigned,
+		SLAHours:        24,
+		AutoReassign:    true,
+		Required:        true,
+		WorkingHours:    &dto.WorkingHours{TimeZone: "Europe/Berlin", Start: "09:00", End: "17:00", Days: []int{1, 2, 3, 4, 5}},
+	}}, pending)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewSLARepoMarkBreached_WritesEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewReviewSLARepository(db)
+	review := dto.PendingReview{PullRequestID: "pr-1", ReviewerID: "u2", AssignedAt: time.Now(), SLAHours: 24}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE pull_request_reviewers\s+SET sla_breached_at = now\(\)`).
+		WithArgs("pr-1", "u2", review.AssignedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEvent(mock, "review.sla_breached", "pr-1")
+	mock.ExpectCommit()
+
+	marked, err := r.MarkBreached(context.Background(), review)
+	require.NoError(t, err)
+	require.True(t, marked)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewSLARepoMarkBreached_AlreadyHandled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewReviewSLARepository(db)
+	review := dto.PendingReview{PullRequestID: "pr-1", ReviewerID: "u2", AssignedAt: time.Now(), SLAHours: 24}
+
+	// The reviewer submitted a verdict since the review was listed.
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE pull_request_reviewers\s+SET sla_breached_at = now\(\)`).
+		WithArgs("pr-1", "u2", review.AssignedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	marked, err := r.MarkBreached(context.Background(), review)
+	require.NoError(t, err)
+	require.False(t, marked)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,73 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	errors2 "pr-reviwer-assigner/internal/errors"
+	repo "pr-reviwer-assigner/internal/infrastructure/database/repository"
+)
+
+func TestStatsRepoPairings_BuildsMatrix(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewStatsRepository(db)
+
+	mock.ExpectQuery(`SELECT t\.pairing_window, a\.user_id, prr\.reviewer_id, COUNT\(prr\.reviewer_id\)\s+FROM teams t`).
+		WithArgs("backend").
+		WillReturnRows(sqlmock.NewRows([]string{"pairing_window", "user_id", "reviewer_id", "count"}).
+			AddRow(5, "u1", "u2", 4).
+			AddRow(5, "u1", "u3", 1).
+			AddRow(5, "u2", "u1", 2).
+			AddRow(5, "u3", nil, 0))
+
+	stats, err := r.Pairings(context.Background(), "backend")
+	require.NoError(t, err)
+	require.Equal(t, "backend", stats.TeamName)
+	require.Equal(t, 5, stats.PairingWindow)
+	require.Equal(t, map[string]map[string]int{
+		"u1": {"u2": 4, "u3": 1},
+		"u2": {"u1": 2},
+		"u3": {},
+	}, stats.Pairings)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsRepoPairings_EmptyTeam(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewStatsRepository(db)
+
+	mock.ExpectQuery(`FROM teams t`).
+		WithArgs("empty").
+		WillReturnRows(sqlmock.NewRows([]string{"pairing_window", "user_id", "reviewer_id", "count"}).
+			AddRow(5, nil, nil, 0))
+
+	stats, err := r.Pairings(context.Background(), "empty")
+	require.NoError(t, err)
+	require.Empty(t, stats.Pairings)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsRepoPairings_UnknownTeam(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewStatsRepository(db)
+
+	mock.ExpectQuery(`FROM teams t`).
+		WithArgs("ghosts").
+		WillReturnRows(sqlmock.NewRows([]string{"pairing_window", "user_id", "reviewer_id", "count"}))
+
+	_, err = r.Pairings(context.Background(), "ghosts")
+	require.ErrorIs(t, err, errors2.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
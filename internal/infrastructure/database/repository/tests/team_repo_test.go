@@ -22,11 +22,11 @@ func TestTeamRepoGet_Success(t *testing.T) {
 
 	r := repo.NewTeamRepository(db)
 
-	rows := sqlmock.NewRows([]string{"user_id", "username", "is_active"}).
-		AddRow("u1", "Alice", true).
-		AddRow("u2", "Bob", false)
+	rows := sqlmock.NewRows([]string{"user_id", "username", "is_active", "skills"}).
+		AddRow("u1", "Alice", true, "{go,postgres}").
+		AddRow("u2", "Bob", false, "{}")
 
-	mock.ExpectQuery(`SELECT user_id, username, is_active FROM users WHERE team_name = \$1`).
+	mock.ExpectQuery(`SELECT u\.user_id, u\.username, u\.is_active, ARRAY\(SELECT s\.skill FROM user_skills s WHERE s\.user_id = u\.user_id ORDER BY s\.skill\) FROM users u WHERE u\.team_name = \$1`).
 		WithArgs("backend").
 		WillReturnRows(rows)
 
@@ -35,6 +35,7 @@ func TestTeamRepoGet_Success(t *testing.T) {
 	require.Len(t, members, 2)
 	require.Equal(t, "u1", members[0].ID)
 	require.Equal(t, false, members[1].IsActive)
+	require.Equal(t, []string{"go", "postgres"}, members[0].Skills)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -45,9 +46,9 @@ func TestTeamRepoGet_NotFound(t *testing.T) {
 
 	r := repo.NewTeamRepository(db)
 
-	mock.ExpectQuery(`SELECT user_id, username, is_active FROM users WHERE team_name = \$1`).
+	mock.ExpectQuery(`SELECT u\.user_id, u\.username, u\.is_active, ARRAY\(SELECT s\.skill FROM user_skills s WHERE s\.user_id = u\.user_id ORDER BY s\.skill\) FROM users u WHERE u\.team_name = \$1`).
 		WithArgs("ghosts").
-		WillReturnRows(sqlmock.NewRows([]string{"user_id", "username", "is_active"}))
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "username", "is_active", "skills"}))
 
 	mock.ExpectQuery(`SELECT 1 FROM teams WHERE team_name = \$1`).
 		WithArgs("ghosts").
@@ -66,13 +67,17 @@ func TestTeamRepoAdd_AlreadyExists(t *testing.T) {
 	r := repo.NewTeamRepository(db)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(`INSERT INTO teams \(team_name\) VALUES \(\$1\)`).
-		WithArgs("backend").
+	mock.ExpectExec(`INSERT INTO teams \(team_name, reviewer_strategy, reviewers_count, min_reviewers, max_reviewers, min_approvals\)`).
+		WithArgs("backend", "least_loaded", 2, 1, 5, 0).
 		WillReturnError(&pq.Error{Code: "23505"})
 	mock.ExpectRollback()
 
 	team := dto.Team{
-		Name: "backend",
+		Name:             "backend",
+		ReviewerStrategy: "least_loaded",
+		ReviewersCount:   2,
+		MinReviewers:     1,
+		MaxReviewers:     5,
 		Members: []dto.TeamMember{
 			{ID: "u1", Name: "Alice", IsActive: true},
 		},
@@ -83,6 +88,37 @@ func TestTeamRepoAdd_AlreadyExists(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestTeamRepoAdd_CheckViolations(t *testing.T) {
+	for constraint, want := range map[string]string{
+		"teams_reviewers_bounds":           "INVALID_REVIEWERS_COUNT: expected min_reviewers <= reviewers_count <= max_reviewers",
+		"teams_min_approvals_non_negative": "BAD_REQUEST: min_approvals can't be negative",
+		"teams_some_new_rule":              "BAD_REQUEST: violates constraint teams_some_new_rule",
+	} {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		r := repo.NewTeamRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO teams \(team_name, reviewer_strategy, reviewers_count, min_reviewers, max_reviewers, min_approvals\)`).
+			WithArgs("backend", "least_loaded", 2, 1, 5, 0).
+			WillReturnError(&pq.Error{Code: "23514", Constraint: constraint})
+		mock.ExpectRollback()
+
+		err = r.Add(context.Background(), dto.Team{
+			Name:             "backend",
+			ReviewerStrategy: "least_loaded",
+			ReviewersCount:   2,
+			MinReviewers:     1,
+			MaxReviewers:     5,
+			Members:          []dto.TeamMember{{ID: "u1", Name: "Alice", IsActive: true}},
+		})
+		require.EqualError(t, err, want)
+		require.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	}
+}
+
 func TestTeamRepoAdd_Success(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -91,8 +127,8 @@ func TestTeamRepoAdd_Success(t *testing.T) {
 	r := repo.NewTeamRepository(db)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(`INSERT INTO teams \(team_name\) VALUES \(\$1\)`).
-		WithArgs("backend").
+	mock.ExpectExec(`INSERT INTO teams \(team_name, reviewer_strategy, reviewers_count, min_reviewers, max_reviewers, min_approvals\)`).
+		WithArgs("backend", "least_loaded", 2, 1, 5, 0).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	userUpsert := regexp.QuoteMeta(`
@@ -114,7 +150,11 @@ func TestTeamRepoAdd_Success(t *testing.T) {
 	mock.ExpectCommit()
 
 	team := dto.Team{
-		Name: "backend",
+		Name:             "backend",
+		ReviewerStrategy: "least_loaded",
+		ReviewersCount:   2,
+		MinReviewers:     1,
+		MaxReviewers:     5,
 		Members: []dto.TeamMember{
 			{ID: "u1", Name: "Alice", IsActive: true},
 			{ID: "u2", Name: "Bob", IsActive: false},
@@ -1,6 +1,7 @@
 package repository_test
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
@@ -99,3 +100,74 @@ func TestUserRepoGetReview_Success(t *testing.T) {
 	require.Equal(t, "pr-2", prs[1].ID)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestUserRepoResolveVCSLogin_FallsBackToUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewUserRepository(db)
+
+	mock.ExpectQuery(`SELECT COALESCE\(\s+\(SELECT user_id FROM vcs_identities`).
+		WithArgs("github", "u1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("u1"))
+
+	userID, err := r.ResolveVCSLogin(context.Background(), "github", "u1")
+	require.NoError(t, err)
+	require.Equal(t, "u1", userID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepoResolveVCSLogin_Unknown(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewUserRepository(db)
+
+	mock.ExpectQuery(`SELECT COALESCE\(\s+\(SELECT user_id FROM vcs_identities`).
+		WithArgs("github", "stranger").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(nil))
+
+	_, err = r.ResolveVCSLogin(context.Background(), "github", "stranger")
+	require.ErrorIs(t, err, errors2.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepoSetProfile_WithoutWorkingHours(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewUserRepository(db)
+
+	email := "bob@example.com"
+	mock.ExpectQuery(`UPDATE users\s+SET chat_handle = COALESCE\(\$2, chat_handle\)`).
+		WithArgs("u2", nil, email, "", "", "", nil, false).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "chat_handle", "email", "time_zone", "work_start", "work_end", "work_days"}).
+			AddRow("u2", "Bob", "backend", true, "", email, "", "", "", nil))
+
+	user, err := r.SetProfile(context.Background(), dto.ProfileRequest{ID: "u2", Email: &email})
+	require.NoError(t, err)
+	require.Equal(t, email, user.Email)
+	require.Nil(t, user.WorkingHours)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepoSetProfile_FirstWorkingHoursFillDefaults(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewUserRepository(db)
+
+	mock.ExpectQuery(`time_zone\s+= COALESCE\(NULLIF\(\$4, ''\), time_zone, CASE WHEN \$8 THEN 'UTC' END\)`).
+		WithArgs("u2", nil, nil, "Asia/Singapore", "", "", nil, true).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "chat_handle", "email", "time_zone", "work_start", "work_end", "work_days"}).
+			AddRow("u2", "Bob", "backend", true, "", "", "Asia/Singapore", "09:00", "18:00", "{1,2,3,4,5}"))
+
+	user, err := r.SetProfile(context.Background(), dto.ProfileRequest{ID: "u2", WorkingHours: &dto.WorkingHours{TimeZone: "Asia/Singapore"}})
+	require.NoError(t, err)
+	require.Equal(t, &dto.WorkingHours{TimeZone: "Asia/Singapore", Start: "09:00", End: "18:00", Days: []int{1, 2, 3, 4, 5}}, user.WorkingHours)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/events"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	repo "pr-reviwer-assigner/internal/infrastructure/database/repository"
+)
+
+func TestWebhookRepoEnqueue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewWebhookRepository(db)
+
+	mock.ExpectExec(`INSERT INTO webhook_deliveries \(subscription_id, event_id\)\s+SELECT s.id, \$1`).
+		WithArgs(int64(9), events.ReviewerAssigned, pq.Array([]string{"u1", "u2"}), pq.Array([]string{"platform"})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	ev := events.Event{ID: 9, Type: events.ReviewerAssigned}
+	n, err := r.Enqueue(context.Background(), ev, []string{"u1", "u2"}, []string{"platform"})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepoRetryDelivery_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	r := repo.NewWebhookRepository(db)
+
+	mock.ExpectExec(`UPDATE webhook_deliveries\s+SET status\s+= 'PENDING'`).
+		WithArgs(int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = r.RetryDelivery(context.Background(), 3)
+	require.ErrorIs(t, err, errors2.ErrNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
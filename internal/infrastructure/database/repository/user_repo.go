@@ -1,11 +1,14 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"pr-reviwer-assigner/internal/domain/dto"
 	"pr-reviwer-assigner/internal/domain/repository"
 	errors2 "pr-reviwer-assigner/internal/errors"
+
+	"github.com/lib/pq"
 )
 
 type userRepo struct {
@@ -29,6 +32,7 @@ func (s *userRepo) GetReview(userID string) ([]dto.PRShort, error) {
 		JOIN pull_request_reviewers prr
 		  ON prr.pull_request_id = pr.pull_request_id
 		WHERE prr.reviewer_id = $1
+		  AND pr.status <> 'CLOSED'
 		ORDER BY pr.created_at NULLS LAST, pr.pull_request_id`
 
 	rows, err := s.db.Query(getReview, userID)
@@ -100,3 +104,217 @@ func (s *userRepo) SetIsActive(req dto.SIARequest) (*dto.User, error) {
 
 	return &user, nil
 }
+
+func (s *userRepo) SetProfile(ctx context.Context, req dto.ProfileRequest) (*dto.User, error) {
+	const setProfile = `
+		UPDATE users
+		   SET chat_handle = COALESCE($2, chat_handle),
+		       email       = COALESCE($3, email),
+		       time_zone   = COALESCE(NULLIF($4, ''), time_zone, CASE WHEN $8 THEN 'UTC' END),
+		       work_start  = COALESCE(NULLIF($5, '')::time, work_start, CASE WHEN $8 THEN time '09:00' END),
+		       work_end    = COALESCE(NULLIF($6, '')::time, work_end, CASE WHEN $8 THEN time '18:00' END),
+		       work_days   = COALESCE($7, work_days, CASE WHEN $8 THEN '{1,2,3,4,5}'::smallint[] END)
+		 WHERE user_id = $1
+		RETURNING user_id, username, team_name, is_active, chat_handle, email,
+		          COALESCE(time_zone, ''), COALESCE(to_char(work_start, 'HH24:MI'), ''),
+		          COALESCE(to_char(work_end, 'HH24:MI'), ''), work_days
+	`
+
+	// Users have no working hours until they are first set; the fields left
+	// out then get the usual office hours.
+
+	var hours dto.WorkingHours
+	var days pq.Int64Array
+	if req.WorkingHours != nil {
+		hours = *req.WorkingHours
+		for _, d := range hours.Days {
+			days = append(days, int64(d))
+		}
+	}
+
+	var user dto.User
+	var storedHours dto.WorkingHours
+	var storedDays pq.Int64Array
+	err := s.db.QueryRowContext(ctx, setProfile,
+		req.ID,
+		req.ChatHandle,
+		req.Email,
+		hours.TimeZone,
+		hours.Start,
+		hours.End,
+		days,
+		req.WorkingHours != nil,
+	).Scan(
+		&user.ID,
+		&user.Name,
+		&user.Team,
+		&user.IsActive,
+		&user.ChatHandle,
+		&user.Email,
+		&storedHours.TimeZone,
+		&storedHours.Start,
+		&storedHours.End,
+		&storedDays,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors2.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if storedHours.TimeZone != "" {
+		for _, d := range storedDays {
+			storedHours.Days = append(storedHours.Days, int(d))
+		}
+		user.WorkingHours = &storedHours
+	}
+
+	return &user, nil
+}
+
+func (s *userRepo) GetSkills(ctx context.Context, userID string) (*dto.UserSkills, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	skills, err := listSkills(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return skills, nil
+}
+
+func (s *userRepo) SetSkills(ctx context.Context, req dto.UserSkills) (*dto.UserSkills, error) {
+	const lockUser = `SELECT 1 FROM users WHERE user_id = $1 FOR UPDATE`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var dummy int
+	err = tx.QueryRowContext(ctx, lockUser, req.UserID).Scan(&dummy)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors2.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := replaceSkills(ctx, tx, req.UserID, req.Skills); err != nil {
+		return nil, err
+	}
+
+	skills, err := listSkills(ctx, tx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return skills, nil
+}
+
+func listSkills(ctx context.Context, tx *sql.Tx, userID string) (*dto.UserSkills, error) {
+	const query = `
+		SELECT ARRAY(SELECT s.skill FROM user_skills s WHERE s.user_id = u.user_id ORDER BY s.skill)
+		FROM users u
+		WHERE u.user_id = $1
+	`
+
+	skills := dto.UserSkills{UserID: userID}
+	err := tx.QueryRowContext(ctx, query, userID).Scan(pq.Array(&skills.Skills))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors2.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+	if skills.Skills == nil {
+		skills.Skills = []string{}
+	}
+
+	return &skills, nil
+}
+
+// replaceSkills makes skills the user's only skill tags.
+func replaceSkills(ctx context.Context, tx *sql.Tx, userID string, skills []string) error {
+	const deleteQuery = `
+		DELETE FROM user_skills
+		WHERE user_id = $1
+	`
+
+	const insertQuery = `
+		INSERT INTO user_skills (user_id, skill)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, userID); err != nil {
+		return err
+	}
+	if len(skills) == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, insertQuery, userID, pq.Array(skills))
+	return err
+}
+
+func (s *userRepo) SetVCSIdentity(ctx context.Context, identity dto.VCSIdentity) (*dto.VCSIdentity, error) {
+	const upsertIdentity = `
+		INSERT INTO vcs_identities (provider, login, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (provider, login) DO UPDATE
+		SET user_id = EXCLUDED.user_id
+	`
+
+	_, err := s.db.ExecContext(ctx, upsertIdentity, identity.Provider, identity.Login, identity.UserID)
+	if err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return nil, errors2.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &identity, nil
+}
+
+func (s *userRepo) ResolveVCSLogin(ctx context.Context, provider, login string) (string, error) {
+	const resolveLogin = `
+		SELECT COALESCE(
+		    (SELECT user_id FROM vcs_identities WHERE provider = $1 AND login = $2),
+		    (SELECT user_id FROM users WHERE user_id = $2)
+		)
+	`
+
+	var userID sql.NullString
+	err := s.db.QueryRowContext(ctx, resolveLogin, provider, login).Scan(&userID)
+	if err != nil {
+		return "", err
+	}
+	if !userID.Valid {
+		return "", errors2.ErrNotFound
+	}
+
+	return userID.String, nil
+}
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/events"
+	"pr-reviwer-assigner/internal/domain/repository"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type webhookRepo struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) repository.WebhookRepository {
+	return &webhookRepo{
+		db: db,
+	}
+}
+
+func (r *webhookRepo) CreateSubscription(ctx context.Context, sub dto.WebhookSubscription) (*dto.WebhookSubscription, error) {
+	const query = `
+		INSERT INTO webhook_subscriptions (team_name, url, event_types, secret)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		sub.TeamName,
+		sub.URL,
+		pq.Array(sub.EventTypes),
+		sub.Secret,
+	).Scan(&sub.ID, &sub.CreatedAt)
+	if err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return nil, errors2.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &sub, nil
+}
+
+func (r *webhookRepo) ListSubscriptions(ctx context.Context, teamName string) ([]dto.WebhookSubscription, error) {
+	const query = `
+		SELECT id, team_name, url, event_types, created_at
+		FROM webhook_subscriptions
+		WHERE team_name = $1
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := make([]dto.WebhookSubscription, 0)
+	for rows.Next() {
+		var sub dto.WebhookSubscription
+		err = rows.Scan(&sub.ID, &sub.TeamName, &sub.URL, pq.Array(&sub.EventTypes), &sub.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(subs) == 0 {
+		var dummy int
+		err = r.db.QueryRowContext(ctx, `SELECT 1 FROM teams WHERE team_name = $1`, teamName).Scan(&dummy)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return nil, errors2.ErrNotFound
+			default:
+				return nil, err
+			}
+		}
+	}
+
+	return subs, nil
+}
+
+func (r *webhookRepo) DeleteSubscription(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors2.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *webhookRepo) Enqueue(ctx context.Context, ev events.Event, userIDs, teams []string) (int, error) {
+	const query = `
+		INSERT INTO webhook_deliveries (subscription_id, event_id)
+		SELECT s.id, $1
+		FROM webhook_subscriptions s
+		WHERE (cardinality(s.event_types) = 0 OR $2 = ANY(s.event_types))
+		  AND s.team_name IN (
+		      SELECT team_name FROM users WHERE user_id = ANY($3)
+		      UNION
+		      SELECT unnest($4::text[])
+		  )
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+
+	res, err := r.db.ExecContext(ctx, query, ev.ID, ev.Type, pq.Array(userIDs), pq.Array(teams))
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+func (r *webhookRepo) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]dto.WebhookJob, error) {
+	const query = `
+		WITH due AS (
+		    SELECT id
+		    FROM webhook_deliveries
+		    WHERE status = 'PENDING'
+		      AND next_attempt_at <= now()
+		    ORDER BY next_attempt_at, id
+		    LIMIT $1
+		    FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		   SET next_attempt_at = now() + $2 * interval '1 millisecond'
+		  FROM due, webhook_subscriptions s, outbox_events e
+		 WHERE d.id = due.id
+		   AND s.id = d.subscription_id
+		   AND e.id = d.event_id
+		RETURNING d.id, d.attempts, s.url, s.secret, e.id, e.event_type, e.aggregate_id, e.payload, e.created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []dto.WebhookJob
+	for rows.Next() {
+		var job dto.WebhookJob
+		err = rows.Scan(
+			&job.DeliveryID,
+			&job.Attempts,
+			&job.URL,
+			&job.Secret,
+			&job.Event.ID,
+			&job.Event.Type,
+			&job.Event.AggregateID,
+			&job.Event.Payload,
+			&job.Event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *webhookRepo) MarkDelivered(ctx context.Context, id int64, statusCode int) error {
+	const query = `
+		UPDATE webhook_deliveries
+		   SET status           = 'DELIVERED',
+		       attempts         = attempts + 1,
+		       last_status_code = $2,
+		       last_error       = NULL,
+		       delivered_at     = now()
+		 WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, statusCode)
+	return err
+}
+
+func (r *webhookRepo) MarkFailed(ctx context.Context, id int64, statusCode int, reason string, next time.Time, dead bool) error {
+	const query = `
+		UPDATE webhook_deliveries
+		   SET status           = CASE WHEN $5 THEN 'DEAD' ELSE 'PENDING' END,
+		       attempts         = attempts + 1,
+		       last_status_code = NULLIF($2, 0),
+		       last_error       = $3,
+		       next_attempt_at  = $4
+		 WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, statusCode, reason, next, dead)
+	return err
+}
+
+func (r *webhookRepo) ListDeliveries(ctx context.Context, subscriptionID int64, status string, limit int) ([]dto.WebhookDelivery, error) {
+	const query = `
+		SELECT
+		    d.id,
+		    d.subscription_id,
+		    d.event_id,
+		    e.event_type,
+		    d.status,
+		    d.attempts,
+		    COALESCE(d.last_status_code, 0),
+		    COALESCE(d.last_error, ''),
+		    d.next_attempt_at,
+		    d.delivered_at
+		FROM webhook_deliveries d
+		JOIN outbox_events e ON e.id = d.event_id
+		WHERE d.subscription_id = $1
+		  AND ($2 = '' OR d.status = $2)
+		ORDER BY d.id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, subscriptionID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]dto.WebhookDelivery, 0)
+	for rows.Next() {
+		var d dto.WebhookDelivery
+		var deliveredAt sql.NullTime
+		err = rows.Scan(
+			&d.ID,
+			&d.SubscriptionID,
+			&d.EventID,
+			&d.EventType,
+			&d.Status,
+			&d.Attempts,
+			&d.LastStatusCode,
+			&d.LastError,
+			&d.NextAttemptAt,
+			&deliveredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.Time
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// RetryDelivery puts an undelivered (usually dead-lettered) delivery back in
+// the queue with a fresh attempt budget.
+func (r *webhookRepo) RetryDelivery(ctx context.Context, id int64) error {
+	const query = `
+		UPDATE webhook_deliveries
+		   SET status          = 'PENDING',
+		       attempts        = 0,
+		       next_attempt_at = now()
+		 WHERE id = $1
+		   AND status <> 'DELIVERED'
+	`
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors2.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Template names. Each one is rendered from <name>.subject.tmpl and
+// <name>.txt.tmpl (text/template) and <name>.html.tmpl (html/template).
+const (
+	TemplateAssigned = "assigned"
+	TemplateReplaced = "replaced"
+	TemplateMerged   = "merged"
+)
+
+var templateNames = []string{TemplateAssigned, TemplateReplaced, TemplateMerged}
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// Data is what every template is executed with. ReviewerName is the new
+// reviewer in "replaced" messages and empty otherwise.
+type Data struct {
+	RecipientName   string
+	PullRequestID   string
+	PullRequestName string
+	AuthorName      string
+	ReviewerName    string
+}
+
+type Templates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// LoadTemplates parses the built-in templates and replaces each of them
+// with the file of the same name in dir, if one exists. An empty dir keeps
+// the built-in set.
+func LoadTemplates(dir string) (*Templates, error) {
+	t := &Templates{
+		text: texttemplate.New("mail"),
+		html: htmltemplate.New("mail"),
+	}
+
+	for _, name := range templateNames {
+		for _, file := range []string{name + ".subject.tmpl", name + ".txt.tmpl", name + ".html.tmpl"} {
+			src, err := readTemplate(dir, file)
+			if err != nil {
+				return nil, err
+			}
+
+			if strings.HasSuffix(file, ".html.tmpl") {
+				_, err = t.html.New(file).Parse(src)
+			} else {
+				_, err = t.text.New(file).Parse(src)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return t, nil
+}
+
+func readTemplate(dir, file string) (string, error) {
+	if dir != "" {
+		src, err := os.ReadFile(filepath.Join(dir, file))
+		if err == nil {
+			return string(src), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	src, err := defaultTemplates.ReadFile("templates/" + file)
+	if err != nil {
+		return "", err
+	}
+	return string(src), nil
+}
+
+// Render executes the named template set. The subject is trimmed to one line.
+func (t *Templates) Render(name string, to []string, data Data) (Message, error) {
+	var subject, text, html bytes.Buffer
+
+	if err := t.text.ExecuteTemplate(&subject, name+".subject.tmpl", data); err != nil {
+		return Message{}, err
+	}
+	if err := t.text.ExecuteTemplate(&text, name+".txt.tmpl", data); err != nil {
+		return Message{}, err
+	}
+	if err := t.html.ExecuteTemplate(&html, name+".html.tmpl", data); err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		To:      to,
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
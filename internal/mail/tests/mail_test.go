@@ -0,0 +1,156 @@
+package mail_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/mail"
+)
+
+// smtpStandIn is a minimal in-process SMTP server that records what it receives.
+type smtpStandIn struct {
+	ln net.Listener
+
+	mu    sync.Mutex
+	from  string
+	rcpts []string
+	data  string
+}
+
+func newSMTPStandIn(t *testing.T) *smtpStandIn {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &smtpStandIn{ln: ln}
+	t.Cleanup(func() { ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *smtpStandIn) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpStandIn) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpStandIn) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 stand-in ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 stand-in")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			s.mu.Lock()
+			s.from = strings.TrimSpace(line[len("MAIL FROM:"):])
+			s.mu.Unlock()
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			s.mu.Lock()
+			s.rcpts = append(s.rcpts, strings.TrimSpace(line[len("RCPT TO:"):]))
+			s.mu.Unlock()
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			var b strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				b.WriteString(l)
+			}
+			s.mu.Lock()
+			s.data = b.String()
+			s.mu.Unlock()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	srv := newSMTPStandIn(t)
+
+	sender := mail.NewSMTPSender(mail.SMTPOptions{
+		Host:    "127.0.0.1",
+		Port:    srv.port(),
+		From:    "noreply@example.com",
+		Timeout: 5 * time.Second,
+	})
+
+	templates, err := mail.LoadTemplates("")
+	require.NoError(t, err)
+
+	msg, err := templates.Render(mail.TemplateAssigned, []string{"bob@example.com"}, mail.Data{
+		RecipientName:   "Bob",
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add <search>",
+		AuthorName:      "Alice",
+	})
+	require.NoError(t, err)
+	require.NoError(t, sender.Send(context.Background(), msg))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	require.Equal(t, "<noreply@example.com>", srv.from)
+	require.Equal(t, []string{"<bob@example.com>"}, srv.rcpts)
+	require.Contains(t, srv.data, "Subject: Review requested: Add <search>")
+	require.Contains(t, srv.data, "multipart/alternative")
+	require.Contains(t, srv.data, "Add &lt;search&gt;")
+}
+
+func TestLoadTemplates_OverridesFromDir(t *testing.T) {
+	templates, err := mail.LoadTemplates("testdata/templates")
+	require.NoError(t, err)
+
+	msg, err := templates.Render(mail.TemplateAssigned, []string{"bob@example.com"}, mail.Data{
+		RecipientName:   "Bob",
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add search",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "[PR] pr-1 needs you", msg.Subject)
+	require.Contains(t, msg.Text, "Hi Bob,")
+}
+
+func TestSMTPSender_ConnectionErrorIsNotPermanent(t *testing.T) {
+	srv := newSMTPStandIn(t)
+	srv.ln.Close()
+
+	sender := mail.NewSMTPSender(mail.SMTPOptions{Host: "127.0.0.1", Port: srv.port(), From: "a@example.com"})
+	err := sender.Send(context.Background(), mail.Message{To: []string{"b@example.com"}, Text: "hi"})
+	require.Error(t, err)
+	require.False(t, mail.IsPermanent(err))
+}
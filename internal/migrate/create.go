@@ -0,0 +1,57 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidName = errors.New("migration name must be lower-case letters, digits and underscores")
+
+var validName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Create writes an empty up and down file for the next version into dir and
+// returns their paths. Spaces and dashes in name become underscores.
+func Create(dir, name string) (string, string, error) {
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+	if !validName.MatchString(name) {
+		return "", "", ErrInvalidName
+	}
+
+	existing, err := Load(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+	next := Migration{Version: 1, Name: name}
+	if len(existing) > 0 {
+		next.Version = existing[len(existing)-1].Version + 1
+	}
+
+	up := filepath.Join(dir, next.String()+".up.sql")
+	down := filepath.Join(dir, next.String()+".down.sql")
+	if err := createFile(up, fmt.Sprintf("-- %s: apply the change.\n", next)); err != nil {
+		return "", "", err
+	}
+	if err := createFile(down, fmt.Sprintf("-- %s: revert the up migration.\n", next)); err != nil {
+		os.Remove(up)
+		return "", "", err
+	}
+
+	return up, down, nil
+}
+
+// createFile fails instead of overwriting an existing file.
+func createFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,390 @@
+// Package migrate applies numbered up/down SQL migrations and records them in
+// the schema_migrations table. Every run holds a Postgres advisory lock, so
+// replicas starting together apply each migration once.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// lockKey identifies the advisory lock migrations run under.
+const lockKey int64 = 0x70725f6d69677261 // "pr_migra"
+
+// baselineTable is created by the first migration with baselineColumns. A
+// database that has it but no migration history was created by the database
+// container before migrations existed; Up adopts its schema as the first
+// migration instead of running it.
+const (
+	baselineTable   = "teams"
+	baselineColumns = "team_name"
+)
+
+var (
+	ErrNoMigrations = errors.New("no migrations found")
+	// ErrUnknownSchema is returned by Up for a database without migration
+	// history whose schema isn't the one of the first migration.
+	ErrUnknownSchema = errors.New("schema without migration history doesn't match the first migration")
+)
+
+// fileName matches "0001_init.up.sql" and "0001_init.down.sql".
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Status is a migration and when it was applied; AppliedAt is nil while the
+// migration is pending.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+	// Unknown is set for an applied version this binary has no files for.
+	Unknown bool
+}
+
+// Load reads the migrations of the directory, ordered by version. Every
+// version needs both an up and a down file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("version %d is used by %q and %q", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migration %s needs both an up and a down file", mig)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return nil, ErrNoMigrations
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies every pending migration in version order, each in its own
+// transaction, and returns the applied ones.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 {
+			adopted, err := m.adoptBaseline(ctx, conn)
+			if err != nil {
+				return err
+			}
+			if adopted {
+				applied[m.migrations[0].Version] = true
+			}
+		}
+
+		for _, mig := range m.migrations {
+			if applied[mig.Version] {
+				continue
+			}
+			if err := apply(ctx, conn, mig, true); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Down rolls back the latest applied migration and returns it, nil when
+// nothing is applied.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var done *Migration
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		var latest int64 = -1
+		for version := range applied {
+			latest = max(latest, version)
+		}
+		if latest < 0 {
+			return nil
+		}
+
+		for _, mig := range m.migrations {
+			if mig.Version == latest {
+				if err := apply(ctx, conn, mig, false); err != nil {
+					return err
+				}
+				done = &mig
+				return nil
+			}
+		}
+		return fmt.Errorf("applied version %d has no migration files in this build", latest)
+	})
+
+	return done, err
+}
+
+// Status lists the known migrations and the applied versions this binary has
+// no files for, in version order.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		const query = `
+			SELECT version, name, applied_at
+			FROM schema_migrations
+			ORDER BY version
+		`
+
+		rows, err := conn.QueryContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		applied := make(map[int64]Status)
+		for rows.Next() {
+			var (
+				s  Status
+				at time.Time
+			)
+			if err := rows.Scan(&s.Version, &s.Name, &at); err != nil {
+				return err
+			}
+			s.AppliedAt = &at
+			s.Unknown = true
+			applied[s.Version] = s
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			s := Status{Migration: mig}
+			if a, ok := applied[mig.Version]; ok {
+				s.AppliedAt = a.AppliedAt
+				delete(applied, mig.Version)
+			}
+			statuses = append(statuses, s)
+		}
+		for _, s := range applied {
+			statuses = append(statuses, s)
+		}
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].Version < statuses[j].Version
+		})
+		return nil
+	})
+
+	return statuses, err
+}
+
+// locked runs fn on a single connection holding the advisory lock, after
+// making sure schema_migrations exists.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	const createQuery = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+		    version    BIGINT PRIMARY KEY,
+		    name       TEXT        NOT NULL,
+		    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	// Unlock even if ctx is done; the lock is held by the session otherwise.
+	defer conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+	if _, err := conn.ExecContext(ctx, createQuery); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// adoptBaseline records the first migration as applied when its schema
+// already exists, see baselineTable. Any other schema is refused: running the
+// later migrations on it would fail halfway or miss its changes.
+func (m *Migrator) adoptBaseline(ctx context.Context, conn *sql.Conn) (bool, error) {
+	const columnsQuery = `
+		SELECT COALESCE(string_agg(column_name::text, ',' ORDER BY ordinal_position), '')
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()
+		  AND table_name = $1
+	`
+
+	const insertQuery = `
+		INSERT INTO schema_migrations (version, name)
+		VALUES ($1, $2)
+	`
+
+	var exists bool
+	err := conn.QueryRowContext(ctx, `SELECT to_regclass($1) IS NOT NULL`, baselineTable).Scan(&exists)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	var columns string
+	if err := conn.QueryRowContext(ctx, columnsQuery, baselineTable).Scan(&columns); err != nil {
+		return false, err
+	}
+	if columns != baselineColumns {
+		return false, fmt.Errorf("%w: %s has columns %s, want %s", ErrUnknownSchema, baselineTable, columns, baselineColumns)
+	}
+
+	first := m.migrations[0]
+	if _, err := conn.ExecContext(ctx, insertQuery, first.Version, first.Name); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]bool, error) {
+	const query = `
+		SELECT version
+		FROM schema_migrations
+	`
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+func apply(ctx context.Context, conn *sql.Conn, mig Migration, up bool) error {
+	const insertQuery = `
+		INSERT INTO schema_migrations (version, name)
+		VALUES ($1, $2)
+	`
+
+	const deleteQuery = `
+		DELETE FROM schema_migrations
+		WHERE version = $1
+	`
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	script, direction := mig.Up, "up"
+	if !up {
+		script, direction = mig.Down, "down"
+	}
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("migration %s %s: %w", mig, direction, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, insertQuery, mig.Version, mig.Name)
+	} else {
+		_, err = tx.ExecContext(ctx, deleteQuery, mig.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,279 @@
+package migrate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/migrate"
+	"pr-reviwer-assigner/migrations"
+)
+
+var testFS = fstest.MapFS{
+	"0001_init.up.sql":         {Data: []byte("CREATE TABLE teams (team_name TEXT);")},
+	"0001_init.down.sql":       {Data: []byte("DROP TABLE teams;")},
+	"0002_add_users.up.sql":    {Data: []byte("CREATE TABLE users (user_id TEXT);")},
+	"0002_add_users.down.sql":  {Data: []byte("DROP TABLE users;")},
+	"migrations.go":            {Data: []byte("package migrations")},
+	"README.md":                {Data: []byte("not a migration")},
+	"0003_not_sql.up.sql.orig": {Data: []byte("ignored")},
+}
+
+func expectLocked(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestLoad_OrdersAndPairsFiles(t *testing.T) {
+	ms, err := migrate.Load(testFS)
+	require.NoError(t, err)
+	require.Len(t, ms, 2)
+	require.Equal(t, "0001_init", ms[0].String())
+	require.Equal(t, "DROP TABLE teams;", ms[0].Down)
+	require.Equal(t, int64(2), ms[1].Version)
+	require.Equal(t, "add_users", ms[1].Name)
+}
+
+func TestLoad_MissingDownFile(t *testing.T) {
+	_, err := migrate.Load(fstest.MapFS{
+		"0001_init.up.sql": {Data: []byte("SELECT 1;")},
+	})
+	require.ErrorContains(t, err, "0001_init needs both an up and a down file")
+}
+
+func TestLoad_DuplicateVersion(t *testing.T) {
+	_, err := migrate.Load(fstest.MapFS{
+		"0001_init.up.sql":    {Data: []byte("SELECT 1;")},
+		"0001_other.down.sql": {Data: []byte("SELECT 1;")},
+	})
+	require.ErrorContains(t, err, "version 1 is used by")
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	ms, err := migrate.Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, ms)
+	require.Equal(t, "0001_init", ms[0].String())
+	for i, m := range ms {
+		require.Equal(t, int64(i+1), m.Version, "versions must have no gaps")
+	}
+}
+
+func TestUp_AppliesPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := migrate.New(db, testFS)
+	require.NoError(t, err)
+
+	expectLocked(mock)
+	mock.ExpectQuery(`SELECT version\s+FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE users (user_id TEXT);")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO schema_migrations \(version, name\)`).
+		WithArgs(int64(2), "add_users").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	applied, err := m.Up(context.Background())
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	require.Equal(t, "0002_add_users", applied[0].String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUp_AdoptsSchemaCreatedWithoutMigrations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := migrate.New(db, testFS)
+	require.NoError(t, err)
+
+	expectLocked(mock)
+	mock.ExpectQuery(`SELECT version\s+FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectQuery(`SELECT to_regclass\(\$1\) IS NOT NULL`).
+		WithArgs("teams").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`FROM information_schema.columns`).
+		WithArgs("teams").
+		WillReturnRows(sqlmock.NewRows([]string{"columns"}).AddRow("team_name"))
+	mock.ExpectExec(`INSERT INTO schema_migrations \(version, name\)`).
+		WithArgs(int64(1), "init").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE users (user_id TEXT);")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO schema_migrations`).
+		WithArgs(int64(2), "add_users").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	applied, err := m.Up(context.Background())
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	require.Equal(t, int64(2), applied[0].Version)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUp_RefusesUnknownSchemaWithoutHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := migrate.New(db, testFS)
+	require.NoError(t, err)
+
+	expectLocked(mock)
+	mock.ExpectQuery(`SELECT version\s+FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectQuery(`SELECT to_regclass\(\$1\) IS NOT NULL`).
+		WithArgs("teams").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`FROM information_schema.columns`).
+		WithArgs("teams").
+		WillReturnRows(sqlmock.NewRows([]string{"columns"}).AddRow("team_name,reviewer_strategy"))
+	expectUnlock(mock)
+
+	applied, err := m.Up(context.Background())
+	require.ErrorIs(t, err, migrate.ErrUnknownSchema)
+	require.Empty(t, applied)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUp_FailedMigrationRollsBackAndUnlocks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := migrate.New(db, testFS)
+	require.NoError(t, err)
+
+	expectLocked(mock)
+	mock.ExpectQuery(`SELECT version\s+FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE users (user_id TEXT);")).WillReturnError(os.ErrInvalid)
+	mock.ExpectRollback()
+	expectUnlock(mock)
+
+	applied, err := m.Up(context.Background())
+	require.ErrorIs(t, err, os.ErrInvalid)
+	require.ErrorContains(t, err, "migration 0002_add_users up")
+	require.Empty(t, applied)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDown_RollsBackLatest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := migrate.New(db, testFS)
+	require.NoError(t, err)
+
+	expectLocked(mock)
+	mock.ExpectQuery(`SELECT version\s+FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1).AddRow(2))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE users;")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM schema_migrations\s+WHERE version = \$1`).
+		WithArgs(int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	rolledBack, err := m.Down(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, rolledBack)
+	require.Equal(t, "0002_add_users", rolledBack.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDown_NothingApplied(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := migrate.New(db, testFS)
+	require.NoError(t, err)
+
+	expectLocked(mock)
+	mock.ExpectQuery(`SELECT version\s+FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	expectUnlock(mock)
+
+	rolledBack, err := m.Down(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, rolledBack)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatus_ListsPendingAndUnknown(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := migrate.New(db, testFS)
+	require.NoError(t, err)
+
+	at := time.Date(2025, 11, 3, 10, 0, 0, 0, time.UTC)
+
+	expectLocked(mock)
+	mock.ExpectQuery(`SELECT version, name, applied_at\s+FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_at"}).
+			AddRow(1, "init", at).
+			AddRow(7, "from_newer_build", at))
+	expectUnlock(mock)
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+
+	require.Equal(t, "0001_init", statuses[0].String())
+	require.Equal(t, &at, statuses[0].AppliedAt)
+	require.False(t, statuses[0].Unknown)
+
+	require.Equal(t, "0002_add_users", statuses[1].String())
+	require.Nil(t, statuses[1].AppliedAt)
+
+	require.Equal(t, "0007_from_newer_build", statuses[2].String())
+	require.True(t, statuses[2].Unknown)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_WritesNextVersion(t *testing.T) {
+	dir := t.TempDir()
+	for name, f := range testFS {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), f.Data, 0o644))
+	}
+
+	up, down, err := migrate.Create(dir, "Add reviewer-notes")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "0003_add_reviewer_notes.up.sql"), up)
+	require.Equal(t, filepath.Join(dir, "0003_add_reviewer_notes.down.sql"), down)
+
+	ms, err := migrate.Load(os.DirFS(dir))
+	require.NoError(t, err)
+	require.Len(t, ms, 3)
+}
+
+func TestCreate_RejectsInvalidName(t *testing.T) {
+	_, _, err := migrate.Create(t.TempDir(), "drop; users")
+	require.ErrorIs(t, err, migrate.ErrInvalidName)
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
@@ -16,6 +17,7 @@ import (
 type Server struct {
 	app *fiber.App
 	cfg *config.Config
+	c   *di.Container
 
 	stopC chan os.Signal
 }
@@ -28,6 +30,7 @@ func New(cfg *config.Config, c *di.Container) (*Server, error) {
 	return &Server{
 		app:   app,
 		cfg:   cfg,
+		c:     c,
 		stopC: make(chan os.Signal, 1),
 	}, nil
 }
@@ -35,6 +38,17 @@ func New(cfg *config.Config, c *di.Container) (*Server, error) {
 func (s *Server) Run() {
 	signal.Notify(s.stopC, syscall.SIGINT, syscall.SIGTERM)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go s.c.GetEventDispatcher().Run(ctx)
+	go s.c.GetWebhookDeliverer().Run(ctx)
+	go s.c.GetAvailabilityWatcher().Run(ctx)
+	go s.c.GetSLAWatcher().Run(ctx)
+	if digest := s.c.GetChatDigest(); digest != nil {
+		go digest.Run(ctx)
+	}
+
 	go func() {
 		if err := s.app.Listen(s.cfg.HTTPAddr); err != nil {
 			log.Fatal(err)
@@ -0,0 +1,104 @@
+package vcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+)
+
+const (
+	StatusApplied = "applied"
+	StatusIgnored = "ignored"
+)
+
+type Result struct {
+	Status string
+	Reason string
+	PR     *dto.PR
+}
+
+type Applier interface {
+	Apply(ctx context.Context, ev Event) (Result, error)
+}
+
+type applier struct {
+	prs   services.PRService
+	users services.UserService
+}
+
+func NewApplier(prs services.PRService, users services.UserService) Applier {
+	return &applier{
+		prs:   prs,
+		users: users,
+	}
+}
+
+func (a *applier) Apply(ctx context.Context, ev Event) (Result, error) {
+	var (
+		pr  *dto.PR
+		err error
+	)
+
+	switch ev.Action {
+	case ActionOpened:
+		authorID, rerr := a.resolve(ctx, ev.Provider, ev.AuthorLogin)
+		if rerr != nil {
+			return Result{}, rerr
+		}
+
+		created, cerr := a.prs.Create(ctx, dto.PRRequest{
+			ID:       ev.PullRequestID,
+			Name:     ev.Title,
+			AuthorID: authorID,
+			Draft:    ev.Draft,
+		})
+		if errors.Is(cerr, errors2.ErrPRExists) {
+			return Result{Status: StatusIgnored, Reason: "pull request already exists"}, nil
+		}
+		pr, err = &created, cerr
+	case ActionReady:
+		pr, err = a.prs.MarkReady(ctx, dto.PRStateRequest{PullRequestID: ev.PullRequestID})
+	case ActionMerged:
+		// The provider already merged it; the approval gate of /pullRequest/merge
+		// would only leave the PR open here.
+		pr, err = a.prs.RecordMerge(ctx, ev.PullRequestID)
+	case ActionClosed:
+		pr, err = a.prs.Close(ctx, dto.PRStateRequest{PullRequestID: ev.PullRequestID})
+	case ActionReopened:
+		pr, err = a.prs.Reopen(ctx, dto.PRStateRequest{PullRequestID: ev.PullRequestID})
+	case ActionReviewed:
+		reviewerID, rerr := a.resolve(ctx, ev.Provider, ev.ReviewerLogin)
+		if rerr != nil {
+			return Result{}, rerr
+		}
+
+		pr, err = a.prs.SubmitReview(ctx, dto.ReviewRequest{
+			PullRequestID: ev.PullRequestID,
+			ReviewerID:    reviewerID,
+			Verdict:       ev.Verdict,
+		})
+		if errors.Is(err, errors2.ErrNotAssigned) {
+			return Result{Status: StatusIgnored, Reason: "reviewer is not assigned to this PR"}, nil
+		}
+	default:
+		return Result{Status: StatusIgnored, Reason: fmt.Sprintf("unsupported action %q", ev.Action)}, nil
+	}
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{Status: StatusApplied, PR: pr}, nil
+}
+
+func (a *applier) resolve(ctx context.Context, provider, login string) (string, error) {
+	userID, err := a.users.ResolveVCSLogin(ctx, provider, login)
+	if err != nil {
+		return "", fmt.Errorf("%s login %q: %w", provider, login, err)
+	}
+
+	return userID, nil
+}
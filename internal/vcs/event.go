@@ -0,0 +1,33 @@
+package vcs
+
+// Providers, also used as vcs_identities.provider.
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+type Action string
+
+const (
+	ActionOpened   Action = "opened"
+	ActionReady    Action = "ready_for_review"
+	ActionMerged   Action = "merged"
+	ActionClosed   Action = "closed"
+	ActionReopened Action = "reopened"
+	ActionReviewed Action = "reviewed"
+)
+
+// Event is a provider-agnostic pull request event. Adapters translate
+// webhook payloads into events; the Applier turns them into PRService calls.
+type Event struct {
+	Provider      string
+	Action        Action
+	PullRequestID string
+	Title         string
+	AuthorLogin   string
+	Draft         bool
+
+	// ReviewerLogin and Verdict are set for ActionReviewed only.
+	ReviewerLogin string
+	Verdict       string
+}
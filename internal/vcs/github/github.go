@@ -0,0 +1,156 @@
+// Package github translates GitHub webhook deliveries into vcs events.
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/vcs"
+	"strings"
+)
+
+// Header names set by GitHub on every delivery.
+const (
+	EventHeader     = "X-GitHub-Event"
+	SignatureHeader = "X-Hub-Signature-256"
+)
+
+type repository struct {
+	FullName string `json:"full_name"`
+}
+
+type account struct {
+	Login string `json:"login"`
+}
+
+type pullRequest struct {
+	Number int     `json:"number"`
+	Title  string  `json:"title"`
+	Draft  bool    `json:"draft"`
+	Merged bool    `json:"merged"`
+	User   account `json:"user"`
+}
+
+type pullRequestPayload struct {
+	Action      string      `json:"action"`
+	PullRequest pullRequest `json:"pull_request"`
+	Repository  repository  `json:"repository"`
+}
+
+type reviewPayload struct {
+	Action string `json:"action"`
+	Review struct {
+		State string  `json:"state"`
+		User  account `json:"user"`
+	} `json:"review"`
+	PullRequest pullRequest `json:"pull_request"`
+	Repository  repository  `json:"repository"`
+}
+
+// VerifySignature checks the sha256=<hex> HMAC of body sent in X-Hub-Signature-256.
+func VerifySignature(secret string, body []byte, header string) bool {
+	if secret == "" {
+		return false
+	}
+
+	got, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	sig, err := hex.DecodeString(got)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
+// PullRequestID is the pull_request_id GitHub PRs are stored under, e.g. "octo/app#42".
+func PullRequestID(repo string, number int) string {
+	return fmt.Sprintf("%s#%d", repo, number)
+}
+
+// ParseEvent maps a delivery to an event. It returns nil for event types and
+// actions that don't affect the PR lifecycle (ping, labeled, edited, ...).
+func ParseEvent(eventType string, body []byte) (*vcs.Event, error) {
+	switch eventType {
+	case "pull_request":
+		return parsePullRequest(body)
+	case "pull_request_review":
+		return parseReview(body)
+	default:
+		return nil, nil
+	}
+}
+
+func parsePullRequest(body []byte) (*vcs.Event, error) {
+	var p pullRequestPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("decode pull_request payload: %w", err)
+	}
+
+	ev := vcs.Event{
+		Provider:      vcs.ProviderGitHub,
+		PullRequestID: PullRequestID(p.Repository.FullName, p.PullRequest.Number),
+		Title:         p.PullRequest.Title,
+		AuthorLogin:   p.PullRequest.User.Login,
+		Draft:         p.PullRequest.Draft,
+	}
+
+	switch p.Action {
+	case "opened":
+		ev.Action = vcs.ActionOpened
+	case "ready_for_review":
+		ev.Action = vcs.ActionReady
+	case "reopened":
+		ev.Action = vcs.ActionReopened
+	case "closed":
+		ev.Action = vcs.ActionClosed
+		if p.PullRequest.Merged {
+			ev.Action = vcs.ActionMerged
+		}
+	default:
+		return nil, nil
+	}
+
+	return &ev, nil
+}
+
+func parseReview(body []byte) (*vcs.Event, error) {
+	var p reviewPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("decode pull_request_review payload: %w", err)
+	}
+
+	if p.Action != "submitted" {
+		return nil, nil
+	}
+
+	var verdict string
+	switch strings.ToLower(p.Review.State) {
+	case "approved":
+		verdict = dto.VerdictApproved
+	case "changes_requested":
+		verdict = dto.VerdictChangesRequested
+	case "commented":
+		verdict = dto.VerdictCommented
+	default:
+		return nil, nil
+	}
+
+	return &vcs.Event{
+		Provider:      vcs.ProviderGitHub,
+		Action:        vcs.ActionReviewed,
+		PullRequestID: PullRequestID(p.Repository.FullName, p.PullRequest.Number),
+		Title:         p.PullRequest.Title,
+		AuthorLogin:   p.PullRequest.User.Login,
+		ReviewerLogin: p.Review.User.Login,
+		Verdict:       verdict,
+	}, nil
+}
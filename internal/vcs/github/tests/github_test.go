@@ -0,0 +1,82 @@
+package github_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/vcs"
+	"pr-reviwer-assigner/internal/vcs/github"
+)
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseEvent_PullRequestActions(t *testing.T) {
+	cases := []struct {
+		fixture string
+		action  vcs.Action
+	}{
+		{"pull_request_opened.json", vcs.ActionOpened},
+		{"pull_request_closed_merged.json", vcs.ActionMerged},
+		{"pull_request_closed.json", vcs.ActionClosed},
+		{"pull_request_reopened.json", vcs.ActionReopened},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			ev, err := github.ParseEvent("pull_request", fixture(t, tc.fixture))
+			require.NoError(t, err)
+			require.NotNil(t, ev)
+			require.Equal(t, tc.action, ev.Action)
+			require.Equal(t, vcs.ProviderGitHub, ev.Provider)
+			require.Equal(t, "acme/billing#42", ev.PullRequestID)
+			require.Equal(t, "Add invoice export", ev.Title)
+			require.Equal(t, "alice-dev", ev.AuthorLogin)
+		})
+	}
+}
+
+func TestParseEvent_Review(t *testing.T) {
+	ev, err := github.ParseEvent("pull_request_review", fixture(t, "pull_request_review_submitted.json"))
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	require.Equal(t, vcs.ActionReviewed, ev.Action)
+	require.Equal(t, "bob-ops", ev.ReviewerLogin)
+	require.Equal(t, dto.VerdictApproved, ev.Verdict)
+}
+
+func TestParseEvent_Ignored(t *testing.T) {
+	ev, err := github.ParseEvent("pull_request", fixture(t, "pull_request_labeled.json"))
+	require.NoError(t, err)
+	require.Nil(t, ev)
+
+	ev, err = github.ParseEvent("ping", []byte(`{"zen":"Keep it logically awesome."}`))
+	require.NoError(t, err)
+	require.Nil(t, ev)
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := fixture(t, "pull_request_opened.json")
+
+	require.True(t, github.VerifySignature("s3cret", body, sign("s3cret", body)))
+	require.False(t, github.VerifySignature("s3cret", body, sign("other", body)))
+	require.False(t, github.VerifySignature("s3cret", body, "sha1=deadbeef"))
+	require.False(t, github.VerifySignature("", body, sign("", body)))
+}
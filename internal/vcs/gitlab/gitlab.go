@@ -0,0 +1,111 @@
+// Package gitlab translates GitLab Merge Request Hook deliveries into vcs events.
+package gitlab
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/vcs"
+)
+
+// Header names set by GitLab on every delivery.
+const (
+	EventHeader = "X-Gitlab-Event"
+	TokenHeader = "X-Gitlab-Token"
+)
+
+const mergeRequestHook = "Merge Request Hook"
+
+type boolChange struct {
+	Previous bool `json:"previous"`
+	Current  bool `json:"current"`
+}
+
+type mergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID            int    `json:"iid"`
+		Title          string `json:"title"`
+		Action         string `json:"action"`
+		Draft          bool   `json:"draft"`
+		WorkInProgress bool   `json:"work_in_progress"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft          *boolChange `json:"draft"`
+		WorkInProgress *boolChange `json:"work_in_progress"`
+	} `json:"changes"`
+}
+
+// VerifyToken compares the X-Gitlab-Token header with the configured token.
+func VerifyToken(token, header string) bool {
+	if token == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(header)) == 1
+}
+
+// PullRequestID is the pull_request_id GitLab MRs are stored under, e.g. "acme/app!7".
+func PullRequestID(project string, iid int) string {
+	return fmt.Sprintf("%s!%d", project, iid)
+}
+
+// ParseEvent maps a delivery to an event. It returns nil for hooks and
+// actions that don't affect the PR lifecycle.
+//
+// GitLab payloads carry only the numeric author_id, so for "open" the user
+// who triggered the hook is taken as the author.
+func ParseEvent(eventType string, body []byte) (*vcs.Event, error) {
+	if eventType != mergeRequestHook {
+		return nil, nil
+	}
+
+	var p mergeRequestPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("decode merge request payload: %w", err)
+	}
+
+	attrs := p.ObjectAttributes
+	ev := vcs.Event{
+		Provider:      vcs.ProviderGitLab,
+		PullRequestID: PullRequestID(p.Project.PathWithNamespace, attrs.IID),
+		Title:         attrs.Title,
+		Draft:         attrs.Draft || attrs.WorkInProgress,
+	}
+
+	switch attrs.Action {
+	case "open":
+		ev.Action = vcs.ActionOpened
+		ev.AuthorLogin = p.User.Username
+	case "reopen":
+		ev.Action = vcs.ActionReopened
+	case "close":
+		ev.Action = vcs.ActionClosed
+	case "merge":
+		ev.Action = vcs.ActionMerged
+	case "approved", "approval":
+		ev.Action = vcs.ActionReviewed
+		ev.ReviewerLogin = p.User.Username
+		ev.Verdict = dto.VerdictApproved
+	case "update":
+		if !leftDraft(p.Changes.Draft) && !leftDraft(p.Changes.WorkInProgress) {
+			return nil, nil
+		}
+		ev.Action = vcs.ActionReady
+	default:
+		return nil, nil
+	}
+
+	return &ev, nil
+}
+
+func leftDraft(c *boolChange) bool {
+	return c != nil && c.Previous && !c.Current
+}
@@ -0,0 +1,81 @@
+package gitlab_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/vcs"
+	"pr-reviwer-assigner/internal/vcs/gitlab"
+)
+
+const hook = "Merge Request Hook"
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseEvent_Actions(t *testing.T) {
+	cases := []struct {
+		fixture string
+		action  vcs.Action
+	}{
+		{"merge_request_open.json", vcs.ActionOpened},
+		{"merge_request_update_ready.json", vcs.ActionReady},
+		{"merge_request_merge.json", vcs.ActionMerged},
+		{"merge_request_close.json", vcs.ActionClosed},
+		{"merge_request_approved.json", vcs.ActionReviewed},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			ev, err := gitlab.ParseEvent(hook, fixture(t, tc.fixture))
+			require.NoError(t, err)
+			require.NotNil(t, ev)
+			require.Equal(t, tc.action, ev.Action)
+			require.Equal(t, vcs.ProviderGitLab, ev.Provider)
+			require.Equal(t, "fintech/payments!7", ev.PullRequestID)
+		})
+	}
+}
+
+func TestParseEvent_OpenTakesAuthorFromUser(t *testing.T) {
+	ev, err := gitlab.ParseEvent(hook, fixture(t, "merge_request_open.json"))
+	require.NoError(t, err)
+	require.Equal(t, "carol.d", ev.AuthorLogin)
+	require.Equal(t, "Retry failed captures", ev.Title)
+	require.False(t, ev.Draft)
+
+	ev, err = gitlab.ParseEvent(hook, fixture(t, "merge_request_open_draft.json"))
+	require.NoError(t, err)
+	require.True(t, ev.Draft)
+}
+
+func TestParseEvent_Approved(t *testing.T) {
+	ev, err := gitlab.ParseEvent(hook, fixture(t, "merge_request_approved.json"))
+	require.NoError(t, err)
+	require.Equal(t, "dan.park", ev.ReviewerLogin)
+	require.Equal(t, dto.VerdictApproved, ev.Verdict)
+}
+
+func TestParseEvent_Ignored(t *testing.T) {
+	ev, err := gitlab.ParseEvent(hook, fixture(t, "merge_request_update_title.json"))
+	require.NoError(t, err)
+	require.Nil(t, ev)
+
+	ev, err = gitlab.ParseEvent("Push Hook", []byte(`{"object_kind":"push"}`))
+	require.NoError(t, err)
+	require.Nil(t, ev)
+}
+
+func TestVerifyToken(t *testing.T) {
+	require.True(t, gitlab.VerifyToken("tok", "tok"))
+	require.False(t, gitlab.VerifyToken("tok", "TOK"))
+	require.False(t, gitlab.VerifyToken("", ""))
+}
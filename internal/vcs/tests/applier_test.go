@@ -0,0 +1,149 @@
+package vcs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pr-reviwer-assigner/internal/domain/dto"
+	"pr-reviwer-assigner/internal/domain/services"
+	errors2 "pr-reviwer-assigner/internal/errors"
+	"pr-reviwer-assigner/internal/vcs"
+)
+
+type prServiceStub struct {
+	services.PRService
+
+	created  []dto.PRRequest
+	merged   []string
+	reviews  []dto.ReviewRequest
+	createFn func(req dto.PRRequest) error
+	reviewFn func(req dto.ReviewRequest) error
+
+	minApprovals int
+}
+
+func (s *prServiceStub) Create(_ context.Context, req dto.PRRequest) (dto.PR, error) {
+	if s.createFn != nil {
+		if err := s.createFn(req); err != nil {
+			return dto.PR{}, err
+		}
+	}
+	s.created = append(s.created, req)
+	return dto.PR{ID: req.ID, AuthorID: req.AuthorID, Status: dto.PRStatusOpen}, nil
+}
+
+// Merge is gated like the repository's with minApprovals.
+func (s *prServiceStub) Merge(_ context.Context, req dto.MergeRequest) (*dto.PR, error) {
+	if s.minApprovals > 0 {
+		return nil, errors2.ErrNotEnoughApprovals
+	}
+	s.merged = append(s.merged, req.PullRequestID)
+	return &dto.PR{ID: req.PullRequestID, Status: dto.PRStatusMerged}, nil
+}
+
+func (s *prServiceStub) RecordMerge(_ context.Context, prID string) (*dto.PR, error) {
+	s.merged = append(s.merged, prID)
+	return &dto.PR{ID: prID, Status: dto.PRStatusMerged}, nil
+}
+
+func (s *prServiceStub) SubmitReview(_ context.Context, req dto.ReviewRequest) (*dto.PR, error) {
+	if s.reviewFn != nil {
+		if err := s.reviewFn(req); err != nil {
+			return nil, err
+		}
+	}
+	s.reviews = append(s.reviews, req)
+	return &dto.PR{ID: req.PullRequestID, Status: dto.PRStatusOpen}, nil
+}
+
+type userServiceStub struct {
+	services.UserService
+
+	logins map[string]string
+}
+
+func (s *userServiceStub) ResolveVCSLogin(_ context.Context, _ string, login string) (string, error) {
+	id, ok := s.logins[login]
+	if !ok {
+		return "", errors2.ErrNotFound
+	}
+	return id, nil
+}
+
+func TestApplier_OpenedResolvesAuthor(t *testing.T) {
+	prs := &prServiceStub{}
+	users := &userServiceStub{logins: map[string]string{"alice-dev": "u1"}}
+	a := vcs.NewApplier(prs, users)
+
+	res, err := a.Apply(context.Background(), vcs.Event{
+		Provider:      vcs.ProviderGitHub,
+		Action:        vcs.ActionOpened,
+		PullRequestID: "acme/billing#42",
+		Title:         "Add invoice export",
+		AuthorLogin:   "alice-dev",
+		Draft:         true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, vcs.StatusApplied, res.Status)
+	require.Len(t, prs.created, 1)
+	require.Equal(t, "u1", prs.created[0].AuthorID)
+	require.True(t, prs.created[0].Draft)
+}
+
+func TestApplier_OpenedTwiceIsIgnored(t *testing.T) {
+	prs := &prServiceStub{createFn: func(dto.PRRequest) error { return errors2.ErrPRExists }}
+	users := &userServiceStub{logins: map[string]string{"alice-dev": "u1"}}
+	a := vcs.NewApplier(prs, users)
+
+	res, err := a.Apply(context.Background(), vcs.Event{
+		Action:        vcs.ActionOpened,
+		PullRequestID: "acme/billing#42",
+		AuthorLogin:   "alice-dev",
+	})
+	require.NoError(t, err)
+	require.Equal(t, vcs.StatusIgnored, res.Status)
+}
+
+func TestApplier_UnknownLogin(t *testing.T) {
+	a := vcs.NewApplier(&prServiceStub{}, &userServiceStub{})
+
+	_, err := a.Apply(context.Background(), vcs.Event{
+		Provider:    vcs.ProviderGitHub,
+		Action:      vcs.ActionOpened,
+		AuthorLogin: "stranger",
+	})
+	require.ErrorIs(t, err, errors2.ErrNotFound)
+	require.Contains(t, err.Error(), "stranger")
+}
+
+func TestApplier_ReviewFromUnassignedUserIsIgnored(t *testing.T) {
+	prs := &prServiceStub{reviewFn: func(dto.ReviewRequest) error { return errors2.ErrNotAssigned }}
+	users := &userServiceStub{logins: map[string]string{"bob-ops": "u7"}}
+	a := vcs.NewApplier(prs, users)
+
+	res, err := a.Apply(context.Background(), vcs.Event{
+		Action:        vcs.ActionReviewed,
+		PullRequestID: "acme/billing#42",
+		ReviewerLogin: "bob-ops",
+		Verdict:       dto.VerdictApproved,
+	})
+	require.NoError(t, err)
+	require.Equal(t, vcs.StatusIgnored, res.Status)
+}
+
+func TestApplier_MergedIsRecordedWithoutApprovals(t *testing.T) {
+	prs := &prServiceStub{minApprovals: 2}
+	a := vcs.NewApplier(prs, &userServiceStub{})
+
+	res, err := a.Apply(context.Background(), vcs.Event{
+		Provider:      vcs.ProviderGitHub,
+		Action:        vcs.ActionMerged,
+		PullRequestID: "acme/billing#42",
+	})
+	require.NoError(t, err)
+	require.Equal(t, vcs.StatusApplied, res.Status)
+	require.Equal(t, dto.PRStatusMerged, res.PR.Status)
+	require.Equal(t, []string{"acme/billing#42"}, prs.merged)
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS
+    pull_request_reviewers,
+    pull_requests,
+    users,
+    teams;
+
+DROP TYPE IF EXISTS pull_request_status;
@@ -35,4 +35,4 @@ CREATE TABLE pull_request_reviewers (
 );
 
 CREATE INDEX idx_pull_request_reviewers_reviewer ON pull_request_reviewers (reviewer_id);
-CREATE UNIQUE INDEX ux_users_team_name_username ON users(team_name, username);
\ No newline at end of file
+CREATE UNIQUE INDEX ux_users_team_name_username ON users(team_name, username);
@@ -0,0 +1,3 @@
+ALTER TABLE teams
+    DROP COLUMN rr_cursor,
+    DROP COLUMN reviewer_strategy;
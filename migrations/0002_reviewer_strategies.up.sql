@@ -0,0 +1,3 @@
+ALTER TABLE teams
+    ADD COLUMN reviewer_strategy TEXT NOT NULL DEFAULT 'round_robin',
+    ADD COLUMN rr_cursor         TEXT;
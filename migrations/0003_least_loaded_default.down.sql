@@ -0,0 +1,2 @@
+ALTER TABLE teams
+    ALTER COLUMN reviewer_strategy SET DEFAULT 'round_robin';
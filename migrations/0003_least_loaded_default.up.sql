@@ -0,0 +1,4 @@
+-- Existing teams keep the strategy they have; only new ones default to
+-- least_loaded.
+ALTER TABLE teams
+    ALTER COLUMN reviewer_strategy SET DEFAULT 'least_loaded';
@@ -0,0 +1,8 @@
+ALTER TABLE pull_requests
+    DROP COLUMN requested_reviewers;
+
+ALTER TABLE teams
+    DROP CONSTRAINT teams_reviewers_bounds,
+    DROP COLUMN max_reviewers,
+    DROP COLUMN min_reviewers,
+    DROP COLUMN reviewers_count;
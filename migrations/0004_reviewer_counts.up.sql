@@ -0,0 +1,9 @@
+ALTER TABLE teams
+    ADD COLUMN reviewers_count INTEGER NOT NULL DEFAULT 2,
+    ADD COLUMN min_reviewers   INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN max_reviewers   INTEGER NOT NULL DEFAULT 5,
+    ADD CONSTRAINT teams_reviewers_bounds
+        CHECK (0 <= min_reviewers AND min_reviewers <= reviewers_count AND reviewers_count <= max_reviewers);
+
+ALTER TABLE pull_requests
+    ADD COLUMN requested_reviewers INTEGER NOT NULL DEFAULT 2;
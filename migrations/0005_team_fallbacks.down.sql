@@ -0,0 +1,4 @@
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN source_team;
+
+DROP TABLE team_fallbacks;
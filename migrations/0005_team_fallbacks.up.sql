@@ -0,0 +1,27 @@
+-- Fallback teams and the team each reviewer was picked from. Reviewers
+-- assigned before source_team existed came from their own team.
+
+CREATE TABLE team_fallbacks (
+    team_name     TEXT NOT NULL REFERENCES teams(team_name)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    fallback_team TEXT NOT NULL REFERENCES teams(team_name)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    position      INTEGER NOT NULL,
+    PRIMARY KEY (team_name, fallback_team),
+    CONSTRAINT team_fallbacks_not_self CHECK (team_name <> fallback_team)
+);
+
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN source_team TEXT REFERENCES teams(team_name)
+        ON UPDATE CASCADE
+        ON DELETE RESTRICT;
+
+UPDATE pull_request_reviewers prr
+   SET source_team = u.team_name
+  FROM users u
+ WHERE u.user_id = prr.reviewer_id;
+
+ALTER TABLE pull_request_reviewers
+    ALTER COLUMN source_team SET NOT NULL;
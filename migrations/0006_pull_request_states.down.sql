@@ -0,0 +1,16 @@
+ALTER TABLE pull_requests
+    DROP COLUMN closed_at;
+
+-- Enum values can't be dropped: the type is rebuilt without them. Closed and
+-- draft pull requests become open ones again.
+UPDATE pull_requests
+   SET status = 'OPEN'
+ WHERE status IN ('CLOSED', 'DRAFT');
+
+ALTER TYPE pull_request_status RENAME TO pull_request_status_old;
+CREATE TYPE pull_request_status AS ENUM ('OPEN', 'MERGED');
+
+ALTER TABLE pull_requests
+    ALTER COLUMN status TYPE pull_request_status USING status::text::pull_request_status;
+
+DROP TYPE pull_request_status_old;
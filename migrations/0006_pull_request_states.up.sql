@@ -0,0 +1,10 @@
+-- The new statuses aren't used in this file, which Postgres requires of enum
+-- values added inside a transaction.
+
+ALTER TYPE pull_request_status ADD VALUE 'CLOSED';
+ALTER TYPE pull_request_status ADD VALUE 'DRAFT';
+
+-- Times stay strings like created_at and merged_at until
+-- 0021_pull_request_timestamps.
+ALTER TABLE pull_requests
+    ADD COLUMN closed_at TEXT;
@@ -0,0 +1,11 @@
+DROP TABLE pull_request_reviews;
+
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN verdict_at,
+    DROP COLUMN verdict;
+
+ALTER TABLE teams
+    DROP CONSTRAINT teams_min_approvals_non_negative,
+    DROP COLUMN min_approvals;
+
+DROP TYPE review_verdict;
@@ -0,0 +1,23 @@
+CREATE TYPE review_verdict AS ENUM ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED');
+
+ALTER TABLE teams
+    ADD COLUMN min_approvals INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT teams_min_approvals_non_negative CHECK (min_approvals >= 0);
+
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN verdict    review_verdict,
+    ADD COLUMN verdict_at TIMESTAMPTZ;
+
+CREATE TABLE pull_request_reviews (
+    id              BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    reviewer_id     TEXT NOT NULL REFERENCES users(user_id)
+        ON UPDATE CASCADE
+        ON DELETE RESTRICT,
+    verdict         review_verdict NOT NULL,
+    submitted_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_pull_request_reviews_pr ON pull_request_reviews (pull_request_id, submitted_at);
@@ -0,0 +1 @@
+DROP TABLE vcs_identities;
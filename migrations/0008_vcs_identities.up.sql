@@ -0,0 +1,8 @@
+CREATE TABLE vcs_identities (
+    provider TEXT NOT NULL,
+    login    TEXT NOT NULL,
+    user_id  TEXT NOT NULL REFERENCES users(user_id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    PRIMARY KEY (provider, login)
+);
@@ -0,0 +1,17 @@
+CREATE TABLE outbox_events (
+    id            BIGSERIAL PRIMARY KEY,
+    event_type    TEXT NOT NULL,
+    aggregate_id  TEXT NOT NULL,
+    payload       JSONB NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    dispatched_at TIMESTAMPTZ,
+    locked_until  TIMESTAMPTZ,
+    attempts      INTEGER NOT NULL DEFAULT 0,
+    last_error    TEXT,
+    -- set once the event ran out of attempts; it is not dispatched any more
+    dead_at       TIMESTAMPTZ,
+    -- Sinks that already accepted the event; a retry skips them.
+    delivered_to  TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events (id) WHERE dispatched_at IS NULL AND dead_at IS NULL;
@@ -0,0 +1,28 @@
+CREATE TABLE webhook_subscriptions (
+    id          BIGSERIAL PRIMARY KEY,
+    team_name   TEXT NOT NULL REFERENCES teams(team_name)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    url         TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    secret      TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE webhook_deliveries (
+    id               BIGSERIAL PRIMARY KEY,
+    subscription_id  BIGINT NOT NULL REFERENCES webhook_subscriptions(id)
+        ON DELETE CASCADE,
+    event_id         BIGINT NOT NULL REFERENCES outbox_events(id)
+        ON DELETE CASCADE,
+    status           TEXT NOT NULL DEFAULT 'PENDING'
+        CHECK (status IN ('PENDING', 'DELIVERED', 'DEAD')),
+    attempts         INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_status_code INTEGER,
+    last_error       TEXT,
+    delivered_at     TIMESTAMPTZ,
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at, id) WHERE status = 'PENDING';
@@ -0,0 +1,5 @@
+ALTER TABLE users
+    DROP COLUMN chat_handle;
+
+ALTER TABLE teams
+    DROP COLUMN chat_webhook_url;
@@ -0,0 +1,4 @@
+DROP TABLE email_deliveries;
+
+ALTER TABLE users
+    DROP COLUMN email;
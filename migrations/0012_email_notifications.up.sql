@@ -0,0 +1,12 @@
+ALTER TABLE users
+    ADD COLUMN email TEXT NOT NULL DEFAULT '';
+
+-- Recipients already emailed about an event, so a retry after a transient
+-- SMTP error doesn't mail them again.
+CREATE TABLE email_deliveries (
+    event_id BIGINT NOT NULL REFERENCES outbox_events(id)
+        ON DELETE CASCADE,
+    user_id  TEXT NOT NULL,
+    sent_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (event_id, user_id)
+);
@@ -0,0 +1,20 @@
+CREATE TABLE user_unavailability (
+    id            BIGSERIAL PRIMARY KEY,
+    user_id       TEXT NOT NULL REFERENCES users(user_id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    starts_at     TIMESTAMPTZ NOT NULL,
+    ends_at       TIMESTAMPTZ NOT NULL,
+    reason        TEXT NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    -- set once the user's open reviews were handed over for this window
+    reassigned_at TIMESTAMPTZ,
+    -- failed hand-overs, retried with backoff until reassign_attempts runs out
+    reassign_attempts INTEGER NOT NULL DEFAULT 0,
+    reassign_error    TEXT,
+    next_attempt_at   TIMESTAMPTZ,
+    CONSTRAINT user_unavailability_range CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability (user_id, ends_at);
+CREATE INDEX idx_user_unavailability_pending ON user_unavailability (starts_at) WHERE reassigned_at IS NULL;
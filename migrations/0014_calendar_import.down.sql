@@ -0,0 +1,4 @@
+DROP INDEX ux_user_unavailability_external;
+
+ALTER TABLE user_unavailability
+    DROP COLUMN external_id;
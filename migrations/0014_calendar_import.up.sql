@@ -0,0 +1,5 @@
+ALTER TABLE user_unavailability
+    -- UID of the calendar event the window was imported from
+    ADD COLUMN external_id TEXT;
+
+CREATE UNIQUE INDEX ux_user_unavailability_external ON user_unavailability (user_id, external_id) WHERE external_id IS NOT NULL;
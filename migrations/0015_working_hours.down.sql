@@ -0,0 +1,13 @@
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN policy;
+
+ALTER TABLE users
+    DROP CONSTRAINT users_working_hours_complete,
+    DROP COLUMN work_days,
+    DROP COLUMN work_end,
+    DROP COLUMN work_start,
+    DROP COLUMN time_zone;
+
+ALTER TABLE teams
+    DROP CONSTRAINT teams_working_hours_lead_non_negative,
+    DROP COLUMN working_hours_lead_minutes;
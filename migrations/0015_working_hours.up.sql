@@ -0,0 +1,24 @@
+ALTER TABLE teams
+    -- reviewers whose working hours start this soon are preferred over off-hours ones
+    ADD COLUMN working_hours_lead_minutes INTEGER NOT NULL DEFAULT 60,
+    ADD CONSTRAINT teams_working_hours_lead_non_negative CHECK (working_hours_lead_minutes >= 0);
+
+-- Working hours are either all set or all NULL; users without them count as
+-- always working.
+ALTER TABLE users
+    -- IANA time zone name, e.g. Europe/Berlin
+    ADD COLUMN time_zone  TEXT,
+    -- work_end <= work_start means the working hours run past midnight
+    ADD COLUMN work_start TIME,
+    ADD COLUMN work_end   TIME,
+    -- ISO weekdays the working hours start on, 1 = Monday
+    ADD COLUMN work_days  SMALLINT[],
+    ADD CONSTRAINT users_working_hours_complete CHECK (
+        (time_zone IS NULL) = (work_start IS NULL)
+        AND (time_zone IS NULL) = (work_end IS NULL)
+        AND (time_zone IS NULL) = (work_days IS NULL)
+    );
+
+ALTER TABLE pull_request_reviewers
+    -- selection policy the reviewer was picked under, e.g. working_hours
+    ADD COLUMN policy TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN owner_pattern;
+
+ALTER TABLE pull_requests
+    DROP COLUMN changed_files;
+
+DROP TABLE team_codeowners;
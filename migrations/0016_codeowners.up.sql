@@ -0,0 +1,16 @@
+CREATE TABLE team_codeowners (
+    team_name  TEXT PRIMARY KEY REFERENCES teams(team_name)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    -- CODEOWNERS file as uploaded; owners of the author's team rules are assigned first
+    content    TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE pull_requests
+    -- paths the PR touches, matched against the author's team CODEOWNERS
+    ADD COLUMN changed_files TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE pull_request_reviewers
+    -- CODEOWNERS pattern the reviewer was picked as an owner of
+    ADD COLUMN owner_pattern TEXT NOT NULL DEFAULT '';
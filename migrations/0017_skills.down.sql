@@ -0,0 +1,7 @@
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN match_score;
+
+ALTER TABLE pull_requests
+    DROP COLUMN required_skills;
+
+DROP TABLE user_skills;
@@ -0,0 +1,16 @@
+CREATE TABLE user_skills (
+    user_id TEXT NOT NULL REFERENCES users(user_id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    -- lower-cased tag, e.g. go, postgres, frontend
+    skill   TEXT NOT NULL,
+    PRIMARY KEY (user_id, skill)
+);
+
+ALTER TABLE pull_requests
+    -- skill tags reviewers are preferred to have
+    ADD COLUMN required_skills TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE pull_request_reviewers
+    -- share of the PR's required skills the reviewer had, NULL when none were required
+    ADD COLUMN match_score REAL;
@@ -0,0 +1,11 @@
+-- reviewers the PR author asked for (required) or ruled out (excluded)
+CREATE TABLE pull_request_reviewer_rules (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    user_id         TEXT NOT NULL REFERENCES users(user_id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    required        BOOLEAN NOT NULL,
+    PRIMARY KEY (pull_request_id, user_id)
+);
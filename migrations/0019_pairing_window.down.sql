@@ -0,0 +1,5 @@
+DROP INDEX idx_pull_requests_author;
+
+ALTER TABLE teams
+    DROP CONSTRAINT teams_pairing_window_positive,
+    DROP COLUMN pairing_window;
@@ -0,0 +1,6 @@
+ALTER TABLE teams
+    -- how many of the author's latest PRs the pairing_aware strategy looks at
+    ADD COLUMN pairing_window INTEGER NOT NULL DEFAULT 5,
+    ADD CONSTRAINT teams_pairing_window_positive CHECK (pairing_window > 0);
+
+CREATE INDEX idx_pull_requests_author ON pull_requests (author_id, created_at);
@@ -0,0 +1,10 @@
+DROP INDEX idx_pull_request_reviewers_sla;
+
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN sla_breached_at,
+    DROP COLUMN assigned_at;
+
+ALTER TABLE teams
+    DROP CONSTRAINT teams_review_sla_non_negative,
+    DROP COLUMN sla_auto_reassign,
+    DROP COLUMN review_sla_hours;
@@ -0,0 +1,25 @@
+-- Review SLA. Reviewers assigned before assigned_at existed are dated by
+-- their pull request; its created_at is still Go's time.Time.String() in UTC
+-- here, e.g. "2025-10-24 12:34:56.123456789 +0000 UTC", and is read without
+-- the zone abbreviation.
+
+ALTER TABLE teams
+    -- working hours a reviewer has for their first verdict, 0 disables the SLA
+    ADD COLUMN review_sla_hours  INTEGER NOT NULL DEFAULT 0,
+    -- hand a review over once its SLA is breached
+    ADD COLUMN sla_auto_reassign BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT teams_review_sla_non_negative CHECK (review_sla_hours >= 0);
+
+ALTER TABLE pull_request_reviewers
+    -- the SLA clock starts here; it is restarted when the PR is reopened or marked ready
+    ADD COLUMN assigned_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN sla_breached_at TIMESTAMPTZ;
+
+UPDATE pull_request_reviewers prr
+   SET assigned_at = regexp_replace(btrim(pr.created_at), '\s+[A-Z]{3,5}$', '')::timestamptz
+  FROM pull_requests pr
+ WHERE pr.pull_request_id = prr.pull_request_id
+   AND btrim(pr.created_at) <> '';
+
+CREATE INDEX idx_pull_request_reviewers_sla ON pull_request_reviewers (assigned_at, pull_request_id, reviewer_id)
+    WHERE verdict IS NULL AND sla_breached_at IS NULL;
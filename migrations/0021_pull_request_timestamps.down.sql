@@ -0,0 +1,14 @@
+-- Writes the times back in the format of time.Time.String() in UTC, to
+-- microseconds.
+
+ALTER TABLE pull_requests
+    DROP COLUMN updated_at,
+    ALTER COLUMN created_at DROP DEFAULT;
+
+ALTER TABLE pull_requests
+    ALTER COLUMN created_at TYPE TEXT
+        USING to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:MI:SS.US') || ' +0000 UTC',
+    ALTER COLUMN merged_at TYPE TEXT
+        USING to_char(merged_at AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:MI:SS.US') || ' +0000 UTC',
+    ALTER COLUMN closed_at TYPE TEXT
+        USING to_char(closed_at AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:MI:SS.US') || ' +0000 UTC';
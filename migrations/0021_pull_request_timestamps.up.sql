@@ -0,0 +1,23 @@
+-- Pull request times become timestamptz. The old values are Go's
+-- time.Time.String() in UTC, e.g. "2025-10-24 12:34:56.123456789 +0000 UTC";
+-- the trailing zone abbreviation is dropped and the offset kept.
+
+ALTER TABLE pull_requests
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ
+        USING NULLIF(regexp_replace(btrim(created_at), '\s+[A-Z]{3,5}$', ''), '')::timestamptz,
+    ALTER COLUMN merged_at TYPE TIMESTAMPTZ
+        USING NULLIF(regexp_replace(btrim(merged_at), '\s+[A-Z]{3,5}$', ''), '')::timestamptz,
+    ALTER COLUMN closed_at TYPE TIMESTAMPTZ
+        USING NULLIF(regexp_replace(btrim(closed_at), '\s+[A-Z]{3,5}$', ''), '')::timestamptz;
+
+ALTER TABLE pull_requests
+    ALTER COLUMN created_at SET DEFAULT now(),
+    -- last status change
+    ADD COLUMN updated_at TIMESTAMPTZ;
+
+UPDATE pull_requests
+   SET updated_at = GREATEST(created_at, merged_at, closed_at);
+
+ALTER TABLE pull_requests
+    ALTER COLUMN updated_at SET DEFAULT now(),
+    ALTER COLUMN updated_at SET NOT NULL;
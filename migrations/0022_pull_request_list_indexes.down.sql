@@ -0,0 +1,10 @@
+DROP INDEX idx_pull_request_reviewers_reviewer;
+CREATE INDEX idx_pull_request_reviewers_reviewer ON pull_request_reviewers (reviewer_id);
+
+DROP INDEX idx_pull_requests_author;
+CREATE INDEX idx_pull_requests_author ON pull_requests (author_id, created_at);
+
+DROP INDEX idx_pull_requests_status_updated;
+DROP INDEX idx_pull_requests_status_created;
+DROP INDEX idx_pull_requests_updated;
+DROP INDEX idx_pull_requests_created;
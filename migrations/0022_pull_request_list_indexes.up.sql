@@ -0,0 +1,17 @@
+-- Indexes for /pullRequest/list. Every one ends in the keyset columns
+-- (sort time, pull_request_id), so a page is an index range scan in either
+-- direction whatever the filter.
+
+CREATE INDEX idx_pull_requests_created ON pull_requests (created_at, pull_request_id);
+CREATE INDEX idx_pull_requests_updated ON pull_requests (updated_at, pull_request_id);
+CREATE INDEX idx_pull_requests_status_created ON pull_requests (status, created_at, pull_request_id);
+CREATE INDEX idx_pull_requests_status_updated ON pull_requests (status, updated_at, pull_request_id);
+
+-- Extends the index the pairing-aware strategy already reads recent
+-- pull requests of an author from.
+DROP INDEX idx_pull_requests_author;
+CREATE INDEX idx_pull_requests_author ON pull_requests (author_id, created_at, pull_request_id);
+
+-- Covers the reviewer filter without visiting the table.
+DROP INDEX idx_pull_request_reviewers_reviewer;
+CREATE INDEX idx_pull_request_reviewers_reviewer ON pull_request_reviewers (reviewer_id, pull_request_id);
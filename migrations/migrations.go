@@ -0,0 +1,9 @@
+// Package migrations embeds the numbered SQL migrations of the schema. Every
+// version has a NNNN_name.up.sql and a NNNN_name.down.sql file; new ones are
+// created with "prservice migrate create <name>".
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS